@@ -5,11 +5,15 @@ All Rights Reserved.
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
 	"github.com/pageza/recipe-book-api-v2/internal/config"
 	"github.com/pageza/recipe-book-api-v2/internal/handlers"
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/collections"
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/comments"
 	"github.com/pageza/recipe-book-api-v2/internal/handlers/recipes"
 	"github.com/pageza/recipe-book-api-v2/internal/handlers/users"
 	"github.com/pageza/recipe-book-api-v2/internal/repository"
@@ -91,13 +95,32 @@ func main() {
 	userService := service.NewUserService(userRepo)
 	userHandler := users.NewUserHandler(userService, cfg.JWTSecret)
 
-	recipeRepo := repository.NewRecipeRepository(db)
-	recipeService := service.NewRecipeService(recipeRepo)
-	recipeHandler := recipes.NewRecipeHandler(recipeService)
+	mediaStore, err := blobstore.NewLocalStore(cfg.MediaDir, cfg.MediaBaseURL)
+	if err != nil {
+		log.Fatalf("failed to open media store: %v", err)
+	}
+	recipeRepo := repository.NewRecipeRepository(db, mediaStore)
+	recipeService := service.NewRecipeService(recipeRepo, mediaStore)
+	// Purge recipes that have outstayed the trash retention period. This is
+	// the only binary that does, however many recipe services share the database.
+	service.StartTrashPurger(context.Background(), recipeService, cfg.TrashRetention, cfg.TrashPurgeInterval)
+	recipeHandler := recipes.NewRecipeHandler(recipeService, userService)
+	if cfg.ShareLinkSecret == "" {
+		log.Fatal("set SHARE_LINK_SECRET, or JWT_SECRET to a non-default value, to sign share links")
+	}
+	shareLinkService := service.NewShareLinkService(recipeRepo, []byte(cfg.ShareLinkSecret))
+	collectionService := service.NewCollectionService(repository.NewCollectionRepository(db, mediaStore), recipeRepo, userRepo)
+	// Notify users mentioned in comments, storing the notifications.
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), true)
+	commentService := service.NewCommentService(repository.NewCommentRepository(db), recipeRepo, userRepo, notificationService)
 
 	h := &handlers.Handlers{
-		User:   userHandler,
-		Recipe: recipeHandler,
+		User:       userHandler,
+		Recipe:     recipeHandler,
+		ShareLink:  recipes.NewShareLinkHandler(shareLinkService),
+		Collection: collections.NewCollectionHandler(collectionService),
+		Comment:    comments.NewCommentHandler(commentService),
+		Media:      mediaStore.Handler(),
 	}
 
 	// Initialize the router.
@@ -4,6 +4,7 @@ import (
 	"log"
 
 	grpcserver "github.com/pageza/recipe-book-api-v2/grpc"
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
 	"github.com/pageza/recipe-book-api-v2/internal/config"
 	"github.com/pageza/recipe-book-api-v2/internal/repository"
 	"github.com/pageza/recipe-book-api-v2/internal/service"
@@ -26,8 +27,12 @@ func main() {
 	userRepo := repository.NewUserRepository(db)
 	userSvc := service.NewUserService(userRepo)
 
-	recipeRepo := repository.NewRecipeRepository(db)
-	recipeSvc := service.NewRecipeService(recipeRepo)
+	mediaStore, err := blobstore.NewLocalStore(cfg.MediaDir, cfg.MediaBaseURL)
+	if err != nil {
+		log.Fatalf("Failed to open media store: %v", err)
+	}
+	recipeRepo := repository.NewRecipeRepository(db, mediaStore)
+	recipeSvc := service.NewRecipeService(recipeRepo, mediaStore)
 
 	notificationRepo := repository.NewNotificationRepository(db)
 	storeEnabled := db != nil                                                         // ✅ Enable storage if DB is available
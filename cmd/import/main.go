@@ -0,0 +1,135 @@
+// cmd/import/main.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/internal/config"
+	"github.com/pageza/recipe-book-api-v2/internal/importer"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+)
+
+// Usage: import [-format json|jsonl|csv] [-owner USER_ID] [-batch N] [-rejects FILE] FILE...
+//
+// Each file is streamed into the recipes table in batched transactions.
+// Records that fail validation are written to the rejects file (by default
+// FILE.rejects.jsonl next to each input) and the import carries on.
+func main() {
+	format := flag.String("format", "", "input format: json, jsonl or csv (default: from the file extension)")
+	owner := flag.String("owner", "", "user ID that will own the imported recipes")
+	batch := flag.Int("batch", importer.DefaultBatchSize, "records written per transaction")
+	rejectsPath := flag.String("rejects", "", "file listing rejected records (default: FILE.rejects.jsonl)")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: import [flags] FILE...")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+	db, err := config.ConnectDatabase(cfg)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	repo := repository.NewRecipeImportRepository(db)
+
+	// A shared rejects file is truncated once and collects the rejects of
+	// every input.
+	var shared *rejectsFile
+	if *rejectsPath != "" {
+		shared = &rejectsFile{path: *rejectsPath}
+	}
+	failed := false
+	for _, path := range flag.Args() {
+		rejects := shared
+		if rejects == nil {
+			rejects = &rejectsFile{path: path + ".rejects.jsonl"}
+		}
+		stats, err := importFile(repo, path, *format, *owner, *batch, rejects)
+		if rejects != shared {
+			rejects.Close()
+		}
+		log.Printf("%s: read %d, inserted %d, updated %d, duplicates %d, rejected %d",
+			path, stats.Read, stats.Inserted, stats.Updated, stats.Duplicates, stats.Rejected)
+		if err != nil {
+			log.Printf("%s: import stopped: %v", path, err)
+			failed = true
+			continue
+		}
+		if stats.Rejected > 0 {
+			log.Printf("%s: rejected records written to %s", path, rejects.path)
+		}
+	}
+	if shared != nil {
+		shared.Close()
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// importFile imports a single file, writing rejected records to rejects.
+func importFile(repo repository.RecipeImportRepository, path, format, owner string, batch int, rejects *rejectsFile) (importer.Stats, error) {
+	if format == "" {
+		var err error
+		if format, err = importer.FormatFromPath(path); err != nil {
+			return importer.Stats{}, err
+		}
+	}
+	in, err := os.Open(path)
+	if err != nil {
+		return importer.Stats{}, err
+	}
+	defer in.Close()
+	src, err := importer.NewSource(in, format)
+	if err != nil {
+		return importer.Stats{}, err
+	}
+
+	start := time.Now()
+	imp := importer.New(repo, importer.Options{
+		BatchSize: batch,
+		OwnerID:   owner,
+		Rejects:   rejects,
+		Progress: func(s importer.Stats) {
+			rate := float64(s.Read) / time.Since(start).Seconds()
+			log.Printf("%s: %d records processed (%.0f/s), %d inserted, %d updated, %d duplicates, %d rejected",
+				path, s.Read, rate, s.Inserted, s.Updated, s.Duplicates, s.Rejected)
+		},
+	})
+	return imp.Run(src)
+}
+
+// rejectsFile writes rejected records to path, creating or truncating the
+// file on the first write so that imports without rejects leave no file
+// behind.
+type rejectsFile struct {
+	path string
+	file *os.File
+}
+
+func (r *rejectsFile) Write(p []byte) (int, error) {
+	if r.file == nil {
+		file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return 0, err
+		}
+		r.file = file
+	}
+	return r.file.Write(p)
+}
+
+// Close closes the file if it was created.
+func (r *rejectsFile) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
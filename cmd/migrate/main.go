@@ -7,6 +7,7 @@ import (
 
 	"github.com/pageza/recipe-book-api-v2/internal/config"
 	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
 )
 
 func main() {
@@ -25,15 +26,30 @@ func main() {
 	// Instead of os.Getenv("CI"), check a dedicated variable:
 	if os.Getenv("DROP_TABLES") == "true" {
 		log.Println("DROP_TABLES environment detected, dropping existing tables")
-		if err := db.Migrator().DropTable(&models.User{}, &models.Recipe{}, &models.Notification{}); err != nil {
+		if err := db.Migrator().DropTable(models.All()...); err != nil {
 			log.Fatalf("failed to drop tables: %v", err)
 		}
 	}
 
 	// Run migrations.
-	err = db.AutoMigrate(&models.User{}, &models.Recipe{}, &models.Notification{})
+	err = db.AutoMigrate(models.All()...)
 	if err != nil {
 		log.Fatalf("failed to run migrations: %v", err)
 	}
+	if err := repository.MigrateRecipeSearch(db); err != nil {
+		log.Fatalf("failed to create recipe search index: %v", err)
+	}
+	// Derive step times for recipes stored before, or by an older version of, the step parser.
+	updated, err := repository.BackfillRecipeSteps(db)
+	if err != nil {
+		log.Fatalf("failed to backfill recipe steps: %v", err)
+	}
+	log.Printf("Backfilled structured steps of %d recipes", updated)
+	// Detect allergens of recipes stored before allergens were detected.
+	updated, err = repository.BackfillRecipeAllergens(db)
+	if err != nil {
+		log.Fatalf("failed to backfill recipe allergens: %v", err)
+	}
+	log.Printf("Backfilled allergens of %d recipes", updated)
 	log.Println("Database migrations complete")
 }
@@ -6,6 +6,7 @@ import (
 	"net"
 
 	grpcserver "github.com/pageza/recipe-book-api-v2/grpc/recipe"
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
 	"github.com/pageza/recipe-book-api-v2/internal/config"
 	"github.com/pageza/recipe-book-api-v2/internal/repository"
 	"github.com/pageza/recipe-book-api-v2/internal/service"
@@ -26,9 +27,14 @@ func main() {
 		log.Fatalf("Failed to connect to the database: %v", err)
 	}
 
-	repo := repository.NewRecipeRepository(db) // ✅ Pass the actual DB instance
-
-	recipeSvc := service.NewRecipeService(repo)
+	mediaStore, err := blobstore.NewLocalStore(cfg.MediaDir, cfg.MediaBaseURL)
+	if err != nil {
+		log.Fatalf("Failed to open media store: %v", err)
+	}
+	repo := repository.NewRecipeRepository(db, mediaStore) // ✅ Pass the actual DB instance
+	// The trash is purged by the API gateway only, so running both binaries
+	// against one database does not purge it twice.
+	recipeSvc := service.NewRecipeService(repo, mediaStore)
 
 	// Create gRPC server
 	grpcServer := grpc.NewServer()
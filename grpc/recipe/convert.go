@@ -0,0 +1,284 @@
+package recipe
+
+import (
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	pb "github.com/pageza/recipe-book-api-v2/proto/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto converts an internal recipe model into its gRPC representation.
+// List fields are copied element by element so no information is lost.
+func ToProto(recipe *models.Recipe) *pb.GetRecipeResponse {
+	if recipe == nil {
+		return nil
+	}
+	return &pb.GetRecipeResponse{
+		RecipeId:             recipe.ID,
+		Title:                recipe.Title,
+		Ingredients:          copyStrings(recipe.Ingredients),
+		Steps:                copyStrings(recipe.Steps),
+		Servings:             int32(recipe.Servings),
+		NutritionalInfo:      nutritionToProto(&recipe.NutritionalInfo),
+		TotalNutritionalInfo: nutritionToProto(recipe.TotalNutritionalInfo),
+		AllergyDisclaimer:    recipe.AllergyDisclaimer,
+		Allergens:            copyStrings(recipe.Allergens),
+		Appliances:           copyStrings(recipe.Appliances),
+		CreatedAt:            timeToProto(recipe.CreatedAt),
+		UpdatedAt:            timeToProto(recipe.UpdatedAt),
+		UserId:               recipe.UserID,
+		ParentRecipeId:       recipe.ParentRecipeID,
+		OriginalUserId:       recipe.OriginalUserID,
+		Tags:                 models.TagRefs(recipe.Tags),
+		Images:               imagesToProto(recipe.Images),
+		Visibility:           recipe.Visibility,
+		FavoriteCount:        int32(recipe.FavoriteCount),
+		IsFavorited:          recipe.IsFavorited,
+		Rating:               ratingToProto(recipe.Rating),
+		PrepMinutes:          int32(recipe.PrepMinutes),
+		CookMinutes:          int32(recipe.CookMinutes),
+		TotalMinutes:         int32(recipe.TotalMinutes),
+	}
+}
+
+// FromProto converts a gRPC recipe message back into the internal model.
+func FromProto(msg *pb.GetRecipeResponse) *models.Recipe {
+	if msg == nil {
+		return nil
+	}
+	recipe := &models.Recipe{
+		ID:                msg.GetRecipeId(),
+		Title:             msg.GetTitle(),
+		Ingredients:       copyStrings(msg.GetIngredients()),
+		Steps:             copyStrings(msg.GetSteps()),
+		Servings:          int(msg.GetServings()),
+		NutritionalInfo:   nutritionFromProto(msg.GetNutritionalInfo()),
+		AllergyDisclaimer: msg.GetAllergyDisclaimer(),
+		Allergens:         copyStrings(msg.GetAllergens()),
+		Appliances:        copyStrings(msg.GetAppliances()),
+		CreatedAt:         timeFromProto(msg.GetCreatedAt()),
+		UpdatedAt:         timeFromProto(msg.GetUpdatedAt()),
+		UserID:            msg.GetUserId(),
+		ParentRecipeID:    msg.GetParentRecipeId(),
+		OriginalUserID:    msg.GetOriginalUserId(),
+		Tags:              models.ParseTags(msg.GetTags()),
+		Images:            imagesFromProto(msg.GetImages()),
+		Visibility:        msg.GetVisibility(),
+		FavoriteCount:     int(msg.GetFavoriteCount()),
+		IsFavorited:       msg.GetIsFavorited(),
+		Rating:            ratingFromProto(msg.GetRating()),
+		PrepMinutes:       int(msg.GetPrepMinutes()),
+		CookMinutes:       int(msg.GetCookMinutes()),
+		TotalMinutes:      int(msg.GetTotalMinutes()),
+	}
+	if msg.GetTotalNutritionalInfo() != nil {
+		totals := nutritionFromProto(msg.GetTotalNutritionalInfo())
+		recipe.TotalNutritionalInfo = &totals
+	}
+	return recipe
+}
+
+// InputFromProto converts the editable recipe fields of a request into a model.
+func InputFromProto(in *pb.RecipeInput) *models.Recipe {
+	return &models.Recipe{
+		Title:             in.GetTitle(),
+		Ingredients:       copyStrings(in.GetIngredients()),
+		Steps:             copyStrings(in.GetSteps()),
+		Servings:          int(in.GetServings()),
+		NutritionalInfo:   nutritionFromProto(in.GetNutritionalInfo()),
+		AllergyDisclaimer: in.GetAllergyDisclaimer(),
+		Appliances:        copyStrings(in.GetAppliances()),
+		Tags:              models.ParseTags(in.GetTags()),
+		Visibility:        in.GetVisibility(),
+	}
+}
+
+// ToProtoList converts a slice of recipe models.
+func ToProtoList(recipes []*models.Recipe) []*pb.GetRecipeResponse {
+	out := make([]*pb.GetRecipeResponse, 0, len(recipes))
+	for _, r := range recipes {
+		out = append(out, ToProto(r))
+	}
+	return out
+}
+
+// FiltersFromProto converts query filters; nil yields no filtering.
+func FiltersFromProto(f *pb.RecipeFilters) models.RecipeFilters {
+	filters := models.RecipeFilters{
+		Appliances:        copyStrings(f.GetAppliances()),
+		ExcludeAppliances: copyStrings(f.GetExcludeAppliances()),
+		ExcludeAllergens:  copyStrings(f.GetExcludeAllergens()),
+		Tags:              copyStrings(f.GetTags()),
+	}
+	filters.MinCalories, filters.MaxCalories = rangeFromProto(f.GetCalories())
+	filters.MinProtein, filters.MaxProtein = rangeFromProto(f.GetProtein())
+	filters.MinCarbohydrates, filters.MaxCarbohydrates = rangeFromProto(f.GetCarbohydrates())
+	filters.MinFat, filters.MaxFat = rangeFromProto(f.GetFat())
+	if f != nil {
+		filters.MaxPrepMinutes = intFromProto(f.MaxPrepMinutes)
+		filters.MaxCookMinutes = intFromProto(f.MaxCookMinutes)
+		filters.MaxTotalMinutes = intFromProto(f.MaxTotalMinutes)
+	}
+	return filters
+}
+
+// FacetsToProto converts facet counts; nil yields nil.
+func FacetsToProto(facets *models.RecipeFacets) *pb.RecipeFacets {
+	if facets == nil {
+		return nil
+	}
+	out := &pb.RecipeFacets{Appliances: make(map[string]int32, len(facets.Appliances))}
+	for name, n := range facets.Appliances {
+		out.Appliances[name] = int32(n)
+	}
+	for _, band := range facets.Calories {
+		out.Calories = append(out.Calories, &pb.RangeFacet{
+			Label: band.Label,
+			Min:   band.Min,
+			Max:   band.Max,
+			Count: int32(band.Count),
+		})
+	}
+	return out
+}
+
+// rangeFromProto returns the bounds of a range; unset bounds are nil.
+func rangeFromProto(r *pb.NutrientRange) (min, max *float64) {
+	if r == nil {
+		return nil, nil
+	}
+	return r.Min, r.Max
+}
+
+// intFromProto converts an optional int32; unset yields nil.
+func intFromProto(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+// nutritionToProto converts nutritional values into a message; nil yields nil.
+func nutritionToProto(info *models.NutritionalInfo) *pb.NutritionalInfo {
+	if info == nil {
+		return nil
+	}
+	return &pb.NutritionalInfo{
+		Calories:      info.Calories,
+		Protein:       info.Protein,
+		Carbohydrates: info.Carbohydrates,
+		Fat:           info.Fat,
+		Fiber:         info.Fiber,
+	}
+}
+
+// nutritionFromProto converts a NutritionalInfo message; nil yields zero values.
+func nutritionFromProto(info *pb.NutritionalInfo) models.NutritionalInfo {
+	return models.NutritionalInfo{
+		Calories:      info.GetCalories(),
+		Protein:       info.GetProtein(),
+		Carbohydrates: info.GetCarbohydrates(),
+		Fat:           info.GetFat(),
+		Fiber:         info.GetFiber(),
+	}
+}
+
+// ratingToProto converts a rating summary, flattening its histogram.
+func ratingToProto(summary models.RatingSummary) *pb.RatingSummary {
+	h := summary.Histogram
+	return &pb.RatingSummary{
+		Average:   summary.Average,
+		Count:     int32(summary.Count),
+		Histogram: []int32{int32(h.One), int32(h.Two), int32(h.Three), int32(h.Four), int32(h.Five)},
+	}
+}
+
+// ratingFromProto converts a RatingSummary message; nil yields an unrated
+// summary. The rating total is recomputed from the histogram.
+func ratingFromProto(msg *pb.RatingSummary) models.RatingSummary {
+	summary := models.RatingSummary{Average: msg.GetAverage(), Count: int(msg.GetCount())}
+	buckets := []*int{&summary.Histogram.One, &summary.Histogram.Two, &summary.Histogram.Three, &summary.Histogram.Four, &summary.Histogram.Five}
+	for i, n := range msg.GetHistogram() {
+		if i < len(buckets) {
+			*buckets[i] = int(n)
+			summary.Total += (i + 1) * int(n)
+		}
+	}
+	return summary
+}
+
+// imagesToProto converts recipe images; an empty list yields nil.
+func imagesToProto(in []models.RecipeImage) []*pb.RecipeImage {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*pb.RecipeImage, len(in))
+	for i, img := range in {
+		out[i] = &pb.RecipeImage{
+			Id:          img.ID,
+			Url:         img.URL,
+			ContentType: img.ContentType,
+			Width:       int32(img.Width),
+			Height:      int32(img.Height),
+			UserId:      img.UserID,
+			CreatedAt:   timeToProto(img.CreatedAt),
+		}
+		for _, v := range img.Thumbnails {
+			out[i].Thumbnails = append(out[i].Thumbnails, &pb.ImageVariant{
+				Name: v.Name, Width: int32(v.Width), Height: int32(v.Height), Url: v.URL,
+			})
+		}
+	}
+	return out
+}
+
+// imagesFromProto converts RecipeImage messages; an empty list yields nil.
+func imagesFromProto(in []*pb.RecipeImage) []models.RecipeImage {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]models.RecipeImage, len(in))
+	for i, msg := range in {
+		out[i] = models.RecipeImage{
+			ID:          msg.GetId(),
+			URL:         msg.GetUrl(),
+			ContentType: msg.GetContentType(),
+			Width:       int(msg.GetWidth()),
+			Height:      int(msg.GetHeight()),
+			UserID:      msg.GetUserId(),
+			CreatedAt:   timeFromProto(msg.GetCreatedAt()),
+		}
+		for _, v := range msg.GetThumbnails() {
+			out[i].Thumbnails = append(out[i].Thumbnails, models.ImageVariant{
+				Name: v.GetName(), Width: int(v.GetWidth()), Height: int(v.GetHeight()), URL: v.GetUrl(),
+			})
+		}
+	}
+	return out
+}
+
+// copyStrings returns a copy of in, preserving nil.
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	return append([]string(nil), in...)
+}
+
+// timeToProto maps the zero time to an unset timestamp.
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeFromProto maps an unset timestamp to the zero time.
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,113 @@
+package recipe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	grpcRecipe "github.com/pageza/recipe-book-api-v2/grpc/recipe"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	pb "github.com/pageza/recipe-book-api-v2/proto/proto"
+)
+
+func sampleRecipe() *models.Recipe {
+	return &models.Recipe{
+		ID:          "r-1",
+		Title:       "Shakshuka",
+		Ingredients: []string{"2 tbsp olive oil", "1 onion, diced", "4 eggs"},
+		Steps: []string{
+			"Heat the oil, then soften the onion.",
+			"Add tomatoes, simmer, and crack in the eggs.",
+		},
+		NutritionalInfo: models.NutritionalInfo{
+			Calories:      312.5,
+			Protein:       14.2,
+			Carbohydrates: 18.75,
+			Fat:           20.1,
+			Fiber:         4.4,
+		},
+		AllergyDisclaimer: "Contains egg.",
+		Allergens:         []string{"egg"},
+		Appliances:        []string{"Stove", "Cast-iron skillet, 12\""},
+		CreatedAt:         time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC),
+		UpdatedAt:         time.Date(2025, 2, 3, 4, 5, 6, 700, time.UTC),
+		UserID:            "user-42",
+		ParentRecipeID:    "r-0",
+		OriginalUserID:    "user-7",
+		Visibility:        models.VisibilityUnlisted,
+		FavoriteCount:     3,
+		IsFavorited:       true,
+		Rating:            models.RatingSummary{Average: 4.5, Count: 2, Total: 9, Histogram: models.RatingHistogram{Four: 1, Five: 1}},
+		PrepMinutes:       5,
+		CookMinutes:       20,
+		TotalMinutes:      25,
+		Tags:              models.ParseTags([]string{"cuisine:Middle Eastern", "course:Breakfast", "tag:one-pan"}),
+		Images: []models.RecipeImage{{
+			ID:          "img-1",
+			UserID:      "user-42",
+			ContentType: "image/jpeg",
+			Width:       2048,
+			Height:      1365,
+			URL:         "/media/recipes/r-1/img-1/original.jpg",
+			Thumbnails: []models.ImageVariant{
+				{Name: "small", Width: 320, Height: 213, URL: "/media/recipes/r-1/img-1/small.jpg"},
+			},
+			CreatedAt: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+}
+
+func TestToProtoPreservesFields(t *testing.T) {
+	msg := grpcRecipe.ToProto(sampleRecipe())
+
+	assert.Equal(t, "r-1", msg.RecipeId)
+	assert.Equal(t, "user-42", msg.UserId)
+	assert.Equal(t, []string{"Heat the oil, then soften the onion.", "Add tomatoes, simmer, and crack in the eggs."}, msg.Steps)
+	assert.Len(t, msg.Ingredients, 3)
+	assert.Equal(t, 18.75, msg.NutritionalInfo.Carbohydrates)
+	assert.Equal(t, int64(1735787045), msg.CreatedAt.Seconds)
+	assert.Equal(t, int32(600), msg.CreatedAt.Nanos)
+}
+
+func TestRecipeProtoRoundTrip(t *testing.T) {
+	original := sampleRecipe()
+
+	// Go through the wire format to make sure nothing depends on in-memory sharing.
+	data, err := proto.Marshal(grpcRecipe.ToProto(original))
+	assert.NoError(t, err)
+	var decoded pb.GetRecipeResponse
+	assert.NoError(t, proto.Unmarshal(data, &decoded))
+
+	got := grpcRecipe.FromProto(&decoded)
+	assert.Equal(t, original.ID, got.ID)
+	assert.Equal(t, original.Title, got.Title)
+	assert.Equal(t, original.Ingredients, got.Ingredients)
+	assert.Equal(t, original.Steps, got.Steps)
+	assert.Equal(t, original.Appliances, got.Appliances)
+	assert.Equal(t, original.Tags, got.Tags)
+	assert.Equal(t, original.NutritionalInfo, got.NutritionalInfo)
+	assert.Equal(t, original.AllergyDisclaimer, got.AllergyDisclaimer)
+	assert.Equal(t, original.Allergens, got.Allergens)
+	assert.Equal(t, original.Images, got.Images)
+	assert.Equal(t, original.UserID, got.UserID)
+	assert.Equal(t, original.Rating, got.Rating)
+	assert.Equal(t, []int{5, 20, 25}, []int{got.PrepMinutes, got.CookMinutes, got.TotalMinutes})
+	assert.True(t, original.CreatedAt.Equal(got.CreatedAt))
+	assert.True(t, original.UpdatedAt.Equal(got.UpdatedAt))
+}
+
+func TestRecipeProtoRoundTripEmpty(t *testing.T) {
+	msg := grpcRecipe.ToProto(&models.Recipe{ID: "empty"})
+	assert.Nil(t, msg.CreatedAt, "zero times are sent as unset timestamps")
+
+	got := grpcRecipe.FromProto(msg)
+	assert.Equal(t, "empty", got.ID)
+	assert.True(t, got.CreatedAt.IsZero())
+	assert.Empty(t, got.Ingredients)
+	assert.Equal(t, models.NutritionalInfo{}, got.NutritionalInfo)
+
+	assert.Nil(t, grpcRecipe.ToProto(nil))
+	assert.Nil(t, grpcRecipe.FromProto(nil))
+}
@@ -4,14 +4,22 @@ package recipe
 //nolint:unusedwrite // false positive: field assignments are used in the gRPC response
 import (
 	"context"
-	"fmt"
-	"strings"
+	"errors"
 
 	"github.com/pageza/recipe-book-api-v2/internal/models"
 	"github.com/pageza/recipe-book-api-v2/internal/service"
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
 	pb "github.com/pageza/recipe-book-api-v2/proto/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// UserIDMetadataKey is the request metadata key identifying the acting user.
+// It is required by RPCs that modify recipes; reads without it only see
+// recipes that are not private.
+const UserIDMetadataKey = "x-user-id"
+
 // Server implements the gRPC RecipeService.
 type Server struct {
 	pb.UnimplementedRecipeServiceServer
@@ -25,34 +33,25 @@ func NewServer(svc service.RecipeService) *Server {
 
 // GetRecipe implements the GetRecipe RPC.
 // It retrieves a recipe by its ID and converts the internal model into a gRPC response.
+// Private recipes of other users are reported as not found.
 func (s *Server) GetRecipe(ctx context.Context, req *pb.GetRecipeRequest) (*pb.GetRecipeResponse, error) {
-	recipe, err := s.svc.GetRecipe(req.RecipeId)
+	viewerID := viewerFromContext(ctx)
+	system, err := units.ParseSystem(req.UnitSystem)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get recipe: %v", err)
-	}
-
-	ingredientsStr := strings.Join(recipe.Ingredients, ", ")
-	stepsStr := strings.Join(recipe.Steps, ", ")
-	appliancesStr := strings.Join(recipe.Appliances, ", ")
-	nutritionalInfoStr := fmt.Sprintf("Calories: %.0f, Protein: %.0f, Carbs: %.0f, Fat: %.0f, Fiber: %.0f",
-		recipe.NutritionalInfo.Calories,
-		recipe.NutritionalInfo.Protein,
-		recipe.NutritionalInfo.Carbohydrates,
-		recipe.NutritionalInfo.Fat,
-		recipe.NutritionalInfo.Fiber)
-
-	resp := &pb.GetRecipeResponse{
-		RecipeId:          recipe.ID,
-		Title:             recipe.Title,
-		Ingredients:       ingredientsStr,
-		Steps:             stepsStr,
-		NutritionalInfo:   nutritionalInfoStr,
-		AllergyDisclaimer: recipe.AllergyDisclaimer,
-		Appliances:        appliancesStr,
-		CreatedAt:         recipe.CreatedAt.Unix(),
-		UpdatedAt:         recipe.UpdatedAt.Unix(),
-	}
-	return resp, nil
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if req.Servings != 0 {
+		recipe, err := s.svc.ScaleRecipe(viewerID, req.RecipeId, int(req.Servings))
+		if err != nil {
+			return nil, toStatus("failed to scale recipe", err)
+		}
+		return ToProto(service.ConvertRecipeUnits(recipe, system)), nil
+	}
+	recipe, err := s.svc.GetRecipe(viewerID, req.RecipeId)
+	if err != nil {
+		return nil, toStatus("failed to get recipe", err)
+	}
+	return ToProto(service.ConvertRecipeUnits(recipe, system)), nil
 }
 
 // QueryRecipe implements the QueryRecipe RPC.
@@ -60,50 +59,163 @@ func (s *Server) GetRecipe(ctx context.Context, req *pb.GetRecipeRequest) (*pb.G
 func (s *Server) QueryRecipe(ctx context.Context, req *pb.RecipeQueryRequest) (*pb.RecipeQueryResponse, error) {
 	// Convert incoming proto request into an internal RecipeQueryRequest.
 	queryReq := &models.RecipeQueryRequest{
-		Query:  req.Query,
-		UserID: req.UserId,
-		Filter: req.Filter,
-		Page:   int(req.Page),
-		Limit:  int(req.Limit),
+		Query:         req.Query,
+		UserID:        req.UserId,
+		Filter:        req.Filter,
+		Filters:       FiltersFromProto(req.Filters),
+		Page:          int(req.Page),
+		Limit:         int(req.Limit),
+		Cursor:        req.Cursor,
+		Sort:          req.Sort,
+		TotalMode:     req.TotalMode,
+		IncludeFacets: req.IncludeFacets,
+		ViewerID:      viewerFromContext(ctx),
 	}
 
 	// Delegate query processing to the service layer.
 	queryResp, err := s.svc.QueryRecipes(queryReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query recipes: %v", err)
-	}
-
-	var protoRecipes []*pb.GetRecipeResponse
-	for _, rec := range queryResp.Recipes {
-		// Convert slice fields into a comma-separated string
-		ingredientsStr := strings.Join(rec.Ingredients, ", ")
-		stepsStr := strings.Join(rec.Steps, ", ")
-		appliancesStr := strings.Join(rec.Appliances, ", ")
-		// Format the NutritionalInfo struct into a single string.
-		nutritionalInfoStr := fmt.Sprintf("Calories: %.0f, Protein: %.0f, Carbs: %.0f, Fat: %.0f, Fiber: %.0f",
-			rec.NutritionalInfo.Calories,
-			rec.NutritionalInfo.Protein,
-			rec.NutritionalInfo.Carbohydrates,
-			rec.NutritionalInfo.Fat,
-			rec.NutritionalInfo.Fiber)
-
-		protoRecipes = append(protoRecipes, &pb.GetRecipeResponse{
-			RecipeId:          rec.ID,
-			Title:             rec.Title,
-			Ingredients:       ingredientsStr,
-			Steps:             stepsStr,
-			NutritionalInfo:   nutritionalInfoStr,
-			AllergyDisclaimer: rec.AllergyDisclaimer,
-			Appliances:        appliancesStr,
-			CreatedAt:         rec.CreatedAt.Unix(),
-			UpdatedAt:         rec.UpdatedAt.Unix(),
-		})
+		return nil, toStatus("failed to query recipes", err)
 	}
 
 	return &pb.RecipeQueryResponse{
-		Recipes: protoRecipes,
-		Page:    int32(queryResp.Page),
-		Limit:   int32(queryResp.Limit),
-		Total:   int32(queryResp.Total),
+		Recipes:    ToProtoList(queryResp.Recipes),
+		Page:       int32(queryResp.Page),
+		Limit:      int32(queryResp.Limit),
+		Total:      int32(queryResp.Total),
+		Facets:     FacetsToProto(queryResp.Facets),
+		NextCursor: queryResp.NextCursor,
+		PrevCursor: queryResp.PrevCursor,
+		TotalMode:  queryResp.TotalMode,
 	}, nil
 }
+
+// CreateRecipe implements the CreateRecipe RPC.
+// The new recipe is owned by the user named in the request metadata.
+func (s *Server) CreateRecipe(ctx context.Context, req *pb.CreateRecipeRequest) (*pb.GetRecipeResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recipe, err := s.svc.CreateRecipe(userID, InputFromProto(req.GetRecipe()))
+	if err != nil {
+		return nil, toStatus("failed to create recipe", err)
+	}
+	return ToProto(recipe), nil
+}
+
+// UpdateRecipe implements the UpdateRecipe RPC.
+// Fields named in update_mask are copied from the request onto the stored recipe;
+// an empty mask replaces all editable fields.
+func (s *Server) UpdateRecipe(ctx context.Context, req *pb.UpdateRecipeRequest) (*pb.GetRecipeResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	input := InputFromProto(req.GetRecipe())
+
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) > 0 {
+		existing, err := s.svc.GetRecipe(userID, req.GetRecipeId())
+		if err != nil {
+			return nil, toStatus("failed to update recipe", err)
+		}
+		if err := applyMask(existing, input, paths); err != nil {
+			return nil, err
+		}
+		input = existing
+	}
+
+	recipe, err := s.svc.UpdateRecipe(userID, req.GetRecipeId(), input)
+	if err != nil {
+		return nil, toStatus("failed to update recipe", err)
+	}
+	return ToProto(recipe), nil
+}
+
+// DeleteRecipe implements the DeleteRecipe RPC.
+func (s *Server) DeleteRecipe(ctx context.Context, req *pb.DeleteRecipeRequest) (*pb.DeleteRecipeResponse, error) {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.DeleteRecipe(userID, req.GetRecipeId()); err != nil {
+		return nil, toStatus("failed to delete recipe", err)
+	}
+	return &pb.DeleteRecipeResponse{}, nil
+}
+
+// applyMask copies the fields named in paths from src onto dst.
+func applyMask(dst, src *models.Recipe, paths []string) error {
+	for _, path := range paths {
+		switch path {
+		case "title":
+			dst.Title = src.Title
+		case "ingredients":
+			dst.Ingredients = src.Ingredients
+		case "steps":
+			dst.Steps = src.Steps
+		case "nutritional_info":
+			dst.NutritionalInfo = src.NutritionalInfo
+		case "nutritional_info.calories":
+			dst.NutritionalInfo.Calories = src.NutritionalInfo.Calories
+		case "nutritional_info.protein":
+			dst.NutritionalInfo.Protein = src.NutritionalInfo.Protein
+		case "nutritional_info.carbohydrates":
+			dst.NutritionalInfo.Carbohydrates = src.NutritionalInfo.Carbohydrates
+		case "nutritional_info.fat":
+			dst.NutritionalInfo.Fat = src.NutritionalInfo.Fat
+		case "nutritional_info.fiber":
+			dst.NutritionalInfo.Fiber = src.NutritionalInfo.Fiber
+		case "allergy_disclaimer":
+			dst.AllergyDisclaimer = src.AllergyDisclaimer
+		case "appliances":
+			dst.Appliances = src.Appliances
+		case "servings":
+			dst.Servings = src.Servings
+		case "tags":
+			dst.Tags = src.Tags
+		case "visibility":
+			dst.Visibility = src.Visibility
+		default:
+			return status.Errorf(codes.InvalidArgument, "unknown update_mask path %q", path)
+		}
+	}
+	return nil
+}
+
+// userIDFromContext reads the acting user from the incoming request metadata.
+func userIDFromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		if values := md.Get(UserIDMetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0], nil
+		}
+	}
+	return "", status.Errorf(codes.Unauthenticated, "missing %s metadata", UserIDMetadataKey)
+}
+
+// viewerFromContext reads the acting user from the incoming request
+// metadata, returning "" for anonymous callers.
+func viewerFromContext(ctx context.Context) string {
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+	return userID
+}
+
+// toStatus maps service errors to gRPC status errors.
+func toStatus(msg string, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidRecipe), errors.Is(err, service.ErrInvalidServings),
+		errors.Is(err, service.ErrInvalidQuery):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	case errors.Is(err, service.ErrRecipeForbidden):
+		return status.Errorf(codes.PermissionDenied, "%s: %v", msg, err)
+	case errors.Is(err, service.ErrRecipeNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
@@ -0,0 +1,214 @@
+package recipe_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	grpcRecipe "github.com/pageza/recipe-book-api-v2/grpc/recipe"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+	pb "github.com/pageza/recipe-book-api-v2/proto/proto"
+)
+
+// newTestServer builds a recipe gRPC server backed by an in-memory SQLite database.
+func newTestServer(t *testing.T) *grpcRecipe.Server {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models.All()...))
+	return grpcRecipe.NewServer(service.NewRecipeService(repository.NewRecipeRepository(db, nil), nil))
+}
+
+// asUser returns a context carrying the acting user in incoming metadata.
+func asUser(userID string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcRecipe.UserIDMetadataKey, userID))
+}
+
+func testInput() *pb.RecipeInput {
+	return &pb.RecipeInput{
+		Title:           "Miso Soup",
+		Ingredients:     []string{"4 cups dashi", "3 tbsp white miso", "1 block tofu, cubed"},
+		Steps:           []string{"Warm the dashi.", "Whisk in miso, then add tofu."},
+		NutritionalInfo: &pb.NutritionalInfo{Calories: 120, Protein: 9},
+		Appliances:      []string{"Stove"},
+	}
+}
+
+func TestCreateRecipeRPC(t *testing.T) {
+	srv := newTestServer(t)
+
+	_, err := srv.CreateRecipe(context.Background(), &pb.CreateRecipeRequest{Recipe: testInput()})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = srv.CreateRecipe(asUser("chef"), &pb.CreateRecipeRequest{Recipe: &pb.RecipeInput{Title: "No steps"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	created, err := srv.CreateRecipe(asUser("chef"), &pb.CreateRecipeRequest{Recipe: testInput()})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.RecipeId)
+	assert.Equal(t, "chef", created.UserId)
+	assert.Equal(t, testInput().Steps, created.Steps)
+
+	fetched, err := srv.GetRecipe(context.Background(), &pb.GetRecipeRequest{RecipeId: created.RecipeId})
+	require.NoError(t, err)
+	assert.Equal(t, created.Ingredients, fetched.Ingredients)
+	assert.Equal(t, 120.0, fetched.NutritionalInfo.Calories)
+}
+
+func TestGetRecipeRPCScalingAndUnits(t *testing.T) {
+	srv := newTestServer(t)
+
+	input := testInput()
+	input.Servings = 4
+	created, err := srv.CreateRecipe(asUser("chef"), &pb.CreateRecipeRequest{Recipe: input})
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), created.Servings)
+
+	scaled, err := srv.GetRecipe(context.Background(), &pb.GetRecipeRequest{RecipeId: created.RecipeId, Servings: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), scaled.Servings)
+	assert.Equal(t, []string{"2 cups dashi", "1 1/2 tablespoons white miso", "1/2 block tofu, cubed"}, scaled.Ingredients)
+	assert.Equal(t, 120.0, scaled.NutritionalInfo.Calories)
+	assert.Equal(t, 240.0, scaled.TotalNutritionalInfo.GetCalories())
+
+	_, err = srv.GetRecipe(context.Background(), &pb.GetRecipeRequest{RecipeId: created.RecipeId, Servings: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	metric, err := srv.GetRecipe(context.Background(), &pb.GetRecipeRequest{RecipeId: created.RecipeId, UnitSystem: "metric"})
+	require.NoError(t, err)
+	assert.Equal(t, "946 milliliters dashi", metric.Ingredients[0])
+
+	_, err = srv.GetRecipe(context.Background(), &pb.GetRecipeRequest{RecipeId: created.RecipeId, UnitSystem: "cubits"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateRecipeRPCWithFieldMask(t *testing.T) {
+	srv := newTestServer(t)
+	created, err := srv.CreateRecipe(asUser("chef"), &pb.CreateRecipeRequest{Recipe: testInput()})
+	require.NoError(t, err)
+
+	// Only the masked fields change.
+	updated, err := srv.UpdateRecipe(asUser("chef"), &pb.UpdateRecipeRequest{
+		RecipeId:   created.RecipeId,
+		Recipe:     &pb.RecipeInput{Title: "Red Miso Soup", NutritionalInfo: &pb.NutritionalInfo{Calories: 140}},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "nutritional_info.calories"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Red Miso Soup", updated.Title)
+	assert.Equal(t, created.Ingredients, updated.Ingredients)
+	assert.Equal(t, 140.0, updated.NutritionalInfo.Calories)
+	assert.Equal(t, 9.0, updated.NutritionalInfo.Protein)
+
+	// An empty mask replaces everything.
+	replacement := testInput()
+	replacement.Steps = []string{"Just heat it."}
+	updated, err = srv.UpdateRecipe(asUser("chef"), &pb.UpdateRecipeRequest{RecipeId: created.RecipeId, Recipe: replacement})
+	require.NoError(t, err)
+	assert.Equal(t, "Miso Soup", updated.Title)
+	assert.Equal(t, []string{"Just heat it."}, updated.Steps)
+
+	_, err = srv.UpdateRecipe(asUser("chef"), &pb.UpdateRecipeRequest{
+		RecipeId:   created.RecipeId,
+		Recipe:     testInput(),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"user_id"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.UpdateRecipe(asUser("someone-else"), &pb.UpdateRecipeRequest{
+		RecipeId:   created.RecipeId,
+		Recipe:     testInput(),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srv.UpdateRecipe(asUser("chef"), &pb.UpdateRecipeRequest{RecipeId: "missing", Recipe: testInput()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDeleteRecipeRPC(t *testing.T) {
+	srv := newTestServer(t)
+	created, err := srv.CreateRecipe(asUser("chef"), &pb.CreateRecipeRequest{Recipe: testInput()})
+	require.NoError(t, err)
+
+	_, err = srv.DeleteRecipe(asUser("someone-else"), &pb.DeleteRecipeRequest{RecipeId: created.RecipeId})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srv.DeleteRecipe(asUser("chef"), &pb.DeleteRecipeRequest{RecipeId: created.RecipeId})
+	assert.NoError(t, err)
+
+	_, err = srv.DeleteRecipe(asUser("chef"), &pb.DeleteRecipeRequest{RecipeId: created.RecipeId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestQueryRecipeRPCWithFilters(t *testing.T) {
+	srv := newTestServer(t)
+
+	soup := testInput()
+	stew := testInput()
+	stew.Title = "Beef Stew"
+	stew.Appliances = []string{"Oven"}
+	stew.NutritionalInfo = &pb.NutritionalInfo{Calories: 540}
+	for _, in := range []*pb.RecipeInput{soup, stew} {
+		_, err := srv.CreateRecipe(asUser("chef"), &pb.CreateRecipeRequest{Recipe: in})
+		require.NoError(t, err)
+	}
+
+	maxCalories := 300.0
+	resp, err := srv.QueryRecipe(context.Background(), &pb.RecipeQueryRequest{
+		Filters:       &pb.RecipeFilters{Calories: &pb.NutrientRange{Max: &maxCalories}},
+		IncludeFacets: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Recipes, 1)
+	assert.Equal(t, "Miso Soup", resp.Recipes[0].Title)
+	assert.Equal(t, map[string]int32{"stove": 1}, resp.Facets.Appliances)
+	assert.Equal(t, int32(1), resp.Facets.Calories[0].Count)
+
+	resp, err = srv.QueryRecipe(context.Background(), &pb.RecipeQueryRequest{
+		Filters: &pb.RecipeFilters{Appliances: []string{"oven"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Total)
+	assert.Nil(t, resp.Facets)
+
+	minCalories := 900.0
+	_, err = srv.QueryRecipe(context.Background(), &pb.RecipeQueryRequest{
+		Filters: &pb.RecipeFilters{Calories: &pb.NutrientRange{Min: &minCalories, Max: &maxCalories}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestQueryRecipeRPCWithCursor(t *testing.T) {
+	srv := newTestServer(t)
+	for _, title := range []string{"Udon", "Ramen", "Soba"} {
+		in := testInput()
+		in.Title = title
+		_, err := srv.CreateRecipe(asUser("chef"), &pb.CreateRecipeRequest{Recipe: in})
+		require.NoError(t, err)
+	}
+
+	first, err := srv.QueryRecipe(context.Background(), &pb.RecipeQueryRequest{Sort: "title", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Recipes, 2)
+	assert.Equal(t, "Ramen", first.Recipes[0].Title)
+	assert.Equal(t, "exact", first.TotalMode)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := srv.QueryRecipe(context.Background(), &pb.RecipeQueryRequest{Sort: "title", Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, second.Recipes, 1)
+	assert.Equal(t, "Udon", second.Recipes[0].Title)
+	assert.Empty(t, second.NextCursor)
+
+	_, err = srv.QueryRecipe(context.Background(), &pb.RecipeQueryRequest{Cursor: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
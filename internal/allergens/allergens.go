@@ -0,0 +1,229 @@
+// Package allergens detects the major food allergen groups in ingredient
+// lines using a keyword and synonym dictionary.
+package allergens
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// Allergen groups, stored on recipes as models.Recipe.Allergens.
+const (
+	Milk      = "milk"
+	Egg       = "egg"
+	Fish      = "fish"
+	Shellfish = "shellfish"
+	TreeNut   = "tree_nut"
+	Peanut    = "peanut"
+	Wheat     = "wheat" // wheat and the other gluten-containing grains
+	Soy       = "soy"
+	Sesame    = "sesame"
+)
+
+// All lists every allergen group in the order they are reported.
+var All = []string{Milk, Egg, Fish, Shellfish, TreeNut, Peanut, Wheat, Soy, Sesame}
+
+// displayNames are the names used in generated disclaimers.
+var displayNames = map[string]string{
+	Milk: "milk", Egg: "egg", Fish: "fish", Shellfish: "shellfish", TreeNut: "tree nuts",
+	Peanut: "peanuts", Wheat: "wheat (gluten)", Soy: "soy", Sesame: "sesame",
+}
+
+// groupAliases maps the names users may filter by to allergen groups.
+var groupAliases = map[string]string{
+	"milk": Milk, "dairy": Milk, "lactose": Milk,
+	"egg": Egg, "eggs": Egg,
+	"fish":      Fish,
+	"shellfish": Shellfish, "crustacean": Shellfish, "crustaceans": Shellfish, "mollusc": Shellfish, "molluscs": Shellfish,
+	"tree_nut": TreeNut, "tree nut": TreeNut, "tree nuts": TreeNut, "nut": TreeNut, "nuts": TreeNut,
+	"peanut": Peanut, "peanuts": Peanut,
+	"wheat": Wheat, "gluten": Wheat,
+	"soy": Soy, "soya": Soy, "soybean": Soy, "soybeans": Soy,
+	"sesame": Sesame,
+}
+
+// keywords maps lower-cased words and phrases, in singular form, to the
+// allergen groups they contain. Entries with no groups are "false friends"
+// that shadow a shorter keyword, such as "coconut milk" for "milk".
+var keywords = map[string][]string{
+	// Milk.
+	"milk": {Milk}, "butter": {Milk}, "buttermilk": {Milk}, "cream": {Milk}, "creme": {Milk}, "crème": {Milk},
+	"cheese": {Milk}, "yogurt": {Milk}, "yoghurt": {Milk}, "ghee": {Milk}, "whey": {Milk}, "casein": {Milk},
+	"kefir": {Milk}, "custard": {Milk}, "lactose": {Milk}, "dairy": {Milk}, "half and half": {Milk},
+	"parmesan": {Milk}, "mozzarella": {Milk}, "cheddar": {Milk}, "ricotta": {Milk}, "feta": {Milk},
+	"mascarpone": {Milk}, "brie": {Milk}, "gouda": {Milk}, "gruyere": {Milk}, "gruyère": {Milk},
+	"paneer": {Milk}, "pecorino": {Milk}, "halloumi": {Milk}, "quark": {Milk},
+	"coconut milk": {}, "coconut cream": {}, "coconut butter": {}, "oat milk": {}, "rice milk": {},
+	"cocoa butter": {}, "apple butter": {}, "cream of tartar": {}, "vegan butter": {}, "vegan cheese": {},
+	"vegan cream cheese": {}, "vegan mayo": {}, "vegan mayonnaise": {},
+
+	// Egg.
+	"egg": {Egg}, "yolk": {Egg}, "mayonnaise": {Egg}, "mayo": {Egg}, "meringue": {Egg}, "aioli": {Egg},
+	"eggnog": {Egg, Milk}, "egg noodle": {Egg, Wheat},
+
+	// Fish.
+	"fish": {Fish}, "anchovy": {Fish}, "salmon": {Fish}, "tuna": {Fish}, "cod": {Fish}, "haddock": {Fish},
+	"halibut": {Fish}, "trout": {Fish}, "sardine": {Fish}, "mackerel": {Fish}, "tilapia": {Fish},
+	"sea bass": {Fish}, "snapper": {Fish}, "swordfish": {Fish}, "herring": {Fish}, "pollock": {Fish},
+	"catfish": {Fish}, "bonito": {Fish}, "worcestershire": {Fish}, "fish sauce": {Fish},
+
+	// Shellfish.
+	"shellfish": {Shellfish}, "shrimp": {Shellfish}, "prawn": {Shellfish}, "crab": {Shellfish},
+	"lobster": {Shellfish}, "crayfish": {Shellfish}, "crawfish": {Shellfish}, "langoustine": {Shellfish},
+	"scallop": {Shellfish}, "clam": {Shellfish}, "mussel": {Shellfish}, "oyster": {Shellfish},
+	"squid": {Shellfish}, "calamari": {Shellfish}, "octopus": {Shellfish},
+
+	// Tree nuts.
+	"nut": {TreeNut}, "almond": {TreeNut}, "cashew": {TreeNut}, "walnut": {TreeNut}, "pecan": {TreeNut},
+	"pistachio": {TreeNut}, "hazelnut": {TreeNut}, "filbert": {TreeNut}, "macadamia": {TreeNut},
+	"brazil nut": {TreeNut}, "pine nut": {TreeNut}, "chestnut": {TreeNut}, "praline": {TreeNut},
+	"marzipan": {TreeNut}, "nutella": {TreeNut, Milk}, "almond milk": {TreeNut}, "almond butter": {TreeNut},
+	"cashew butter": {TreeNut}, "nut butter": {TreeNut}, "almond flour": {TreeNut}, "coconut": {},
+
+	// Peanuts.
+	"peanut": {Peanut}, "groundnut": {Peanut}, "peanut butter": {Peanut}, "arachis oil": {Peanut},
+
+	// Wheat and gluten.
+	"wheat": {Wheat}, "gluten": {Wheat}, "flour": {Wheat}, "bread": {Wheat}, "breadcrumb": {Wheat},
+	"panko": {Wheat}, "pasta": {Wheat}, "spaghetti": {Wheat}, "macaroni": {Wheat}, "noodle": {Wheat},
+	"penne": {Wheat}, "linguine": {Wheat}, "fettuccine": {Wheat}, "lasagna": {Wheat}, "lasagne": {Wheat},
+	"orzo": {Wheat}, "ravioli": {Wheat}, "udon": {Wheat}, "ramen": {Wheat}, "couscous": {Wheat},
+	"bulgur": {Wheat}, "semolina": {Wheat}, "farro": {Wheat}, "spelt": {Wheat}, "barley": {Wheat},
+	"rye": {Wheat}, "seitan": {Wheat}, "malt": {Wheat}, "beer": {Wheat}, "tortilla": {Wheat},
+	"pita": {Wheat}, "baguette": {Wheat}, "brioche": {Wheat}, "croissant": {Wheat}, "bun": {Wheat},
+	"cracker": {Wheat}, "crouton": {Wheat}, "biscuit": {Wheat}, "pastry": {Wheat}, "dough": {Wheat},
+	"wonton": {Wheat}, "gnocchi": {Wheat},
+	"rice flour": {}, "coconut flour": {}, "chickpea flour": {}, "oat flour": {}, "corn flour": {},
+	"buckwheat flour": {}, "rice noodle": {}, "corn tortilla": {},
+
+	// Soy.
+	"soy": {Soy}, "soya": {Soy}, "soybean": {Soy}, "tofu": {Soy}, "tempeh": {Soy}, "edamame": {Soy},
+	"miso": {Soy}, "tamari": {Soy}, "soy sauce": {Soy, Wheat}, "shoyu": {Soy, Wheat},
+
+	// Sesame.
+	"sesame": {Sesame}, "tahini": {Sesame}, "halva": {Sesame}, "halvah": {Sesame}, "hummus": {Sesame},
+}
+
+// maxPhraseWords is the length of the longest phrase in keywords.
+const maxPhraseWords = 3
+
+// Parse resolves an allergen name such as "Gluten" or "tree nuts" to its
+// group. It reports false for names that are not a known group.
+func Parse(name string) (string, bool) {
+	group, ok := groupAliases[strings.ToLower(strings.Join(strings.Fields(name), " "))]
+	return group, ok
+}
+
+// Detect returns the allergen groups found in the ingredient lines, in the
+// order of All, or nil when there are none.
+func Detect(lines []string) models.StringArray {
+	found := make(map[string]bool)
+	for _, line := range lines {
+		for _, group := range DetectLine(line) {
+			found[group] = true
+		}
+	}
+	var groups models.StringArray
+	for _, group := range All {
+		if found[group] {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// DetectLine returns the allergen groups named in a single ingredient line.
+// The longest matching phrase wins, so "peanut butter" counts as peanut but
+// not milk. A keyword followed by "free" is ignored and exempts the next
+// keyword from its groups, so "gluten-free flour" contains no wheat.
+func DetectLine(line string) []string {
+	words := strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	var groups, exempt []string
+	for i := 0; i < len(words); {
+		n, matched := match(words[i:])
+		if n == 0 {
+			i++
+			continue
+		}
+		i += n
+		if i < len(words) && words[i] == "free" {
+			exempt = append(exempt, matched...)
+			i++
+			continue
+		}
+		for _, group := range matched {
+			if !slices.Contains(exempt, group) {
+				groups = append(groups, group)
+			}
+		}
+		exempt = nil
+	}
+	return groups
+}
+
+// match finds the longest keyword at the start of words, returning how many
+// words it spans and its groups; n is 0 when nothing matches.
+func match(words []string) (n int, groups []string) {
+	for n = min(maxPhraseWords, len(words)); n > 0; n-- {
+		phrase := strings.Join(words[:n-1], " ")
+		if phrase != "" {
+			phrase += " "
+		}
+		last := words[n-1]
+		for _, form := range []string{last, singular(last)} {
+			if groups, ok := keywords[phrase+form]; ok {
+				return n, groups
+			}
+		}
+	}
+	return 0, nil
+}
+
+// singular strips a regular English plural ending from word.
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "ches"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+// Disclaimer describes the allergen groups in a sentence such as
+// "Contains milk, egg and wheat (gluten).", or returns "" for none.
+func Disclaimer(groups []string) string {
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if name, ok := displayNames[group]; ok {
+			names = append(names, name)
+		}
+	}
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return "Contains " + names[0] + "."
+	}
+	return "Contains " + strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1] + "."
+}
+
+// Annotate stores the allergens detected in recipe's ingredients. The
+// allergy disclaimer is regenerated when it is blank or was generated from
+// previous, the allergens the recipe had before; disclaimers written by hand
+// are kept.
+func Annotate(recipe *models.Recipe, previous []string) {
+	disclaimer := strings.TrimSpace(recipe.AllergyDisclaimer)
+	generated := disclaimer == "" || disclaimer == Disclaimer(previous)
+	recipe.Allergens = Detect(recipe.Ingredients)
+	if generated {
+		recipe.AllergyDisclaimer = Disclaimer(recipe.Allergens)
+	}
+}
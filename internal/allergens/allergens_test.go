@@ -0,0 +1,99 @@
+package allergens_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pageza/recipe-book-api-v2/internal/allergens"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// lineCases are real-world ingredient lines and the allergen groups they name.
+var lineCases = []struct {
+	line string
+	want []string
+}{
+	// Plain keywords, plurals and synonyms.
+	{"2 cups all-purpose flour", []string{allergens.Wheat}},
+	{"3 large eggs, beaten", []string{allergens.Egg}},
+	{"½ cup heavy cream", []string{allergens.Milk}},
+	{"4 anchovies, chopped", []string{allergens.Fish}},
+	{"1 lb shrimp, peeled and deveined", []string{allergens.Shellfish}},
+	{"¼ cup toasted pine nuts", []string{allergens.TreeNut}},
+	{"2 tbsp tahini", []string{allergens.Sesame}},
+	{"200 g firm tofu, cubed", []string{allergens.Soy}},
+	{"1 cup grated Parmesan", []string{allergens.Milk}},
+
+	// Phrases that name several groups.
+	{"3 tbsp soy sauce", []string{allergens.Soy, allergens.Wheat}},
+	{"8 oz egg noodles", []string{allergens.Egg, allergens.Wheat}},
+
+	// Longer phrases shadow shorter keywords.
+	{"2 tbsp peanut butter", []string{allergens.Peanut}},
+	{"1 can (400 ml) coconut milk", nil},
+	{"1 cup unsweetened almond milk", []string{allergens.TreeNut}},
+	{"½ tsp cream of tartar", nil},
+	{"1 cup rice flour", nil},
+
+	// "-free" cancels the keyword and what it describes.
+	{"2 cups gluten-free flour", nil},
+	{"3 tbsp gluten-free soy sauce", []string{allergens.Soy}},
+	{"100 g dairy-free chocolate", nil},
+
+	// Words that merely contain a keyword.
+	{"1 eggplant, diced", nil},
+	{"½ tsp ground nutmeg", nil},
+	{"1 butternut squash", nil},
+	{"2 tbsp buckwheat groats", nil},
+}
+
+func TestDetectLine(t *testing.T) {
+	for _, tc := range lineCases {
+		assert.Equal(t, tc.want, allergens.DetectLine(tc.line), tc.line)
+	}
+}
+
+func TestDetectOrdersAndDedupes(t *testing.T) {
+	got := allergens.Detect([]string{"1 cup milk", "2 cups flour", "1 egg", "2 tbsp butter", "sesame seeds, to garnish"})
+	assert.Equal(t, models.StringArray{allergens.Milk, allergens.Egg, allergens.Wheat, allergens.Sesame}, got)
+	assert.Nil(t, allergens.Detect([]string{"1 tomato", "salt"}))
+}
+
+func TestParse(t *testing.T) {
+	for name, want := range map[string]string{
+		"Gluten": allergens.Wheat, "tree  nuts": allergens.TreeNut, "dairy": allergens.Milk,
+		"Peanuts": allergens.Peanut, "soya": allergens.Soy,
+	} {
+		got, ok := allergens.Parse(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, got, name)
+	}
+	_, ok := allergens.Parse("lupin")
+	assert.False(t, ok)
+}
+
+func TestDisclaimerAndAnnotate(t *testing.T) {
+	assert.Equal(t, "", allergens.Disclaimer(nil))
+	assert.Equal(t, "Contains peanuts.", allergens.Disclaimer([]string{allergens.Peanut}))
+	assert.Equal(t, "Contains milk, egg and wheat (gluten).",
+		allergens.Disclaimer([]string{allergens.Milk, allergens.Egg, allergens.Wheat}))
+
+	recipe := &models.Recipe{Ingredients: []string{"1 cup flour", "1 egg"}}
+	allergens.Annotate(recipe, nil)
+	assert.Equal(t, models.StringArray{allergens.Egg, allergens.Wheat}, recipe.Allergens)
+	assert.Equal(t, "Contains egg and wheat (gluten).", recipe.AllergyDisclaimer)
+
+	// A generated disclaimer follows the ingredients...
+	previous := recipe.Allergens
+	recipe.Ingredients = []string{"1 cup flour"}
+	allergens.Annotate(recipe, previous)
+	assert.Equal(t, "Contains wheat (gluten).", recipe.AllergyDisclaimer)
+
+	// ...but a hand-written one is kept.
+	recipe.AllergyDisclaimer = "Made in a kitchen that handles nuts."
+	recipe.Ingredients = []string{"1 cup milk"}
+	allergens.Annotate(recipe, recipe.Allergens)
+	assert.Equal(t, models.StringArray{allergens.Milk}, recipe.Allergens)
+	assert.Equal(t, "Made in a kitchen that handles nuts.", recipe.AllergyDisclaimer)
+}
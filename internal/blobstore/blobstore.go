@@ -0,0 +1,124 @@
+// Package blobstore stores binary objects, such as recipe photos, under
+// slash-separated keys and tells where clients can download them.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidKey is returned for keys that are empty, absolute or climb out
+// of the store with "..".
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// BlobStore is where uploaded media is kept. Implementations must be safe for
+// concurrent use.
+type BlobStore interface {
+	// Put stores data under key, replacing any existing object.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Delete removes the object stored under key. Deleting a missing object
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the address clients use to download the object under key.
+	URL(key string) string
+}
+
+// LocalStore is a BlobStore that keeps objects as files below a directory.
+// Its Handler serves them at the base URL it was created with.
+type LocalStore struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalStore returns a LocalStore writing below root, which is created if
+// needed. baseURL is the path or absolute URL the files are served at, such
+// as "/media" or "https://cdn.example.com/media".
+func NewLocalStore(root, baseURL string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &LocalStore{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// Put writes data to a temporary file and renames it into place, so readers
+// never see a partly written object.
+func (s *LocalStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Delete removes the file stored under key.
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL joins the base URL and the escaped key.
+func (s *LocalStore) URL(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return s.baseURL + "/" + strings.Join(segments, "/")
+}
+
+// Handler serves stored files by key, taken from the request path with any
+// prefix already stripped. Directories are not listed.
+func (s *LocalStore) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, err := s.path(strings.TrimPrefix(r.URL.Path, "/"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if info, err := os.Stat(name); err != nil || info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		http.ServeFile(w, r, name)
+	})
+}
+
+// path maps key to a file below the root.
+func (s *LocalStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,48 @@
+package blobstore_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := t.TempDir()
+	store, err := blobstore.NewLocalStore(root, "/media/")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "recipes/r1/photo one.jpg", []byte("jpeg"), "image/jpeg"))
+	data, err := os.ReadFile(filepath.Join(root, "recipes", "r1", "photo one.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+	assert.Equal(t, "/media/recipes/r1/photo%20one.jpg", store.URL("recipes/r1/photo one.jpg"))
+
+	w := httptest.NewRecorder()
+	store.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/recipes/r1/photo%20one.jpg", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "jpeg", w.Body.String())
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	for _, path := range []string{"/recipes/r1", "/recipes/missing.jpg", "/../outside"} {
+		w = httptest.NewRecorder()
+		store.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+
+	require.NoError(t, store.Delete(ctx, "recipes/r1/photo one.jpg"))
+	require.NoError(t, store.Delete(ctx, "recipes/r1/photo one.jpg"), "already gone")
+	_, err = os.Stat(filepath.Join(root, "recipes", "r1", "photo one.jpg"))
+	assert.True(t, os.IsNotExist(err))
+
+	for _, key := range []string{"", "/etc/passwd", "../escape", "a/../../b", "a//b"} {
+		assert.ErrorIs(t, store.Put(ctx, key, nil, ""), blobstore.ErrInvalidKey, key)
+	}
+}
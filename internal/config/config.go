@@ -5,15 +5,23 @@ All Rights Reserved.
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// defaultJWTSecret is the JWT secret used when JWT_SECRET is not set. It is
+// public, so nothing else is derived from it.
+const defaultJWTSecret = "your_jwt_secret"
+
 // Config holds app configuration
 type Config struct {
 	DatabaseURL string
@@ -24,6 +32,20 @@ type Config struct {
 	DBPassword  string
 	DBName      string
 	JWTSecret   string
+	// ShareLinkSecret signs recipe share links. It defaults to a key derived
+	// from JWTSecret, and is empty when JWTSecret is the built-in default.
+	// Changing it invalidates every link issued before.
+	ShareLinkSecret string
+	// TrashRetention is how long deleted recipes stay in the trash before
+	// the API gateway purges them; zero disables purging.
+	TrashRetention time.Duration
+	// TrashPurgeInterval is how often the trash is checked for expired recipes.
+	TrashPurgeInterval time.Duration
+	// MediaDir is the directory uploaded recipe images are stored in.
+	MediaDir string
+	// MediaBaseURL is where clients reach the files of MediaDir, which the
+	// API serves at /media; set it to an absolute URL behind a proxy or CDN.
+	MediaBaseURL string
 }
 
 func LoadConfig() (*Config, error) {
@@ -35,7 +57,18 @@ func LoadConfig() (*Config, error) {
 		DBUser:      getEnv("DB_USER", "postgres"),
 		DBPassword:  getEnv("DB_PASSWORD", "postgres"),
 		DBName:      getEnv("DB_NAME", "recipe_db"),
-		JWTSecret:   getEnv("JWT_SECRET", "your_jwt_secret"),
+		JWTSecret:   getEnv("JWT_SECRET", defaultJWTSecret),
+
+		MediaDir:     getEnv("MEDIA_DIR", "./media"),
+		MediaBaseURL: getEnv("MEDIA_BASE_URL", "/media"),
+	}
+	cfg.ShareLinkSecret = getEnv("SHARE_LINK_SECRET", deriveSecret(cfg.JWTSecret, "share-links"))
+	var err error
+	if cfg.TrashRetention, err = getDurationEnv("TRASH_RETENTION", 30*24*time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.TrashPurgeInterval, err = getDurationEnv("TRASH_PURGE_INTERVAL", time.Hour); err != nil {
+		return nil, err
 	}
 	return cfg, nil
 }
@@ -79,3 +112,27 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// deriveSecret returns a key for purpose derived from secret, so that one
+// leaking does not reveal the other, or "" when secret is the default.
+func deriveSecret(secret, purpose string) string {
+	if secret == "" || secret == defaultJWTSecret {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// getDurationEnv parses a duration such as "720h" from the environment.
+func getDurationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: want a non-negative duration such as 720h", key, value)
+	}
+	return d, nil
+}
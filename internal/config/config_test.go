@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pageza/recipe-book-api-v2/internal/config"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ func TestLoadConfig(t *testing.T) {
 	os.Setenv("DB_PASSWORD", "testpass")
 	os.Setenv("DB_NAME", "testdb")
 	os.Setenv("JWT_SECRET", "testsecret")
+	os.Unsetenv("SHARE_LINK_SECRET")
 
 	cfg, err := config.LoadConfig()
 	assert.NoError(t, err)
@@ -27,4 +29,40 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, "testpass", cfg.DBPassword)
 	assert.Equal(t, "testdb", cfg.DBName)
 	assert.Equal(t, "testsecret", cfg.JWTSecret)
+	assert.Len(t, cfg.ShareLinkSecret, 64, "share links use a key derived from the JWT secret")
+	assert.NotContains(t, cfg.ShareLinkSecret, "testsecret")
+}
+
+func TestLoadConfigShareLinkSecret(t *testing.T) {
+	t.Setenv("JWT_SECRET", "testsecret")
+	t.Setenv("SHARE_LINK_SECRET", "linksecret")
+	cfg, err := config.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "linksecret", cfg.ShareLinkSecret)
+
+	os.Unsetenv("SHARE_LINK_SECRET")
+	os.Unsetenv("JWT_SECRET")
+	cfg, err = config.LoadConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, cfg.ShareLinkSecret, "nothing is derived from the default JWT secret")
+}
+
+func TestLoadConfigTrashDurations(t *testing.T) {
+	os.Unsetenv("TRASH_RETENTION")
+	os.Unsetenv("TRASH_PURGE_INTERVAL")
+	cfg, err := config.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, cfg.TrashRetention)
+	assert.Equal(t, time.Hour, cfg.TrashPurgeInterval)
+
+	t.Setenv("TRASH_RETENTION", "168h")
+	t.Setenv("TRASH_PURGE_INTERVAL", "15m")
+	cfg, err = config.LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, cfg.TrashRetention)
+	assert.Equal(t, 15*time.Minute, cfg.TrashPurgeInterval)
+
+	t.Setenv("TRASH_RETENTION", "a month")
+	_, err = config.LoadConfig()
+	assert.Error(t, err)
 }
@@ -0,0 +1,161 @@
+// Package export renders recipes as printable Markdown, HTML and PDF
+// documents. All three share one layout: title, details such as servings and
+// times, equipment, ingredients, numbered steps, nutrition per serving and
+// the allergy disclaimer.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// Formats accepted by Render.
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatPDF      = "pdf"
+)
+
+// ErrUnknownFormat is returned (wrapped with the format) for formats other
+// than the Format constants.
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// contentTypes maps each format to the media type it is served as.
+var contentTypes = map[string]string{
+	FormatMarkdown: "text/markdown; charset=utf-8",
+	FormatHTML:     "text/html; charset=utf-8",
+	FormatPDF:      "application/pdf",
+}
+
+// ContentType returns the media type of documents in format.
+func ContentType(format string) (string, error) {
+	if ct, ok := contentTypes[format]; ok {
+		return ct, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// Filename suggests a download name for the recipe in format, derived from
+// its title, e.g. "lemon-tart.pdf".
+func Filename(recipe *models.Recipe, format string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(recipe.Title) {
+		switch {
+		case r < 0x80 && (r >= 'a' && r <= 'z' || r >= '0' && r <= '9'):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	name := b.String()
+	if name == "" {
+		name = "recipe"
+	}
+	return name + "." + format
+}
+
+// Render writes the recipe to w as a document in format.
+func Render(w io.Writer, recipe *models.Recipe, format string) error {
+	doc := newLayout(recipe)
+	switch format {
+	case FormatMarkdown:
+		return renderMarkdown(w, doc)
+	case FormatHTML:
+		return renderHTML(w, doc)
+	case FormatPDF:
+		return renderPDF(w, doc)
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// layout is the printable content of a recipe, independent of format.
+type layout struct {
+	Title       string
+	Details     []string // e.g. "Serves 4", "Total 1 hr 5 min"
+	Equipment   []string
+	Ingredients []string
+	Steps       []string
+	Nutrition   []string // per serving, e.g. "Calories: 312 kcal"
+	Disclaimer  string
+}
+
+// newLayout collects the printable content of a recipe. Blank entries and
+// zero nutrition values are left out.
+func newLayout(recipe *models.Recipe) layout {
+	doc := layout{
+		Title:       strings.TrimSpace(recipe.Title),
+		Equipment:   nonBlank(recipe.Appliances),
+		Ingredients: nonBlank(recipe.Ingredients),
+		Steps:       nonBlank(recipe.Steps),
+		Disclaimer:  strings.TrimSpace(recipe.AllergyDisclaimer),
+	}
+	if recipe.Servings > 0 {
+		doc.Details = append(doc.Details, "Serves "+strconv.Itoa(recipe.Servings))
+	}
+	if recipe.TotalMinutes > 0 {
+		if recipe.PrepMinutes > 0 {
+			doc.Details = append(doc.Details, "Prep "+formatMinutes(recipe.PrepMinutes))
+		}
+		if recipe.CookMinutes > 0 {
+			doc.Details = append(doc.Details, "Cook "+formatMinutes(recipe.CookMinutes))
+		}
+		doc.Details = append(doc.Details, "Total "+formatMinutes(recipe.TotalMinutes))
+	}
+
+	n := recipe.NutritionalInfo
+	for _, v := range []struct {
+		name  string
+		value float64
+		unit  string
+	}{
+		{"Calories", n.Calories, "kcal"},
+		{"Protein", n.Protein, "g"},
+		{"Carbohydrates", n.Carbohydrates, "g"},
+		{"Fat", n.Fat, "g"},
+		{"Fiber", n.Fiber, "g"},
+	} {
+		// Values are stored unrounded; one decimal is plenty on paper.
+		if v.value > 0 {
+			doc.Nutrition = append(doc.Nutrition, v.name+": "+strconv.FormatFloat(roundTenth(v.value), 'f', -1, 64)+" "+v.unit)
+		}
+	}
+	return doc
+}
+
+// formatMinutes formats a duration such as "45 min" or "1 hr 5 min".
+func formatMinutes(minutes int) string {
+	switch h, m := minutes/60, minutes%60; {
+	case h == 0:
+		return strconv.Itoa(m) + " min"
+	case m == 0:
+		return strconv.Itoa(h) + " hr"
+	default:
+		return strconv.Itoa(h) + " hr " + strconv.Itoa(m) + " min"
+	}
+}
+
+// roundTenth rounds v to one decimal place.
+func roundTenth(v float64) float64 {
+	return float64(int64(v*10+0.5)) / 10
+}
+
+// nonBlank returns the trimmed, non-blank items.
+func nonBlank(items []string) []string {
+	var out []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
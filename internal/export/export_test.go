@@ -0,0 +1,139 @@
+package export_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pageza/recipe-book-api-v2/internal/export"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+func sampleRecipe() *models.Recipe {
+	return &models.Recipe{
+		ID:                "r-1",
+		Title:             "Crème brûlée",
+		Ingredients:       []string{"500 ml double cream", "5 egg yolks", "⅓ cup sugar", "  "},
+		Steps:             []string{"Heat the cream to 80°C.", "Whisk in the yolks and sugar.", "Bake at 150°C for 40 minutes."},
+		Servings:          4,
+		PrepMinutes:       10,
+		CookMinutes:       45,
+		TotalMinutes:      55,
+		NutritionalInfo:   models.NutritionalInfo{Calories: 512.345, Protein: 6.04},
+		AllergyDisclaimer: "Contains egg and milk.",
+		Appliances:        []string{"Oven", "Ramekins"},
+	}
+}
+
+func render(t *testing.T, recipe *models.Recipe, format string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, export.Render(&buf, recipe, format))
+	return buf.String()
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	want := `# Crème brûlée
+
+*Serves 4 · Prep 10 min · Cook 45 min · Total 55 min*
+
+## Equipment
+
+- Oven
+- Ramekins
+
+## Ingredients
+
+- 500 ml double cream
+- 5 egg yolks
+- ⅓ cup sugar
+
+## Steps
+
+1. Heat the cream to 80°C.
+2. Whisk in the yolks and sugar.
+3. Bake at 150°C for 40 minutes.
+
+## Nutrition per serving
+
+- Calories: 512.3 kcal
+- Protein: 6 g
+
+> **Allergens:** Contains egg and milk.
+`
+	assert.Equal(t, want, render(t, sampleRecipe(), export.FormatMarkdown))
+
+	escaped := render(t, &models.Recipe{Title: "Fish *and* chips", Ingredients: []string{"-1 tsp salt"}}, export.FormatMarkdown)
+	assert.Contains(t, escaped, `# Fish \*and\* chips`)
+	assert.Contains(t, escaped, `- \-1 tsp salt`)
+}
+
+func TestRenderHTML(t *testing.T) {
+	recipe := sampleRecipe()
+	recipe.Steps = append(recipe.Steps, `Serve <script>alert("x")</script>`)
+	html := render(t, recipe, export.FormatHTML)
+	assert.Contains(t, html, "<title>Crème brûlée</title>")
+	assert.Contains(t, html, "<span>Serves 4</span><span>Prep 10 min</span>")
+	assert.Contains(t, html, "<li>Bake at 150°C for 40 minutes.</li>")
+	assert.Contains(t, html, "&lt;script&gt;", "text is escaped")
+	assert.NotContains(t, html, "<script>")
+	assert.Contains(t, html, "@media print")
+
+	bare := render(t, &models.Recipe{Title: "Toast", Steps: []string{"Toast it."}}, export.FormatHTML)
+	assert.NotContains(t, bare, "Nutrition")
+	assert.NotContains(t, bare, "Allergens")
+}
+
+// xrefOffsets matches the in-use entries of a PDF cross-reference table.
+var xrefOffsets = regexp.MustCompile(`(\d{10}) 00000 n `)
+
+func TestRenderPDF(t *testing.T) {
+	pdf := render(t, sampleRecipe(), export.FormatPDF)
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	assert.Contains(t, pdf, "(Cr\\350me br\\373l\\351e) Tj", "text is WinAnsi encoded")
+	assert.Contains(t, pdf, "(1/3 cup sugar) Tj", "fractions outside WinAnsi are spelled out")
+	assert.Contains(t, pdf, "(Page 1 of 1) Tj")
+
+	// Every cross-reference entry points at the object it numbers.
+	offsets := xrefOffsets.FindAllStringSubmatch(pdf, -1)
+	require.NotEmpty(t, offsets)
+	for i, m := range offsets {
+		off, err := strconv.Atoi(m[1])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(pdf[off:], strconv.Itoa(i+1)+" 0 obj\n"), "object %d", i+1)
+	}
+	start := strings.LastIndex(pdf, "startxref\n") + len("startxref\n")
+	xref, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(pdf[start:], "%%EOF\n")))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pdf[xref:], "xref\n"))
+}
+
+func TestRenderPDFBreaksPages(t *testing.T) {
+	recipe := sampleRecipe()
+	for i := 0; i < 80; i++ {
+		recipe.Steps = append(recipe.Steps, strings.Repeat("Stir the custard gently and keep watching it closely. ", 3))
+	}
+	pdf := render(t, recipe, export.FormatPDF)
+	pages := strings.Count(pdf, "/Type /Page ")
+	assert.Greater(t, pages, 1)
+	assert.Contains(t, pdf, "/Count "+strconv.Itoa(pages))
+	assert.Contains(t, pdf, "(Page "+strconv.Itoa(pages)+" of "+strconv.Itoa(pages)+") Tj")
+}
+
+func TestFormats(t *testing.T) {
+	ct, err := export.ContentType(export.FormatPDF)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", ct)
+	_, err = export.ContentType("docx")
+	assert.ErrorIs(t, err, export.ErrUnknownFormat)
+	assert.ErrorIs(t, export.Render(&bytes.Buffer{}, sampleRecipe(), "docx"), export.ErrUnknownFormat)
+
+	assert.Equal(t, "cr-me-br-l-e.md", export.Filename(sampleRecipe(), export.FormatMarkdown))
+	assert.Equal(t, "recipe.pdf", export.Filename(&models.Recipe{Title: "!!!"}, export.FormatPDF))
+}
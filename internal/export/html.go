@@ -0,0 +1,71 @@
+package export
+
+import (
+	"html/template"
+	"io"
+)
+
+// htmlTemplate lays the recipe out on a single page with print styles, so
+// browsers can print it without the surrounding site.
+var htmlTemplate = template.Must(template.New("recipe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, "Times New Roman", serif; color: #222; max-width: 42em; margin: 2em auto; padding: 0 1em; line-height: 1.5; }
+h1 { margin-bottom: 0.2em; }
+h2 { font-size: 1.1em; text-transform: uppercase; letter-spacing: 0.05em; border-bottom: 1px solid #ccc; margin-top: 1.5em; }
+.details { color: #555; font-style: italic; }
+.details span + span::before { content: " · "; }
+ol li { margin-bottom: 0.5em; }
+.allergens { border: 1px solid #999; padding: 0.5em 1em; margin-top: 1.5em; }
+@media print {
+  body { margin: 0; max-width: none; font-size: 11pt; }
+  h2, li { break-inside: avoid; }
+}
+</style>
+</head>
+<body>
+<article>
+<h1>{{.Title}}</h1>
+{{- if .Details}}
+<p class="details">{{range .Details}}<span>{{.}}</span>{{end}}</p>
+{{- end}}
+{{- if .Equipment}}
+<h2>Equipment</h2>
+<ul>{{range .Equipment}}
+<li>{{.}}</li>{{end}}
+</ul>
+{{- end}}
+{{- if .Ingredients}}
+<h2>Ingredients</h2>
+<ul>{{range .Ingredients}}
+<li>{{.}}</li>{{end}}
+</ul>
+{{- end}}
+{{- if .Steps}}
+<h2>Steps</h2>
+<ol>{{range .Steps}}
+<li>{{.}}</li>{{end}}
+</ol>
+{{- end}}
+{{- if .Nutrition}}
+<h2>Nutrition per serving</h2>
+<ul>{{range .Nutrition}}
+<li>{{.}}</li>{{end}}
+</ul>
+{{- end}}
+{{- if .Disclaimer}}
+<p class="allergens"><strong>Allergens:</strong> {{.Disclaimer}}</p>
+{{- end}}
+</article>
+</body>
+</html>
+`))
+
+// renderHTML writes the layout as a standalone HTML page.
+func renderHTML(w io.Writer, doc layout) error {
+	return htmlTemplate.Execute(w, doc)
+}
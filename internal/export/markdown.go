@@ -0,0 +1,55 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// markdownEscaper backslash-escapes characters that Markdown would otherwise
+// read as formatting.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `#`, `\#`, `|`, `\|`,
+)
+
+// renderMarkdown writes the layout as CommonMark.
+func renderMarkdown(w io.Writer, doc layout) error {
+	out := bufio.NewWriter(w)
+	out.WriteString("# " + markdownText(doc.Title) + "\n")
+	if len(doc.Details) > 0 {
+		out.WriteString("\n*" + strings.Join(doc.Details, " · ") + "*\n")
+	}
+	list := func(heading string, items []string, numbered bool) {
+		if len(items) == 0 {
+			return
+		}
+		out.WriteString("\n## " + heading + "\n\n")
+		for i, item := range items {
+			marker := "-"
+			if numbered {
+				marker = strconv.Itoa(i+1) + "."
+			}
+			out.WriteString(marker + " " + markdownText(item) + "\n")
+		}
+	}
+	list("Equipment", doc.Equipment, false)
+	list("Ingredients", doc.Ingredients, false)
+	list("Steps", doc.Steps, true)
+	list("Nutrition per serving", doc.Nutrition, false)
+	if doc.Disclaimer != "" {
+		out.WriteString("\n> **Allergens:** " + markdownText(doc.Disclaimer) + "\n")
+	}
+	return out.Flush()
+}
+
+// markdownText escapes text for use inline, including a leading list marker
+// such as "-" or "+" that would start a nested list.
+func markdownText(text string) string {
+	text = markdownEscaper.Replace(text)
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		text = `\` + text
+	}
+	return text
+}
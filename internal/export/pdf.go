@@ -0,0 +1,307 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Page geometry in points: A4 with 2 cm margins.
+const (
+	pageWidth   = 595.28
+	pageHeight  = 841.89
+	pageMargin  = 56.69
+	footerSize  = 8
+	lineSpacing = 1.35
+)
+
+// PDF fonts. Both are standard Type 1 fonts every reader provides, so
+// nothing is embedded.
+const (
+	fontRegular = "F1" // Helvetica
+	fontBold    = "F2" // Helvetica-Bold
+)
+
+// renderPDF writes the layout as a PDF document. Text is set in Helvetica
+// with WinAnsi encoding; characters outside it print as "?".
+func renderPDF(w io.Writer, doc layout) error {
+	p := newPDFPager()
+	p.paragraph(fontBold, 20, 0, "", doc.Title)
+	if len(doc.Details) > 0 {
+		p.space(2)
+		p.paragraph(fontRegular, 10, 0, "", strings.Join(doc.Details, "  |  "))
+	}
+	list := func(heading string, items []string, numbered bool) {
+		if len(items) == 0 {
+			return
+		}
+		p.heading(heading)
+		for i, item := range items {
+			marker := "\x95" // WinAnsi bullet
+			if numbered {
+				marker = strconv.Itoa(i+1) + "."
+			}
+			p.paragraph(fontRegular, 11, 18, marker, item)
+			if numbered {
+				p.space(4)
+			}
+		}
+	}
+	list("Equipment", doc.Equipment, false)
+	list("Ingredients", doc.Ingredients, false)
+	list("Steps", doc.Steps, true)
+	list("Nutrition per serving", doc.Nutrition, false)
+	if doc.Disclaimer != "" {
+		p.heading("Allergens")
+		p.paragraph(fontRegular, 11, 0, "", doc.Disclaimer)
+	}
+	_, err := w.Write(p.document(doc.Title))
+	return err
+}
+
+// pdfPager lays text out top to bottom, starting a new page when the
+// current one is full.
+type pdfPager struct {
+	pages []*bytes.Buffer // content streams
+	y     float64         // baseline of the next line on the current page
+}
+
+func newPDFPager() *pdfPager {
+	p := &pdfPager{}
+	p.newPage()
+	return p
+}
+
+func (p *pdfPager) newPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+	p.y = pageHeight - pageMargin
+}
+
+// space moves down by h points.
+func (p *pdfPager) space(h float64) {
+	p.y -= h
+}
+
+// ensure starts a new page unless h more points fit above the bottom margin.
+func (p *pdfPager) ensure(h float64) {
+	if p.y-h < pageMargin {
+		p.newPage()
+	}
+}
+
+// heading writes a section heading, keeping it on the page of the line that
+// follows it.
+func (p *pdfPager) heading(text string) {
+	p.space(10)
+	p.ensure(13*lineSpacing + 11*lineSpacing*2)
+	p.paragraph(fontBold, 13, 0, "", text)
+	p.space(2)
+}
+
+// paragraph wraps text to the page width and writes it. A non-empty marker,
+// such as a bullet or step number, is set in the indent before the first
+// line.
+func (p *pdfPager) paragraph(font string, size, indent float64, marker, text string) {
+	lineHeight := size * lineSpacing
+	for i, line := range wrap(encodeWinAnsi(text), font, size, pageWidth-2*pageMargin-indent) {
+		p.ensure(lineHeight)
+		p.y -= size
+		if i == 0 && marker != "" {
+			p.show(font, size, pageMargin, marker)
+		}
+		p.show(font, size, pageMargin+indent, line)
+		p.y -= lineHeight - size
+	}
+}
+
+// show writes one line of WinAnsi text with its baseline at the current y.
+func (p *pdfPager) show(font string, size, x float64, text string) {
+	fmt.Fprintf(p.pages[len(p.pages)-1], "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font, num(size), num(x), num(p.y), escapePDFString(text))
+}
+
+// document assembles the pages into a complete PDF file, adding a footer
+// with the title and page number to each page.
+func (p *pdfPager) document(title string) []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1-4 are fixed; each page then adds a page and a content object.
+	const firstPage = 5
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range p.pages {
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(p.pages))
+		x := pageWidth - pageMargin - textWidth(footer, fontRegular, footerSize)
+		fmt.Fprintf(content, "BT /%s %d Tf %s %s Td (%s) Tj ET\n", fontRegular, footerSize, num(pageMargin), num(pageMargin/2), escapePDFString(truncate(encodeWinAnsi(title), fontRegular, footerSize, x-pageMargin-24)))
+		fmt.Fprintf(content, "BT /%s %d Tf %s %s Td (%s) Tj ET\n", fontRegular, footerSize, num(x), num(pageMargin/2), footer)
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			num(pageWidth), num(pageHeight), fontRegular, fontBold, firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// wrap breaks WinAnsi text into lines no wider than width points. Words
+// longer than a line are broken between characters.
+func wrap(text, font string, size, width float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if textWidth(candidate, font, size) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		for textWidth(word, font, size) > width {
+			n := len(word) - 1
+			for n > 1 && textWidth(word[:n], font, size) > width {
+				n--
+			}
+			lines = append(lines, word[:n])
+			word = word[n:]
+		}
+		line = word
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// truncate shortens WinAnsi text to fit width points, ending it with an
+// ellipsis when anything was cut.
+func truncate(text, font string, size, width float64) string {
+	if textWidth(text, font, size) <= width {
+		return text
+	}
+	for len(text) > 0 && textWidth(text+"\x85", font, size) > width {
+		text = text[:len(text)-1]
+	}
+	return text + "\x85"
+}
+
+// textWidth returns the width in points of WinAnsi text set in font at size.
+func textWidth(text, font string, size float64) float64 {
+	widths := helveticaWidths
+	if font == fontBold {
+		widths = helveticaBoldWidths
+	}
+	total := 0
+	for i := 0; i < len(text); i++ {
+		if c := text[i]; c >= 32 && c <= 126 {
+			total += widths[c-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// winAnsiExtras maps the characters WinAnsi places in 0x80-0x9F; Latin-1
+// characters keep their code points.
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// fractionFallbacks spells out vulgar fractions WinAnsi lacks.
+var fractionFallbacks = map[rune]string{
+	'⅓': "1/3", '⅔': "2/3", '⅕': "1/5", '⅖': "2/5", '⅗': "3/5", '⅘': "4/5",
+	'⅙': "1/6", '⅚': "5/6", '⅛': "1/8", '⅜': "3/8", '⅝': "5/8", '⅞': "7/8",
+}
+
+// encodeWinAnsi converts UTF-8 text to WinAnsi bytes, replacing characters
+// it cannot represent with "?".
+func encodeWinAnsi(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteByte(' ')
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			b.WriteByte(byte(r))
+		case winAnsiExtras[r] != 0:
+			b.WriteByte(winAnsiExtras[r])
+		case fractionFallbacks[r] != "":
+			b.WriteString(fractionFallbacks[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// escapePDFString escapes WinAnsi text for a PDF literal string, writing
+// bytes outside printable ASCII as octal escapes.
+func escapePDFString(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// num formats a coordinate with at most two decimals.
+func num(v float64) string {
+	s := strings.TrimRight(strconv.FormatFloat(v, 'f', 2, 64), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// Glyph widths of printable ASCII (32-126) in 1/1000 em, from the Adobe
+// font metrics of the standard fonts.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
@@ -0,0 +1,257 @@
+package collections
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pageza/recipe-book-api-v2/internal/middleware"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+)
+
+// CollectionService defines the interface for collection operations.
+type CollectionService interface {
+	// CreateCollection stores a new collection owned by userID.
+	CreateCollection(userID string, collection *models.Collection) (*models.Collection, error)
+	// GetCollection retrieves a collection with the recipes userID may read.
+	GetCollection(userID, collectionID string) (*models.Collection, error)
+	// ListCollections returns the collections userID owns or is a member of.
+	ListCollections(userID string) ([]*models.Collection, error)
+	// UpdateCollection replaces the name and description of a collection owned by userID.
+	UpdateCollection(userID, collectionID string, collection *models.Collection) (*models.Collection, error)
+	// DeleteCollection removes a collection owned by userID.
+	DeleteCollection(userID, collectionID string) error
+	// AddRecipe appends a recipe to a collection.
+	AddRecipe(userID, collectionID, recipeID string) (*models.Collection, error)
+	// RemoveRecipe takes a recipe out of a collection.
+	RemoveRecipe(userID, collectionID, recipeID string) (*models.Collection, error)
+	// ReorderRecipes moves the given recipes to the front of a collection.
+	ReorderRecipes(userID, collectionID string, recipeIDs []string) (*models.Collection, error)
+	// ShareCollection gives memberID a role on a collection owned by userID.
+	ShareCollection(userID, collectionID, memberID, role string) (*models.Collection, error)
+	// UnshareCollection removes memberID from a collection.
+	UnshareCollection(userID, collectionID, memberID string) error
+}
+
+// CollectionInput is the request body accepted when creating or renaming a collection.
+type CollectionInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// AddRecipeInput is the request body of POST /collection/:id/recipes.
+type AddRecipeInput struct {
+	RecipeID string `json:"recipe_id" binding:"required"`
+}
+
+// ReorderInput is the request body of PUT /collection/:id/recipes/order.
+type ReorderInput struct {
+	// RecipeIDs are moved to the front in this order; recipes not listed
+	// keep their relative order after them.
+	RecipeIDs []string `json:"recipe_ids" binding:"required"`
+}
+
+// MemberInput is the request body of PUT /collection/:id/members/:userID.
+type MemberInput struct {
+	Role string `json:"role" binding:"required"` // "viewer" or "collaborator"
+}
+
+// CollectionHandler handles HTTP requests related to recipe collections.
+// Every endpoint acts on behalf of the authenticated user.
+type CollectionHandler struct {
+	service CollectionService
+}
+
+// NewCollectionHandler constructs a new CollectionHandler with the given CollectionService.
+func NewCollectionHandler(service CollectionService) *CollectionHandler {
+	return &CollectionHandler{service: service}
+}
+
+// Create handles POST /collections.
+func (h *CollectionHandler) Create(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input CollectionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	collection, err := h.service.CreateCollection(userID, input.toModel())
+	if err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, collection)
+}
+
+// List handles GET /collections, listing the collections the user owns or
+// that are shared with them.
+func (h *CollectionHandler) List(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	collections, err := h.service.ListCollections(userID)
+	if err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"collections": collections})
+}
+
+// Get handles GET /collection/:id, returning the collection with its recipes in order.
+func (h *CollectionHandler) Get(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	collection, err := h.service.GetCollection(userID, c.Param("id"))
+	if err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// Update handles PUT /collection/:id, renaming a collection owned by the user.
+func (h *CollectionHandler) Update(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input CollectionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	collection, err := h.service.UpdateCollection(userID, c.Param("id"), input.toModel())
+	if err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// Delete handles DELETE /collection/:id. The recipes themselves are kept.
+func (h *CollectionHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteCollection(userID, c.Param("id")); err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddRecipe handles POST /collection/:id/recipes, appending a recipe the
+// user can read, whoever owns it.
+func (h *CollectionHandler) AddRecipe(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input AddRecipeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recipe_id is required"})
+		return
+	}
+	collection, err := h.service.AddRecipe(userID, c.Param("id"), input.RecipeID)
+	if err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// RemoveRecipe handles DELETE /collection/:id/recipes/:recipeID.
+func (h *CollectionHandler) RemoveRecipe(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	collection, err := h.service.RemoveRecipe(userID, c.Param("id"), c.Param("recipeID"))
+	if err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// Reorder handles PUT /collection/:id/recipes/order.
+func (h *CollectionHandler) Reorder(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input ReorderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recipe_ids is required"})
+		return
+	}
+	collection, err := h.service.ReorderRecipes(userID, c.Param("id"), input.RecipeIDs)
+	if err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// Share handles PUT /collection/:id/members/:userID, giving another user
+// read-only ("viewer") or editing ("collaborator") access.
+func (h *CollectionHandler) Share(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input MemberInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role is required"})
+		return
+	}
+	collection, err := h.service.ShareCollection(userID, c.Param("id"), c.Param("userID"), input.Role)
+	if err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// Unshare handles DELETE /collection/:id/members/:userID. Members may
+// remove themselves to leave a collection.
+func (h *CollectionHandler) Unshare(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.UnshareCollection(userID, c.Param("id"), c.Param("userID")); err != nil {
+		respondCollectionError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// toModel converts the input payload into a collection model.
+func (in *CollectionInput) toModel() *models.Collection {
+	return &models.Collection{Name: in.Name, Description: in.Description}
+}
+
+// respondCollectionError maps service errors to HTTP status codes.
+func respondCollectionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCollection):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCollectionForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCollectionNotFound), errors.Is(err, service.ErrRecipeNotFound), errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Main App: collection operation failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+	}
+}
@@ -0,0 +1,141 @@
+package collections_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/collections"
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/handlertest"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+)
+
+// setupRouter registers the collection endpoints over a fresh in-memory
+// database holding the users alice and bob, behind the handlertest user header.
+func setupRouter(t *testing.T) (*gin.Engine, repository.RecipeRepository) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models.All()...))
+	users := repository.NewUserRepository(db)
+	for _, name := range []string{"alice", "bob"} {
+		require.NoError(t, users.CreateUser(&models.User{ID: name, Username: name, Email: name + "@example.com", PasswordHash: "x"}))
+	}
+	recipes := repository.NewRecipeRepository(db, nil)
+	handler := collections.NewCollectionHandler(service.NewCollectionService(repository.NewCollectionRepository(db, nil), recipes, users))
+
+	r := handlertest.NewRouter()
+	r.POST("/collections", handler.Create)
+	r.GET("/collections", handler.List)
+	r.GET("/collection/:id", handler.Get)
+	r.PUT("/collection/:id", handler.Update)
+	r.DELETE("/collection/:id", handler.Delete)
+	r.POST("/collection/:id/recipes", handler.AddRecipe)
+	r.PUT("/collection/:id/recipes/order", handler.Reorder)
+	r.DELETE("/collection/:id/recipes/:recipeID", handler.RemoveRecipe)
+	r.PUT("/collection/:id/members/:userID", handler.Share)
+	r.DELETE("/collection/:id/members/:userID", handler.Unshare)
+	return r, recipes
+}
+
+// decodeCollection reads a collection response, returning its recipe IDs in order.
+func decodeCollection(t *testing.T, w *httptest.ResponseRecorder) (*models.Collection, []string) {
+	t.Helper()
+	var collection models.Collection
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &collection), w.Body.String())
+	ids := make([]string, len(collection.Recipes))
+	for i, r := range collection.Recipes {
+		ids[i] = r.ID
+	}
+	return &collection, ids
+}
+
+func TestCollectionLifecycle(t *testing.T) {
+	r, recipes := setupRouter(t)
+	for _, recipe := range []*models.Recipe{
+		{ID: "soup", Title: "Soup", UserID: "alice"},
+		{ID: "bread", Title: "Bread", UserID: "bob"},
+		{ID: "bobs-secret", Title: "Secret", UserID: "bob", Visibility: models.VisibilityPrivate},
+	} {
+		require.NoError(t, recipes.CreateRecipe(recipe, nil))
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, handlertest.DoJSON(r, http.MethodPost, "/collections", "", gin.H{"name": "x"}).Code)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPost, "/collections", "alice", gin.H{"name": "  "}).Code)
+	w := handlertest.DoJSON(r, http.MethodPost, "/collections", "alice", gin.H{"name": " Weeknight dinners "})
+	require.Equal(t, http.StatusCreated, w.Code)
+	created, _ := decodeCollection(t, w)
+	assert.Equal(t, "Weeknight dinners", created.Name)
+	assert.Equal(t, models.CollectionRoleOwner, created.Role)
+	path := "/collection/" + created.ID
+
+	// Recipes of other users can be added, but not ones hidden from the user.
+	assert.Equal(t, http.StatusOK, handlertest.DoJSON(r, http.MethodPost, path+"/recipes", "alice", gin.H{"recipe_id": "soup"}).Code)
+	w = handlertest.DoJSON(r, http.MethodPost, path+"/recipes", "alice", gin.H{"recipe_id": "bread"})
+	require.Equal(t, http.StatusOK, w.Code)
+	_, ids := decodeCollection(t, w)
+	assert.Equal(t, []string{"soup", "bread"}, ids)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPost, path+"/recipes", "alice", gin.H{"recipe_id": "bobs-secret"}).Code)
+
+	w = handlertest.DoJSON(r, http.MethodPut, path+"/recipes/order", "alice", gin.H{"recipe_ids": []string{"bread"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	_, ids = decodeCollection(t, w)
+	assert.Equal(t, []string{"bread", "soup"}, ids)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPut, path+"/recipes/order", "alice", gin.H{"recipe_ids": []string{"bread", "bread"}}).Code)
+
+	// Unshared collections are invisible to other users.
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, path, "bob", nil).Code)
+
+	// Viewers can read but not edit; collaborators can edit the recipes only.
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPut, path+"/members/bob", "alice", gin.H{"role": "admin"}).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPut, path+"/members/nobody", "alice", gin.H{"role": models.CollectionRoleViewer}).Code)
+	assert.Equal(t, http.StatusOK, handlertest.DoJSON(r, http.MethodPut, path+"/members/bob", "alice", gin.H{"role": models.CollectionRoleViewer}).Code)
+	w = handlertest.DoJSON(r, http.MethodGet, path, "bob", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	shared, _ := decodeCollection(t, w)
+	assert.Equal(t, models.CollectionRoleViewer, shared.Role)
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodDelete, path+"/recipes/soup", "bob", nil).Code)
+
+	assert.Equal(t, http.StatusOK, handlertest.DoJSON(r, http.MethodPut, path+"/members/bob", "alice", gin.H{"role": models.CollectionRoleCollaborator}).Code)
+	w = handlertest.DoJSON(r, http.MethodPost, path+"/recipes", "bob", gin.H{"recipe_id": "bobs-secret"})
+	require.Equal(t, http.StatusOK, w.Code)
+	_, ids = decodeCollection(t, w)
+	assert.Equal(t, []string{"bread", "soup", "bobs-secret"}, ids)
+	w = handlertest.DoJSON(r, http.MethodGet, path, "alice", nil)
+	_, ids = decodeCollection(t, w)
+	assert.Equal(t, []string{"bread", "soup"}, ids, "bob's private recipe stays hidden from alice")
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodPut, path, "bob", gin.H{"name": "Mine now"}).Code)
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodDelete, path, "bob", nil).Code)
+
+	var listed struct {
+		Collections []models.Collection `json:"collections"`
+	}
+	w = handlertest.DoJSON(r, http.MethodGet, "/collections", "bob", nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	require.Len(t, listed.Collections, 1)
+	assert.Equal(t, models.CollectionRoleCollaborator, listed.Collections[0].Role)
+	w = handlertest.DoJSON(r, http.MethodGet, "/collections", "alice", nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	require.NotEmpty(t, listed.Collections)
+	assert.Equal(t, models.CollectionRoleOwner, listed.Collections[0].Role)
+
+	// Members can leave; the owner renames and deletes.
+	assert.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, path+"/members/bob", "bob", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, path, "bob", nil).Code)
+	w = handlertest.DoJSON(r, http.MethodPut, path, "alice", gin.H{"name": "Quick dinners", "description": "Under 30 minutes"})
+	require.Equal(t, http.StatusOK, w.Code)
+	renamed, _ := decodeCollection(t, w)
+	assert.Equal(t, "Quick dinners", renamed.Name)
+	assert.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, path, "alice", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, path, "alice", nil).Code)
+	_, err := recipes.GetRecipeByID("soup")
+	assert.NoError(t, err, "deleting a collection keeps its recipes")
+}
@@ -0,0 +1,139 @@
+package comments
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pageza/recipe-book-api-v2/internal/middleware"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+)
+
+// CommentService defines the interface for comment operations.
+type CommentService interface {
+	// CreateComment posts a comment or reply under a recipe as userID.
+	CreateComment(userID, recipeID string, comment *models.Comment) (*models.Comment, error)
+	// UpdateComment replaces the body of a comment written by userID.
+	UpdateComment(userID, recipeID, commentID, body string) (*models.Comment, error)
+	// DeleteComment removes a comment written by userID.
+	DeleteComment(userID, recipeID, commentID string) error
+	// ListComments returns one page of a recipe's top-level comments, or of
+	// the replies to parentID.
+	ListComments(viewerID, recipeID, parentID, cursor string, limit int) (*models.CommentPage, error)
+}
+
+// CommentInput is the request body accepted when posting a comment.
+type CommentInput struct {
+	Body     string `json:"body"`
+	ParentID string `json:"parent_id"` // comment being replied to; empty for a top-level comment
+}
+
+// CommentEditInput is the request body of PUT /recipe/:id/comments/:commentID.
+type CommentEditInput struct {
+	Body string `json:"body"`
+}
+
+// CommentListQuery holds the query parameters of GET /recipe/:id/comments.
+type CommentListQuery struct {
+	ParentID string `form:"parent_id"` // list replies to this comment instead of top-level comments
+	Cursor   string `form:"cursor"`
+	Limit    int    `form:"limit"`
+}
+
+// CommentHandler handles HTTP requests related to recipe comments.
+type CommentHandler struct {
+	service CommentService
+}
+
+// NewCommentHandler constructs a new CommentHandler with the given CommentService.
+func NewCommentHandler(service CommentService) *CommentHandler {
+	return &CommentHandler{service: service}
+}
+
+// Create handles POST /recipe/:id/comments, posting a comment or, with a
+// parent_id, a reply. Users mentioned as @username are notified.
+func (h *CommentHandler) Create(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	comment, err := h.service.CreateComment(userID, c.Param("id"), &models.Comment{Body: input.Body, ParentID: input.ParentID})
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// List handles GET /recipe/:id/comments[?parent_id=&cursor=&limit=],
+// listing one level of the discussion oldest first. Pass next_cursor from
+// a response as cursor to read the following page.
+func (h *CommentHandler) List(c *gin.Context) {
+	var query CommentListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+	page, err := h.service.ListComments(c.GetString("userID"), c.Param("id"), query.ParentID, query.Cursor, query.Limit)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// Update handles PUT /recipe/:id/comments/:commentID, editing a comment
+// written by the logged-in user.
+func (h *CommentHandler) Update(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input CommentEditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	comment, err := h.service.UpdateComment(userID, c.Param("id"), c.Param("commentID"), input.Body)
+	if err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// Delete handles DELETE /recipe/:id/comments/:commentID. Comments with
+// replies remain as deleted placeholders.
+func (h *CommentHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteComment(userID, c.Param("id"), c.Param("commentID")); err != nil {
+		respondCommentError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// respondCommentError maps service errors to HTTP status codes.
+func respondCommentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidComment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCommentForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCommentNotFound), errors.Is(err, service.ErrRecipeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Main App: comment operation failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+	}
+}
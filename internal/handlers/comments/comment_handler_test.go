@@ -0,0 +1,162 @@
+package comments_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/comments"
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/handlertest"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+)
+
+// fakeNotifier records the notifications it is asked to deliver.
+type fakeNotifier struct {
+	sent map[string][]string
+}
+
+func (n *fakeNotifier) SendNotification(userID, message string) error {
+	n.sent[userID] = append(n.sent[userID], message)
+	return nil
+}
+
+// setupRouter registers the comment endpoints over a fresh in-memory database
+// holding the users alice, bob and carol, behind the handlertest user header.
+func setupRouter(t *testing.T) (*gin.Engine, repository.RecipeRepository, *fakeNotifier) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models.All()...))
+	users := repository.NewUserRepository(db)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		require.NoError(t, users.CreateUser(&models.User{ID: name, Username: name, Email: name + "@example.com", PasswordHash: "x"}))
+	}
+	recipes := repository.NewRecipeRepository(db, nil)
+	notifier := &fakeNotifier{sent: map[string][]string{}}
+	handler := comments.NewCommentHandler(service.NewCommentService(repository.NewCommentRepository(db), recipes, users, notifier))
+
+	r := handlertest.NewRouter()
+	r.GET("/recipe/:id/comments", handler.List)
+	r.POST("/recipe/:id/comments", handler.Create)
+	r.PUT("/recipe/:id/comments/:commentID", handler.Update)
+	r.DELETE("/recipe/:id/comments/:commentID", handler.Delete)
+	return r, recipes, notifier
+}
+
+// post creates a comment as userID and returns it.
+func post(t *testing.T, r *gin.Engine, userID, recipeID string, body gin.H) *models.Comment {
+	t.Helper()
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipe/"+recipeID+"/comments", userID, body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var comment models.Comment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &comment))
+	return &comment
+}
+
+// list fetches one page of comments as userID.
+func list(t *testing.T, r *gin.Engine, userID, query string) *models.CommentPage {
+	t.Helper()
+	w := handlertest.DoJSON(r, http.MethodGet, "/recipe/soup/comments"+query, userID, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var page models.CommentPage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	return &page
+}
+
+func TestCommentThreads(t *testing.T) {
+	r, recipes, notifier := setupRouter(t)
+	require.NoError(t, recipes.CreateRecipe(&models.Recipe{ID: "soup", Title: "Soup", UserID: "alice"}, nil))
+	require.NoError(t, recipes.CreateRecipe(&models.Recipe{ID: "secret", Title: "Secret", UserID: "alice", Visibility: models.VisibilityPrivate}, nil))
+
+	assert.Equal(t, http.StatusUnauthorized, handlertest.DoJSON(r, http.MethodPost, "/recipe/soup/comments", "", gin.H{"body": "hi"}).Code)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPost, "/recipe/soup/comments", "bob", gin.H{"body": "  "}).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPost, "/recipe/secret/comments", "bob", gin.H{"body": "hi"}).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, "/recipe/secret/comments", "bob", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPost, "/recipe/soup/comments", "bob", gin.H{"body": "hi", "parent_id": "missing"}).Code)
+
+	// Mentions notify known users other than the author, once each.
+	top := post(t, r, "bob", "soup", gin.H{"body": "Ask @carol and @carol, not @bob or @nobody"})
+	assert.Equal(t, top.ID, top.ThreadID)
+	assert.Len(t, notifier.sent["carol"], 1)
+	assert.Contains(t, notifier.sent["carol"][0], "@bob mentioned you")
+	assert.Empty(t, notifier.sent["bob"])
+	assert.Empty(t, notifier.sent["nobody"])
+
+	// Replies nest up to the depth limit.
+	parent := top
+	for depth := 1; depth <= service.MaxCommentDepth; depth++ {
+		reply := post(t, r, "carol", "soup", gin.H{"body": fmt.Sprintf("depth %d", depth), "parent_id": parent.ID})
+		assert.Equal(t, depth, reply.Depth)
+		assert.Equal(t, top.ID, reply.ThreadID)
+		parent = reply
+	}
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPost, "/recipe/soup/comments", "carol", gin.H{"body": "too deep", "parent_id": parent.ID}).Code)
+
+	// Only the author may edit, and an edit notifies newly mentioned users only.
+	path := "/recipe/soup/comments/" + top.ID
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodPut, path, "carol", gin.H{"body": "mine now"}).Code)
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodDelete, path, "carol", nil).Code)
+	require.Equal(t, http.StatusOK, handlertest.DoJSON(r, http.MethodPut, path, "bob", gin.H{"body": "Ask @carol and @alice"}).Code)
+	assert.Len(t, notifier.sent["carol"], 1)
+	assert.Len(t, notifier.sent["alice"], 1)
+
+	// Deleting a comment with replies leaves a placeholder in the thread.
+	require.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, path, "bob", nil).Code)
+	page := list(t, r, "alice", "")
+	require.Len(t, page.Comments, 1)
+	assert.True(t, page.Comments[0].Deleted)
+	assert.Empty(t, page.Comments[0].Body)
+	assert.Equal(t, 1, page.Comments[0].ReplyCount)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPost, "/recipe/soup/comments", "carol", gin.H{"body": "late", "parent_id": top.ID}).Code)
+
+	replies := list(t, r, "alice", "?parent_id="+top.ID)
+	require.Len(t, replies.Comments, 1)
+	assert.Equal(t, "depth 1", replies.Comments[0].Body)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, "/recipe/soup/comments?parent_id=missing", "alice", nil).Code)
+}
+
+func TestCommentMentionsOnPrivateRecipe(t *testing.T) {
+	r, recipes, notifier := setupRouter(t)
+	require.NoError(t, recipes.CreateRecipe(&models.Recipe{ID: "secret", Title: "Secret", UserID: "alice", Visibility: models.VisibilityPrivate}, nil))
+
+	// Users who cannot see the recipe are not told about it.
+	comment := post(t, r, "alice", "secret", gin.H{"body": "Not ready for @bob yet"})
+	assert.Empty(t, notifier.sent["bob"])
+	require.Equal(t, http.StatusOK, handlertest.DoJSON(r, http.MethodPut, "/recipe/secret/comments/"+comment.ID, "alice", gin.H{"body": "Not ready for @bob or @carol yet"}).Code)
+	assert.Empty(t, notifier.sent["bob"])
+	assert.Empty(t, notifier.sent["carol"])
+}
+
+func TestCommentPagination(t *testing.T) {
+	r, recipes, _ := setupRouter(t)
+	require.NoError(t, recipes.CreateRecipe(&models.Recipe{ID: "soup", Title: "Soup", UserID: "alice"}, nil))
+	var want []string
+	for i := 0; i < 5; i++ {
+		want = append(want, post(t, r, "bob", "soup", gin.H{"body": fmt.Sprintf("comment %d", i)}).ID)
+	}
+
+	var got []string
+	query := "?limit=2"
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5)
+		page := list(t, r, "carol", query)
+		assert.LessOrEqual(t, len(page.Comments), 2)
+		for _, c := range page.Comments {
+			got = append(got, c.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query = "?limit=2&cursor=" + page.NextCursor
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodGet, "/recipe/soup/comments?cursor=bogus", "carol", nil).Code)
+}
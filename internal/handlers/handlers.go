@@ -2,6 +2,10 @@
 package handlers
 
 import (
+	"net/http"
+
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/collections"
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/comments"
 	"github.com/pageza/recipe-book-api-v2/internal/handlers/recipes"
 	"github.com/pageza/recipe-book-api-v2/internal/handlers/users"
 )
@@ -9,5 +13,14 @@ import (
 type Handlers struct {
 	User   *users.UserHandler
 	Recipe *recipes.RecipeHandler
+	// ShareLink mints and resolves recipe share links; nil disables sharing.
+	ShareLink *recipes.ShareLinkHandler
+	// Collection manages user-curated recipe collections; nil disables them.
+	Collection *collections.CollectionHandler
+	// Comment manages threaded comments on recipes; nil disables them.
+	Comment *comments.CommentHandler
+	// Media serves uploaded recipe images by blob key; nil when images are
+	// stored elsewhere.
+	Media http.Handler
 	// Add other handlers as needed
 }
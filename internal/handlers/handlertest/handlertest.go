@@ -0,0 +1,46 @@
+// Package handlertest provides helpers shared by the HTTP handler tests.
+package handlertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserHeader carries the authenticated user's ID in test requests. It stands
+// in for the JWT middleware, which sets the same "userID" context key.
+const UserHeader = "X-User-ID"
+
+// NewRouter returns a gin engine in test mode whose only middleware takes
+// the user ID from the UserHeader request header.
+func NewRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if id := c.GetHeader(UserHeader); id != "" {
+			c.Set("userID", id)
+		}
+		c.Next()
+	})
+	return r
+}
+
+// DoJSON performs a request with an optional JSON body as the given user;
+// an empty userID sends the request anonymously.
+func DoJSON(h http.Handler, method, path, userID string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set(UserHeader, userID)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,41 @@
+package recipes
+
+import (
+	"bytes"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pageza/recipe-book-api-v2/internal/export"
+)
+
+// Export handles GET /recipe/:id/export?format=md|html|pdf, rendering the
+// recipe as a printable document. Like Get it honours the servings and units
+// parameters; the format defaults to PDF. Documents are sent inline unless
+// download=true asks for an attachment.
+func (h *RecipeHandler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", export.FormatPDF)
+	contentType, err := export.ContentType(format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	recipe, ok := h.loadRecipe(c)
+	if !ok {
+		return
+	}
+
+	var body bytes.Buffer
+	if err := export.Render(&body, recipe, format); err != nil {
+		log.Printf("Main App: failed to export recipe %s as %s: %v", recipe.ID, format, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+		return
+	}
+	disposition := "inline"
+	if c.Query("download") == "true" {
+		disposition = "attachment"
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": export.Filename(recipe, format)}))
+	c.Data(http.StatusOK, contentType, body.Bytes())
+}
@@ -3,52 +3,684 @@ package recipes
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pageza/recipe-book-api-v2/internal/images"
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/middleware"
 	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/schemaorg"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+	"github.com/pageza/recipe-book-api-v2/internal/steps"
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
 )
 
 // RecipeService defines the interface for recipe operations.
 type RecipeService interface {
-	// GetRecipe retrieves a recipe by its ID.
-	GetRecipe(recipeID string) (*models.Recipe, error)
+	// GetRecipe retrieves a recipe by its ID if viewerID may read it.
+	GetRecipe(viewerID, recipeID string) (*models.Recipe, error)
 	// QueryRecipes processes query requests for recipes.
 	QueryRecipes(req *models.RecipeQueryRequest) (*models.RecipeQueryResponse, error)
+	// ScaleRecipe retrieves a recipe scaled to the given number of servings.
+	ScaleRecipe(viewerID, recipeID string, servings int) (*models.Recipe, error)
+	// CreateRecipe stores a new recipe owned by userID.
+	CreateRecipe(userID string, recipe *models.Recipe) (*models.Recipe, error)
+	// CreateRecipes stores several new recipes owned by userID, all or none.
+	CreateRecipes(userID string, recipes []*models.Recipe) ([]*models.Recipe, error)
+	// UpdateRecipe replaces the editable fields of a recipe owned by userID.
+	UpdateRecipe(userID, recipeID string, recipe *models.Recipe) (*models.Recipe, error)
+	// DeleteRecipe moves a recipe owned by userID to the trash.
+	DeleteRecipe(userID, recipeID string) error
+	// ListTrash returns the recipes in userID's trash.
+	ListTrash(userID string) ([]*models.Recipe, error)
+	// RestoreRecipe takes a recipe owned by userID out of the trash.
+	RestoreRecipe(userID, recipeID string) (*models.Recipe, error)
+	// ListRevisions returns the change history of a recipe, newest first.
+	ListRevisions(viewerID, recipeID string) ([]*models.RecipeRevision, error)
+	// GetRevision retrieves one revision of a recipe.
+	GetRevision(viewerID, recipeID string, number int) (*models.RecipeRevision, error)
+	// DiffRevisions compares two revisions of a recipe.
+	DiffRevisions(viewerID, recipeID string, from, to int) (*models.RecipeDiff, error)
+	// RevertRecipe restores a recipe owned by userID to an earlier revision.
+	RevertRecipe(userID, recipeID string, number int) (*models.Recipe, error)
+	// ForkRecipe copies a recipe into a new recipe owned by userID.
+	ForkRecipe(userID, recipeID string) (*models.Recipe, error)
+	// ListForks returns the direct forks of a recipe listed for viewerID.
+	ListForks(viewerID, recipeID string) ([]*models.Recipe, error)
+	// RecipeAncestry returns the recipes a recipe descends from, parent first.
+	RecipeAncestry(viewerID, recipeID string) ([]*models.Recipe, error)
+	// ListTags returns tags with their recipe counts, optionally of one kind.
+	ListTags(kind string) ([]models.TagCount, error)
+	// EstimateNutrition calculates a recipe's nutrition from its ingredients.
+	EstimateNutrition(viewerID, recipeID string) (*models.NutritionEstimate, error)
+	// AddRecipeImage stores a photo uploaded for a recipe owned by userID.
+	AddRecipeImage(userID, recipeID string, data []byte) (*models.RecipeImage, error)
+	// FavoriteRecipe saves a recipe to userID's favorites.
+	FavoriteRecipe(userID, recipeID string) (*models.Recipe, error)
+	// UnfavoriteRecipe removes a recipe from userID's favorites.
+	UnfavoriteRecipe(userID, recipeID string) error
+	// ListFavorites returns one page of userID's favorite recipes.
+	ListFavorites(userID string, page, limit int) (*models.RecipeQueryResponse, error)
+	// CreateReview rates and reviews a recipe as userID.
+	CreateReview(userID, recipeID string, review *models.Review) (*models.Review, error)
+	// UpdateReview changes a review written by userID.
+	UpdateReview(userID, recipeID, reviewID string, review *models.Review) (*models.Review, error)
+	// DeleteReview removes a review written by userID.
+	DeleteReview(userID, recipeID, reviewID string) error
+	// ListReviews returns one page of a recipe's reviews with its rating summary.
+	ListReviews(viewerID, recipeID string, page, limit int) (*models.ReviewListResponse, error)
+}
+
+// RecipeInput is the request body accepted when creating or replacing a recipe.
+// Ownership and timestamps are always derived server-side.
+type RecipeInput struct {
+	Title             string                 `json:"title"`
+	Ingredients       []string               `json:"ingredients"`
+	Steps             []string               `json:"steps"`
+	Servings          int                    `json:"servings"`
+	NutritionalInfo   models.NutritionalInfo `json:"nutritional_info"`
+	AllergyDisclaimer string                 `json:"allergy_disclaimer"`
+	Appliances        []string               `json:"appliances"`
+	Tags              []string               `json:"tags"`       // "kind:name" references, e.g. "cuisine:Italian"
+	Visibility        string                 `json:"visibility"` // private, unlisted or public; empty keeps the current value
+}
+
+// toModel converts the input payload into a recipe model.
+func (in *RecipeInput) toModel() *models.Recipe {
+	return &models.Recipe{
+		Title:             in.Title,
+		Ingredients:       in.Ingredients,
+		Steps:             in.Steps,
+		Servings:          in.Servings,
+		NutritionalInfo:   in.NutritionalInfo,
+		AllergyDisclaimer: in.AllergyDisclaimer,
+		Appliances:        in.Appliances,
+		Tags:              models.ParseTags(in.Tags),
+		Visibility:        in.Visibility,
+	}
+}
+
+// MaxImportSize limits the size of documents accepted by Import.
+const MaxImportSize = 5 << 20
+
+// maxImageRequestSize limits image upload requests: the image itself plus
+// room for the multipart framing.
+const maxImageRequestSize = images.MaxUploadSize + 64<<10
+
+// ProfileService looks up users so their display preferences can be applied.
+type ProfileService interface {
+	// GetProfile retrieves a user by ID.
+	GetProfile(userID string) (*models.User, error)
 }
 
 // RecipeHandler handles HTTP requests related to recipes.
 type RecipeHandler struct {
-	service RecipeService
+	service  RecipeService
+	profiles ProfileService
 }
 
 // NewRecipeHandler constructs a new RecipeHandler with the given RecipeService.
-func NewRecipeHandler(service RecipeService) *RecipeHandler {
-	return &RecipeHandler{service: service}
+// profiles is used to read each user's preferred unit system; it may be nil,
+// in which case recipes are shown in their original units.
+func NewRecipeHandler(service RecipeService, profiles ProfileService) *RecipeHandler {
+	return &RecipeHandler{service: service, profiles: profiles}
 }
 
 // Get handles GET requests to retrieve a single recipe by its ID.
-// Endpoint: GET /recipes/:id
+// Endpoint: GET /recipes/:id[?servings=N][&units=metric|imperial|original]
+// When servings is given, ingredient quantities are scaled to yield N servings.
+// Quantities are converted to the requested units, falling back to the
+// "unit_system" preference of the authenticated user. Clients sending
+// Accept: application/ld+json receive a schema.org Recipe instead.
 func (h *RecipeHandler) Get(c *gin.Context) {
+	recipe, ok := h.loadRecipe(c)
+	if !ok {
+		return
+	}
+	if c.NegotiateFormat(gin.MIMEJSON, schemaorg.MediaType) == schemaorg.MediaType {
+		body, err := json.Marshal(schemaorg.FromRecipe(recipe))
+		if err != nil {
+			respondRecipeError(c, err)
+			return
+		}
+		c.Data(http.StatusOK, schemaorg.MediaType, body)
+		return
+	}
+	c.JSON(http.StatusOK, recipe)
+}
+
+// loadRecipe reads the recipe named by the :id parameter as the viewer,
+// scaled and converted as requested by the servings and units query
+// parameters. On failure it writes the error response and reports false.
+func (h *RecipeHandler) loadRecipe(c *gin.Context) (*models.Recipe, bool) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "recipe id is required"})
+		return nil, false
+	}
+	system := h.preferredUnitSystem(c)
+	if raw, ok := c.GetQuery("units"); ok {
+		var err error
+		if system, err = units.ParseSystem(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil, false
+		}
+	}
+
+	var recipe *models.Recipe
+	var err error
+	if raw, ok := c.GetQuery("servings"); ok {
+		servings, convErr := strconv.Atoi(raw)
+		if convErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "servings must be a whole number"})
+			return nil, false
+		}
+		recipe, err = h.service.ScaleRecipe(viewerID(c), id, servings)
+	} else {
+		recipe, err = h.service.GetRecipe(viewerID(c), id)
+	}
+	if err != nil {
+		respondRecipeError(c, err)
+		return nil, false
+	}
+	return service.ConvertRecipeUnits(recipe, system), true
+}
+
+// preferredUnitSystem returns the unit system stored in the authenticated
+// user's preferences. Lookup failures fall back to the original units.
+func (h *RecipeHandler) preferredUnitSystem(c *gin.Context) units.System {
+	userID, ok := c.Get("userID")
+	if !ok || h.profiles == nil {
+		return units.Original
+	}
+	user, err := h.profiles.GetProfile(userID.(string))
+	if err != nil {
+		log.Printf("Main App: could not load preferences for user %v: %v", userID, err)
+		return units.Original
+	}
+	return user.UnitSystem()
+}
+
+// Create handles POST /recipes.
+// The new recipe is owned by the authenticated user.
+func (h *RecipeHandler) Create(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input RecipeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
-	recipe, err := h.service.GetRecipe(id)
+	recipe, err := h.service.CreateRecipe(userID, input.toModel())
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, recipe)
+}
+
+// Import handles POST /recipes/import. The body is a schema.org Recipe JSON-LD
+// document, or an HTML page embedding one, sent either as the request body or
+// as the "file" field of a multipart form. Every recipe found is stored for
+// the authenticated user in one transaction; if any of them is invalid or
+// cannot be stored, none are.
+func (h *RecipeHandler) Import(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportSize)
+	data, contentType, err := readImportDocument(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var parsed []*models.Recipe
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/html" || bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		parsed, err = schemaorg.ParseHTML(bytes.NewReader(data))
+	} else {
+		parsed, err = schemaorg.Parse(data)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	created, err := h.service.CreateRecipes(userID, parsed)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"recipes": created})
+}
+
+// UploadImage handles POST /recipe/:id/images, a multipart upload of one
+// JPEG, PNG or GIF photo in the "image" field. It responds with the stored
+// image, including the URLs of the full-size image and its thumbnails.
+func (h *RecipeHandler) UploadImage(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImageRequestSize)
+	header, err := c.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("image exceeds %d bytes", images.MaxUploadSize)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "image file is required"})
+		return
+	}
+	f, err := header.Open()
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	image, err := h.service.AddRecipeImage(userID, c.Param("id"), data)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, image)
+}
+
+// readImportDocument returns the uploaded document and its content type,
+// taken from the multipart "file" field when present, else the request body.
+func readImportDocument(c *gin.Context) ([]byte, string, error) {
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", fmt.Errorf("file is required")
+		}
+		f, err := header.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		return data, header.Header.Get("Content-Type"), err
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("document exceeds %d bytes or could not be read", MaxImportSize)
+	}
+	return data, c.ContentType(), nil
+}
+
+// Update handles PUT /recipe/:id, replacing every editable field of the recipe.
+func (h *RecipeHandler) Update(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input RecipeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	recipe, err := h.service.UpdateRecipe(userID, c.Param("id"), input.toModel())
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, recipe)
+}
+
+// Patch handles PATCH /recipe/:id.
+// Fields present in the JSON body are applied on top of the stored recipe;
+// omitted fields keep their current values.
+func (h *RecipeHandler) Patch(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	existing, err := h.service.GetRecipe(userID, c.Param("id"))
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	input := RecipeInput{
+		Title:             existing.Title,
+		Ingredients:       existing.Ingredients,
+		Steps:             existing.Steps,
+		Servings:          existing.Servings,
+		NutritionalInfo:   existing.NutritionalInfo,
+		AllergyDisclaimer: existing.AllergyDisclaimer,
+		Appliances:        existing.Appliances,
+		Tags:              models.TagRefs(existing.Tags),
+		Visibility:        existing.Visibility,
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	recipe, err := h.service.UpdateRecipe(userID, existing.ID, input.toModel())
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, recipe)
+}
+
+// Delete handles DELETE /recipe/:id, moving the recipe to the owner's trash.
+func (h *RecipeHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteRecipe(userID, c.Param("id")); err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Trash handles GET /trash, listing the logged-in user's deleted recipes,
+// most recently deleted first.
+func (h *RecipeHandler) Trash(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	recipes, err := h.service.ListTrash(userID)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recipes": recipes})
+}
+
+// Restore handles POST /trash/:id/restore, taking a recipe out of the trash.
+func (h *RecipeHandler) Restore(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	recipe, err := h.service.RestoreRecipe(userID, c.Param("id"))
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, recipe)
+}
+
+// Revisions handles GET /recipe/:id/revisions, listing the recipe's history newest first.
+func (h *RecipeHandler) Revisions(c *gin.Context) {
+	revs, err := h.service.ListRevisions(viewerID(c), c.Param("id"))
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revs})
+}
+
+// Revision handles GET /recipe/:id/revisions/:number.
+func (h *RecipeHandler) Revision(c *gin.Context) {
+	number, ok := revisionNumber(c, c.Param("number"))
+	if !ok {
+		return
+	}
+	rev, err := h.service.GetRevision(viewerID(c), c.Param("id"), number)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rev)
+}
+
+// DiffRevisions handles GET /recipe/:id/revisions/diff?from=N&to=M.
+// Without to the latest revision is used, and without from the one before to.
+func (h *RecipeHandler) DiffRevisions(c *gin.Context) {
+	var from, to int
+	var ok bool
+	if raw := c.Query("from"); raw != "" {
+		if from, ok = revisionNumber(c, raw); !ok {
+			return
+		}
+	}
+	if raw := c.Query("to"); raw != "" {
+		if to, ok = revisionNumber(c, raw); !ok {
+			return
+		}
+	}
+	diff, err := h.service.DiffRevisions(viewerID(c), c.Param("id"), from, to)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// Revert handles POST /recipe/:id/revisions/:number/revert, restoring a recipe
+// owned by the authenticated user to an earlier revision.
+func (h *RecipeHandler) Revert(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	number, ok := revisionNumber(c, c.Param("number"))
+	if !ok {
+		return
+	}
+	recipe, err := h.service.RevertRecipe(userID, c.Param("id"), number)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, recipe)
+}
+
+// Fork handles POST /recipe/:id/fork, copying the recipe into a new recipe
+// owned by the authenticated user.
+func (h *RecipeHandler) Fork(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	recipe, err := h.service.ForkRecipe(userID, c.Param("id"))
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, recipe)
+}
+
+// Forks handles GET /recipe/:id/forks, listing the recipe's direct forks.
+func (h *RecipeHandler) Forks(c *gin.Context) {
+	forks, err := h.service.ListForks(viewerID(c), c.Param("id"))
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"forks": forks})
+}
+
+// Ancestry handles GET /recipe/:id/ancestry, listing the recipes it was
+// forked from, nearest first.
+func (h *RecipeHandler) Ancestry(c *gin.Context) {
+	ancestry, err := h.service.RecipeAncestry(viewerID(c), c.Param("id"))
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"ancestry": ancestry})
+}
+
+// Nutrition handles GET /recipe/:id/nutrition, reporting the nutrition
+// calculated from the recipe's ingredients and the ingredients that could not
+// be counted. The stored nutritional_info is left unchanged.
+func (h *RecipeHandler) Nutrition(c *gin.Context) {
+	estimate, err := h.service.EstimateNutrition(viewerID(c), c.Param("id"))
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, estimate)
+}
+
+// Favorite handles POST /recipe/:id/favorite, saving the recipe to the
+// logged-in user's favorites. It responds with the recipe and its updated
+// favorite count.
+func (h *RecipeHandler) Favorite(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	recipe, err := h.service.FavoriteRecipe(userID, c.Param("id"))
+	if err != nil {
+		respondRecipeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, recipe)
 }
 
+// Unfavorite handles DELETE /recipe/:id/favorite.
+func (h *RecipeHandler) Unfavorite(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.UnfavoriteRecipe(userID, c.Param("id")); err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Favorites handles GET /favorites[?page=&limit=], listing the logged-in
+// user's favorite recipes, most recently favorited first.
+func (h *RecipeHandler) Favorites(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	page, limit, ok := pageParams(c)
+	if !ok {
+		return
+	}
+	resp, err := h.service.ListFavorites(userID, page, limit)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Tags handles GET /tags[?kind=cuisine|course|diet|occasion|tag], listing
+// tags with the number of recipes using each.
+func (h *RecipeHandler) Tags(c *gin.Context) {
+	tags, err := h.service.ListTags(c.Query("kind"))
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// revisionNumber parses a revision number, writing a 400 response and
+// returning false when it is not a positive integer.
+func revisionNumber(c *gin.Context, raw string) (int, bool) {
+	number, err := strconv.Atoi(raw)
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "revision must be a positive whole number"})
+		return 0, false
+	}
+	return number, true
+}
+
+// pageParams reads the page and limit query parameters, writing a 400
+// response and returning false when they are not numbers. Missing values are
+// zero and replaced with defaults by the service.
+func pageParams(c *gin.Context) (page, limit int, ok bool) {
+	var params struct {
+		Page  int `form:"page"`
+		Limit int `form:"limit"`
+	}
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return 0, 0, false
+	}
+	return params.Page, params.Limit, true
+}
+
+// viewerID returns the authenticated user's ID, or "" for anonymous
+// requests, which only see recipes that are not private.
+func viewerID(c *gin.Context) string {
+	return c.GetString("userID")
+}
+
+// respondRecipeError maps service errors to HTTP status codes.
+func respondRecipeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidRecipe), errors.Is(err, service.ErrInvalidServings),
+		errors.Is(err, service.ErrInvalidQuery), errors.Is(err, service.ErrInvalidImage),
+		errors.Is(err, service.ErrInvalidReview):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrReviewExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrImageTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrImagesUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrRecipeForbidden), errors.Is(err, service.ErrReviewForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrRecipeNotFound), errors.Is(err, service.ErrRevisionNotFound),
+		errors.Is(err, service.ErrReviewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Main App: recipe operation failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal error"})
+	}
+}
+
+// list handles GET /recipes by reading the query from URL parameters
+// and delegating to the service layer. Structured filters are passed as
+// repeatable appliance, exclude_appliance and exclude_allergen parameters,
+// min_/max_ calories, protein, carbohydrates and fat, and max_prep_minutes,
+// max_cook_minutes and max_total_minutes; facets=true adds facet counts.
+func (h *RecipeHandler) list(c *gin.Context) {
+	var req models.RecipeQueryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
+		return
+	}
+	req.ViewerID = viewerID(c)
+	resp, err := h.service.QueryRecipes(&req)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
 // Query handles POST requests to /recipe/query.
 // It now binds JSON from the request body (instead of reading URL query parameters)
 // and forwards the {"query": "..."} payload to the resolver microservice.
+// GET requests (as routed for /recipes) list stored recipes instead.
 func (h *RecipeHandler) Query(c *gin.Context) {
+	if c.Request.Method == http.MethodGet {
+		h.list(c)
+		return
+	}
 	log.Println("Main App: Received POST /recipe/query")
 
 	// Bind the incoming JSON payload into a RecipeQueryRequest.
@@ -105,6 +737,24 @@ func (h *RecipeHandler) Query(c *gin.Context) {
 	}
 	log.Printf("Main App: Resolver returned primary: %+v, alternatives: %+v", resolverResp.PrimaryRecipe, resolverResp.AlternativeRecipes)
 
+	// Attach structured ingredients and steps unless the resolver already
+	// supplied them.
+	addStructuredFields(&resolverResp.PrimaryRecipe)
+	for i := range resolverResp.AlternativeRecipes {
+		addStructuredFields(&resolverResp.AlternativeRecipes[i])
+	}
+
 	// Return the resolver's response to the client.
 	c.JSON(http.StatusOK, resolverResp)
 }
+
+// addStructuredFields parses the recipe's ingredient lines and steps when
+// no structured form of them is present.
+func addStructuredFields(recipe *models.Recipe) {
+	if len(recipe.StructuredIngredients) == 0 {
+		recipe.StructuredIngredients = ingredients.ParseAll(recipe.Ingredients)
+	}
+	if len(recipe.StructuredSteps) == 0 {
+		steps.Annotate(recipe)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
 	recipes "github.com/pageza/recipe-book-api-v2/internal/handlers/recipes"
 	"github.com/pageza/recipe-book-api-v2/internal/models"
 	"github.com/pageza/recipe-book-api-v2/internal/repository"
@@ -30,6 +31,7 @@ import (
 var testDB *gorm.DB // our test DB connection
 var grpcClient pb.RecipeServiceClient
 var router *gin.Engine
+var testMedia *blobstore.LocalStore // uploaded images, in a temporary directory
 
 // TestMain sets up an in-memory SQLite database and spawns an in-process gRPC server.
 func TestMain(m *testing.M) {
@@ -44,12 +46,20 @@ func TestMain(m *testing.M) {
 		log.Fatalf("failed to connect to test database: %v", err)
 	}
 
-	// Auto-migrate the Recipe model.
-	if err = testDB.AutoMigrate(&models.Recipe{}); err != nil {
+	// Auto-migrate the Recipe and RecipeRevision models.
+	if err = testDB.AutoMigrate(models.All()...); err != nil {
 		log.Fatalf("failed to auto-migrate recipes table: %v", err)
 	}
 	log.Println("Auto-migration complete.")
 
+	mediaDir, err := os.MkdirTemp("", "recipe-media-")
+	if err != nil {
+		log.Fatalf("failed to create media directory: %v", err)
+	}
+	if testMedia, err = blobstore.NewLocalStore(mediaDir, "/media"); err != nil {
+		log.Fatalf("failed to open media store: %v", err)
+	}
+
 	// Start an in-process gRPC server on a random free port.
 	lis, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -96,8 +106,8 @@ func TestMain(m *testing.M) {
 	log.Println("gRPC client setup complete.")
 
 	// Initialize the router for HTTP integration tests using the local helper.
-	recipeRepo := repository.NewRecipeRepository(testDB)
-	recipeSvc := service.NewRecipeService(recipeRepo)
+	recipeRepo := repository.NewRecipeRepository(testDB, nil)
+	recipeSvc := service.NewRecipeService(recipeRepo, nil)
 	router = setupRouter(recipeSvc)
 
 	// Run tests.
@@ -105,6 +115,7 @@ func TestMain(m *testing.M) {
 
 	grpcServer.GracefulStop()
 	log.Println("gRPC server gracefully stopped.")
+	os.RemoveAll(mediaDir)
 	os.Exit(code)
 }
 
@@ -141,7 +152,7 @@ func TestQueryRecipeGRPC(t *testing.T) {
 // mockRecipeService implements recipes.RecipeService for testing.
 type mockRecipeService struct{}
 
-func (m *mockRecipeService) GetRecipe(recipeID string) (*models.Recipe, error) {
+func (m *mockRecipeService) GetRecipe(viewerID, recipeID string) (*models.Recipe, error) {
 	return &models.Recipe{
 		ID:                recipeID,
 		Title:             "Test Recipe",
@@ -193,10 +204,123 @@ func (m *mockRecipeService) QueryRecipes(req *models.RecipeQueryRequest) (*model
 	}, nil
 }
 
+func (m *mockRecipeService) ScaleRecipe(viewerID, recipeID string, servings int) (*models.Recipe, error) {
+	recipe, err := m.GetRecipe(viewerID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	recipe.Servings = servings
+	return recipe, nil
+}
+
+func (m *mockRecipeService) CreateRecipe(userID string, recipe *models.Recipe) (*models.Recipe, error) {
+	recipe.ID = "new-recipe"
+	recipe.UserID = userID
+	return recipe, nil
+}
+
+func (m *mockRecipeService) CreateRecipes(userID string, recipes []*models.Recipe) ([]*models.Recipe, error) {
+	for _, recipe := range recipes {
+		_, _ = m.CreateRecipe(userID, recipe)
+	}
+	return recipes, nil
+}
+
+func (m *mockRecipeService) UpdateRecipe(userID, recipeID string, recipe *models.Recipe) (*models.Recipe, error) {
+	recipe.ID = recipeID
+	recipe.UserID = userID
+	return recipe, nil
+}
+
+func (m *mockRecipeService) DeleteRecipe(userID, recipeID string) error {
+	return nil
+}
+
+func (m *mockRecipeService) AddRecipeImage(userID, recipeID string, data []byte) (*models.RecipeImage, error) {
+	return nil, service.ErrImagesUnavailable
+}
+
+func (m *mockRecipeService) ListTrash(userID string) ([]*models.Recipe, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) RestoreRecipe(userID, recipeID string) (*models.Recipe, error) {
+	return nil, service.ErrRecipeNotFound
+}
+
+func (m *mockRecipeService) ListRevisions(viewerID, recipeID string) ([]*models.RecipeRevision, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) GetRevision(viewerID, recipeID string, number int) (*models.RecipeRevision, error) {
+	return nil, service.ErrRevisionNotFound
+}
+
+func (m *mockRecipeService) DiffRevisions(viewerID, recipeID string, from, to int) (*models.RecipeDiff, error) {
+	return nil, service.ErrRevisionNotFound
+}
+
+func (m *mockRecipeService) RevertRecipe(userID, recipeID string, number int) (*models.Recipe, error) {
+	return nil, service.ErrRevisionNotFound
+}
+
+func (m *mockRecipeService) ForkRecipe(userID, recipeID string) (*models.Recipe, error) {
+	recipe, err := m.GetRecipe(userID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	recipe.ParentRecipeID, recipe.ID, recipe.UserID = recipe.ID, "forked-recipe", userID
+	return recipe, nil
+}
+
+func (m *mockRecipeService) ListForks(viewerID, recipeID string) ([]*models.Recipe, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) RecipeAncestry(viewerID, recipeID string) ([]*models.Recipe, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) ListTags(kind string) ([]models.TagCount, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) EstimateNutrition(viewerID, recipeID string) (*models.NutritionEstimate, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) FavoriteRecipe(userID, recipeID string) (*models.Recipe, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) UnfavoriteRecipe(userID, recipeID string) error {
+	return nil
+}
+
+func (m *mockRecipeService) ListFavorites(userID string, page, limit int) (*models.RecipeQueryResponse, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) CreateReview(userID, recipeID string, review *models.Review) (*models.Review, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) UpdateReview(userID, recipeID, reviewID string, review *models.Review) (*models.Review, error) {
+	return nil, nil
+}
+
+func (m *mockRecipeService) DeleteReview(userID, recipeID, reviewID string) error {
+	return nil
+}
+
+func (m *mockRecipeService) ListReviews(viewerID, recipeID string, page, limit int) (*models.ReviewListResponse, error) {
+	return nil, nil
+}
+
 // setupRouter initializes a Gin router with the RecipeHandler routes.
 func setupRouter(service recipes.RecipeService) *gin.Engine {
 	router := gin.Default()
-	handler := recipes.NewRecipeHandler(service)
+	handler := recipes.NewRecipeHandler(service, nil)
 	router.GET("/recipes", handler.Query)
 	router.GET("/recipes/:id", handler.Get)
 	return router
@@ -0,0 +1,869 @@
+package recipes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pageza/recipe-book-api-v2/internal/handlers/handlertest"
+	recipes "github.com/pageza/recipe-book-api-v2/internal/handlers/recipes"
+	"github.com/pageza/recipe-book-api-v2/internal/images"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProfiles maps user IDs to their stored preferences JSON.
+type fakeProfiles map[string]string
+
+func (f fakeProfiles) GetProfile(userID string) (*models.User, error) {
+	prefs, ok := f[userID]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return &models.User{ID: userID, Preferences: prefs}, nil
+}
+
+// testProfiles gives the "metric-cook" user a metric unit preference.
+var testProfiles = fakeProfiles{
+	"owner-1":     `{}`,
+	"metric-cook": `{"unit_system":"metric"}`,
+}
+
+// setupCRUDRouter registers the recipe write endpoints behind the handlertest
+// user header, which stands in for the auth middleware.
+func setupCRUDRouter() *gin.Engine {
+	r := handlertest.NewRouter()
+	repo := repository.NewRecipeRepository(testDB, testMedia)
+	handler := recipes.NewRecipeHandler(service.NewRecipeService(repo, testMedia), testProfiles)
+	shares := recipes.NewShareLinkHandler(service.NewShareLinkService(repo, []byte("share-secret")))
+	r.GET("/recipe/:id", handler.Get)
+	r.GET("/recipes", handler.Query)
+	r.POST("/recipes", handler.Create)
+	r.POST("/recipes/import", handler.Import)
+	r.PUT("/recipe/:id", handler.Update)
+	r.PATCH("/recipe/:id", handler.Patch)
+	r.DELETE("/recipe/:id", handler.Delete)
+	r.POST("/recipe/:id/images", handler.UploadImage)
+	r.GET("/media/*key", gin.WrapH(http.StripPrefix("/media", testMedia.Handler())))
+	r.GET("/trash", handler.Trash)
+	r.POST("/trash/:id/restore", handler.Restore)
+	r.GET("/recipe/:id/revisions", handler.Revisions)
+	r.GET("/recipe/:id/revisions/diff", handler.DiffRevisions)
+	r.GET("/recipe/:id/revisions/:number", handler.Revision)
+	r.POST("/recipe/:id/revisions/:number/revert", handler.Revert)
+	r.POST("/recipe/:id/fork", handler.Fork)
+	r.GET("/recipe/:id/forks", handler.Forks)
+	r.GET("/recipe/:id/ancestry", handler.Ancestry)
+	r.GET("/recipe/:id/nutrition", handler.Nutrition)
+	r.GET("/recipe/:id/export", handler.Export)
+	r.GET("/tags", handler.Tags)
+	r.POST("/recipe/:id/favorite", handler.Favorite)
+	r.DELETE("/recipe/:id/favorite", handler.Unfavorite)
+	r.GET("/favorites", handler.Favorites)
+	r.GET("/recipe/:id/reviews", handler.Reviews)
+	r.POST("/recipe/:id/reviews", handler.CreateReview)
+	r.PUT("/recipe/:id/reviews/:reviewID", handler.UpdateReview)
+	r.DELETE("/recipe/:id/reviews/:reviewID", handler.DeleteReview)
+	r.POST("/recipe/:id/share-links", shares.Create)
+	r.GET("/recipe/:id/share-links", shares.List)
+	r.DELETE("/recipe/:id/share-links/:linkID", shares.Revoke)
+	r.GET("/shared/:token", shares.Resolve)
+	return r
+}
+
+func validRecipeInput() recipes.RecipeInput {
+	return recipes.RecipeInput{
+		Title:       "Pancakes",
+		Ingredients: []string{"1 cup flour", "1 egg, beaten", "1 cup milk"},
+		Steps:       []string{"Whisk everything, then rest.", "Cook on a hot griddle."},
+		Appliances:  []string{"Stove"},
+	}
+}
+
+func TestCreateRecipeStampsOwner(t *testing.T) {
+	r := setupCRUDRouter()
+
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "owner-1", validRecipeInput())
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.ID)
+	assert.Equal(t, "owner-1", created.UserID)
+
+	// Steps containing commas must survive the round trip through storage.
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID, "owner-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var fetched models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &fetched))
+	assert.Equal(t, []string{"1 cup flour", "1 egg, beaten", "1 cup milk"}, []string(fetched.Ingredients))
+	assert.Equal(t, "owner-1", fetched.UserID)
+
+	// Structured ingredients are parsed on create and stored alongside the recipe.
+	if assert.Len(t, fetched.StructuredIngredients, 3) {
+		egg := fetched.StructuredIngredients[1]
+		assert.Equal(t, 1.0, egg.Quantity)
+		assert.Equal(t, "egg", egg.Name)
+		assert.Equal(t, "beaten", egg.Preparation)
+		assert.Equal(t, "cup", fetched.StructuredIngredients[2].Unit)
+	}
+}
+
+func TestCreateRecipeValidation(t *testing.T) {
+	r := setupCRUDRouter()
+
+	noTitle := validRecipeInput()
+	noTitle.Title = "   "
+	noIngredients := validRecipeInput()
+	noIngredients.Ingredients = nil
+	noSteps := validRecipeInput()
+	noSteps.Steps = []string{}
+	longTitle := validRecipeInput()
+	longTitle.Title = strings.Repeat("a", service.MaxRecipeTitleLength+1)
+	tooManySteps := validRecipeInput()
+	tooManySteps.Steps = make([]string, service.MaxRecipeSteps+1)
+	for i := range tooManySteps.Steps {
+		tooManySteps.Steps[i] = "stir"
+	}
+
+	cases := map[string]recipes.RecipeInput{
+		"empty title":       noTitle,
+		"no ingredients":    noIngredients,
+		"no steps":          noSteps,
+		"oversized title":   longTitle,
+		"too many steps":    tooManySteps,
+		"blank ingredient":  {Title: "x", Ingredients: []string{""}, Steps: []string{"a"}},
+		"oversized step":    {Title: "x", Ingredients: []string{"a"}, Steps: []string{strings.Repeat("s", service.MaxRecipeItemLength+1)}},
+		"missing all lists": {Title: "x"},
+	}
+	for name, input := range cases {
+		w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "owner-1", input)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+	}
+
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "", validRecipeInput())
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestUpdateAndDeleteRecipeOwnership(t *testing.T) {
+	r := setupCRUDRouter()
+
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "owner-1", validRecipeInput())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := "/recipe/" + created.ID
+
+	// Another user may not touch the recipe.
+	update := validRecipeInput()
+	update.Title = "Stolen Pancakes"
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodPut, path, "intruder", update).Code)
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodPatch, path, "intruder", map[string]string{"title": "x"}).Code)
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodDelete, path, "intruder", nil).Code)
+
+	// Unknown recipes are reported as missing.
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPut, "/recipe/does-not-exist", "owner-1", update).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPatch, "/recipe/does-not-exist", "owner-1", update).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodDelete, "/recipe/does-not-exist", "owner-1", nil).Code)
+
+	// The owner can replace the recipe.
+	update.Title = "Fluffy Pancakes"
+	w = handlertest.DoJSON(r, http.MethodPut, path, "owner-1", update)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var updated models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, "Fluffy Pancakes", updated.Title)
+	assert.Equal(t, "owner-1", updated.UserID)
+
+	// PATCH only changes the fields present in the body.
+	w = handlertest.DoJSON(r, http.MethodPatch, path, "owner-1", map[string]string{"allergy_disclaimer": "Contains egg and milk."})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var patched models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &patched))
+	assert.Equal(t, "Fluffy Pancakes", patched.Title)
+	assert.Equal(t, "Contains egg and milk.", patched.AllergyDisclaimer)
+	assert.Len(t, patched.Ingredients, 3)
+
+	// PATCH still validates the merged result.
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPatch, path, "owner-1", map[string]string{"title": ""}).Code)
+
+	// The owner can delete it, after which it is gone.
+	assert.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, path, "owner-1", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, path, "owner-1", nil).Code)
+}
+
+func TestDeleteMovesRecipeToTrash(t *testing.T) {
+	r := setupCRUDRouter()
+
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "trash-owner", validRecipeInput())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, "/recipe/"+created.ID, "trash-owner", nil).Code)
+
+	// The recipe is gone from listings but waits in the owner's trash.
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=trash-owner", "trash-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), created.ID)
+	var trash struct {
+		Recipes []models.Recipe `json:"recipes"`
+	}
+	w = handlertest.DoJSON(r, http.MethodGet, "/trash", "trash-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &trash))
+	if assert.Len(t, trash.Recipes, 1) {
+		assert.Equal(t, created.ID, trash.Recipes[0].ID)
+		assert.True(t, trash.Recipes[0].DeletedAt.Valid)
+	}
+	w = handlertest.DoJSON(r, http.MethodGet, "/trash", "trash-intruder", nil)
+	assert.JSONEq(t, `{"recipes":[]}`, w.Body.String())
+
+	// Only the owner can restore it, and only once.
+	restorePath := "/trash/" + created.ID + "/restore"
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodPost, restorePath, "trash-intruder", nil).Code)
+	w = handlertest.DoJSON(r, http.MethodPost, restorePath, "trash-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusOK, handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID, "trash-owner", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPost, restorePath, "trash-owner", nil).Code)
+}
+
+func TestPrivateRecipesAndShareLinks(t *testing.T) {
+	r := setupCRUDRouter()
+
+	input := validRecipeInput()
+	input.Visibility = models.VisibilityPrivate
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "share-owner", input)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, models.VisibilityPrivate, created.Visibility)
+
+	// Other users can neither open nor list the private recipe.
+	assert.Equal(t, http.StatusOK, handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID, "share-owner", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID, "share-stranger", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID+"/revisions", "share-stranger", nil).Code)
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=share-owner", "share-stranger", nil)
+	assert.NotContains(t, w.Body.String(), created.ID)
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=share-owner", "share-owner", nil)
+	assert.Contains(t, w.Body.String(), created.ID)
+
+	// The owner mints a link that opens the recipe without authentication.
+	linksPath := "/recipe/" + created.ID + "/share-links"
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPost, linksPath, "share-stranger", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPost, linksPath, "share-owner", gin.H{"expires_in": "soon"}).Code)
+	w = handlertest.DoJSON(r, http.MethodPost, linksPath, "share-owner", gin.H{"expires_in": "48h"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var link models.ShareLink
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &link))
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), link.ExpiresAt, time.Minute)
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/shared/"+link.Token, "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), created.ID)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, "/shared/"+link.Token+"x", "", nil).Code)
+
+	w = handlertest.DoJSON(r, http.MethodGet, linksPath, "share-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), link.ID)
+
+	// Revoked links stop working.
+	assert.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, linksPath+"/"+link.ID, "share-owner", nil).Code)
+	assert.Equal(t, http.StatusGone, handlertest.DoJSON(r, http.MethodGet, "/shared/"+link.Token, "", nil).Code)
+}
+
+func TestFavorites(t *testing.T) {
+	r := setupCRUDRouter()
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "fav-author", validRecipeInput())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	favoritePath := "/recipe/" + created.ID + "/favorite"
+
+	assert.Equal(t, http.StatusUnauthorized, handlertest.DoJSON(r, http.MethodPost, favoritePath, "", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPost, "/recipe/missing/favorite", "fav-reader", nil).Code)
+	w = handlertest.DoJSON(r, http.MethodPost, favoritePath, "fav-reader", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var favorited models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &favorited))
+	assert.True(t, favorited.IsFavorited)
+	assert.Equal(t, 1, favorited.FavoriteCount)
+
+	// Listings flag the viewer's favorites.
+	var listed models.RecipeQueryResponse
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=fav-author", "fav-reader", nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	if assert.Len(t, listed.Recipes, 1) {
+		assert.True(t, listed.Recipes[0].IsFavorited)
+		assert.Equal(t, 1, listed.Recipes[0].FavoriteCount)
+	}
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=fav-author", "fav-author", nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	if assert.Len(t, listed.Recipes, 1) {
+		assert.False(t, listed.Recipes[0].IsFavorited)
+	}
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/favorites?limit=5", "fav-reader", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Equal(t, 1, listed.Total)
+	assert.Equal(t, 5, listed.Limit)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodGet, "/favorites?page=first", "fav-reader", nil).Code)
+
+	assert.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, favoritePath, "fav-reader", nil).Code)
+	assert.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, favoritePath, "fav-reader", nil).Code)
+	w = handlertest.DoJSON(r, http.MethodGet, "/favorites", "fav-reader", nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Empty(t, listed.Recipes)
+}
+
+func TestReviews(t *testing.T) {
+	r := setupCRUDRouter()
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "review-author", validRecipeInput())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	reviewsPath := "/recipe/" + created.ID + "/reviews"
+
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPost, reviewsPath, "review-critic", gin.H{"rating": 6}).Code)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPost, reviewsPath, "review-author", gin.H{"rating": 5}).Code)
+	w = handlertest.DoJSON(r, http.MethodPost, reviewsPath, "review-critic", gin.H{"rating": 2, "body": "Too salty."})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var review models.Review
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+	assert.Equal(t, http.StatusConflict, handlertest.DoJSON(r, http.MethodPost, reviewsPath, "review-critic", gin.H{"rating": 3}).Code)
+	assert.Equal(t, http.StatusCreated, handlertest.DoJSON(r, http.MethodPost, reviewsPath, "review-fan", gin.H{"rating": 5}).Code)
+
+	reviewPath := reviewsPath + "/" + review.ID
+	assert.Equal(t, http.StatusForbidden, handlertest.DoJSON(r, http.MethodPut, reviewPath, "review-fan", gin.H{"rating": 1}).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodPut, reviewsPath+"/missing", "review-critic", gin.H{"rating": 1}).Code)
+	w = handlertest.DoJSON(r, http.MethodPut, reviewPath, "review-critic", gin.H{"rating": 4, "body": "Better with less salt."})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = handlertest.DoJSON(r, http.MethodGet, reviewsPath+"?limit=1", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listed models.ReviewListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Equal(t, 2, listed.Total)
+	assert.Len(t, listed.Reviews, 1)
+	assert.Equal(t, 4.5, listed.Summary.Average)
+	assert.Equal(t, models.RatingHistogram{Four: 1, Five: 1}, listed.Summary.Histogram)
+
+	assert.Equal(t, http.StatusNoContent, handlertest.DoJSON(r, http.MethodDelete, reviewPath, "review-critic", nil).Code)
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID, "", nil)
+	var rated models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &rated))
+	assert.Equal(t, 1, rated.Rating.Count)
+	assert.Equal(t, 5.0, rated.Rating.Average)
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=review-author&sort=rating", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), created.ID)
+}
+
+// uploadImage posts data as the "image" field of a multipart form.
+func uploadImage(r *gin.Engine, recipeID, userID string, data []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("image", "photo.png")
+	_, _ = part.Write(data)
+	_ = mw.Close()
+	req := httptest.NewRequest(http.MethodPost, "/recipe/"+recipeID+"/images", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set(handlertest.UserHeader, userID)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestUploadRecipeImage(t *testing.T) {
+	r := setupCRUDRouter()
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "photographer", validRecipeInput())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	var photo bytes.Buffer
+	assert.NoError(t, png.Encode(&photo, image.NewRGBA(image.Rect(0, 0, 640, 480))))
+
+	assert.Equal(t, http.StatusForbidden, uploadImage(r, created.ID, "someone-else", photo.Bytes()).Code)
+	assert.Equal(t, http.StatusBadRequest, uploadImage(r, created.ID, "photographer", []byte("GIF? no, text")).Code)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodPost, "/recipe/"+created.ID+"/images", "photographer", nil).Code, "no file")
+	oversized := append(photo.Bytes(), make([]byte, images.MaxUploadSize)...)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, uploadImage(r, created.ID, "photographer", oversized).Code)
+
+	w = uploadImage(r, created.ID, "photographer", photo.Bytes())
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var uploaded models.RecipeImage
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+	assert.Equal(t, "image/png", uploaded.ContentType)
+	assert.Equal(t, [2]int{640, 480}, [2]int{uploaded.Width, uploaded.Height})
+	assert.Equal(t, "/media/recipes/"+created.ID+"/"+uploaded.ID+"/original.png", uploaded.URL)
+	if assert.Len(t, uploaded.Thumbnails, 2) {
+		assert.Equal(t, models.ImageVariant{
+			Name: "small", Width: 320, Height: 240,
+			URL: "/media/recipes/" + created.ID + "/" + uploaded.ID + "/small.png",
+		}, uploaded.Thumbnails[0])
+	}
+
+	// The stored files are served at their URLs.
+	w = handlertest.DoJSON(r, http.MethodGet, uploaded.Thumbnails[0].URL, "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	thumb, err := png.DecodeConfig(w.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, 320, thumb.Width)
+
+	// Recipe responses list the images.
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID, "photographer", nil)
+	var got models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	if assert.Len(t, got.Images, 1) {
+		assert.Equal(t, uploaded.URL, got.Images[0].URL)
+	}
+}
+
+func TestGetRecipeScaledToServings(t *testing.T) {
+	r := setupCRUDRouter()
+
+	input := validRecipeInput()
+	input.Servings = 2
+	input.NutritionalInfo = models.NutritionalInfo{Calories: 250}
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "owner-1", input)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := "/recipe/" + created.ID
+
+	w = handlertest.DoJSON(r, http.MethodGet, path+"?servings=3", "owner-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var scaled models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &scaled))
+	assert.Equal(t, 3, scaled.Servings)
+	assert.Equal(t, []string{"1 1/2 cups flour", "1 1/2 egg, beaten", "1 1/2 cups milk"}, []string(scaled.Ingredients))
+	assert.Equal(t, 250.0, scaled.NutritionalInfo.Calories)
+	if assert.NotNil(t, scaled.TotalNutritionalInfo) {
+		assert.Equal(t, 750.0, scaled.TotalNutritionalInfo.Calories)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodGet, path+"?servings=two", "owner-1", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodGet, path+"?servings=0", "owner-1", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, "/recipe/does-not-exist?servings=2", "owner-1", nil).Code)
+}
+
+func TestGetRecipeInPreferredUnits(t *testing.T) {
+	r := setupCRUDRouter()
+
+	input := validRecipeInput()
+	input.Ingredients = []string{"2 cups flour", "1 cup milk", "2 eggs", "1 tbsp sugar"}
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "owner-1", input)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := "/recipe/" + created.ID
+
+	// Without a preference, quantities are shown as written.
+	var original models.Recipe
+	w = handlertest.DoJSON(r, http.MethodGet, path, "owner-1", nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &original))
+	assert.Equal(t, input.Ingredients, []string(original.Ingredients))
+
+	// A metric preference weighs dry goods and measures liquids in milliliters.
+	var metric models.Recipe
+	w = handlertest.DoJSON(r, http.MethodGet, path, "metric-cook", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &metric))
+	assert.Equal(t, []string{"251 grams flour", "237 milliliters milk", "2 eggs", "1 tbsp sugar"}, []string(metric.Ingredients))
+
+	// The units query parameter overrides the stored preference.
+	var asWritten models.Recipe
+	w = handlertest.DoJSON(r, http.MethodGet, path+"?units=original", "metric-cook", nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &asWritten))
+	assert.Equal(t, input.Ingredients, []string(asWritten.Ingredients))
+
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodGet, path+"?units=cubits", "owner-1", nil).Code)
+}
+
+func TestListRecipesWithFiltersAndFacets(t *testing.T) {
+	r := setupCRUDRouter()
+
+	light := validRecipeInput()
+	light.Title = "Light Salad"
+	light.Ingredients = []string{"2 cups lettuce", "1 tomato"}
+	light.Appliances = []string{"Bowl"}
+	light.NutritionalInfo = models.NutritionalInfo{Calories: 320}
+	baked := validRecipeInput()
+	baked.Title = "Baked Ziti"
+	baked.Appliances = []string{"Oven"}
+	baked.AllergyDisclaimer = "Contains gluten."
+	baked.NutritionalInfo = models.NutritionalInfo{Calories: 720}
+	for _, input := range []recipes.RecipeInput{light, baked} {
+		assert.Equal(t, http.StatusCreated, handlertest.DoJSON(r, http.MethodPost, "/recipes", "facet-owner", input).Code)
+	}
+
+	w := handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=facet-owner&exclude_appliance=oven&max_calories=500&facets=true", "facet-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp models.RecipeQueryResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Recipes, 1) {
+		assert.Equal(t, "Light Salad", resp.Recipes[0].Title)
+	}
+	if assert.NotNil(t, resp.Facets) {
+		assert.Equal(t, map[string]int{"bowl": 1}, resp.Facets.Appliances)
+		assert.Equal(t, 1, resp.Facets.Calories[1].Count)
+	}
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=facet-owner&exclude_allergen=gluten", "facet-owner", nil)
+	var unfaceted models.RecipeQueryResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &unfaceted))
+	assert.Equal(t, 1, unfaceted.Total)
+	assert.Nil(t, unfaceted.Facets, "facets are only computed on request")
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?min_calories=500&max_calories=100", "facet-owner", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeTagsFilterAndCounts(t *testing.T) {
+	r := setupCRUDRouter()
+
+	pasta := validRecipeInput()
+	pasta.Title = "Cacio e Pepe"
+	pasta.Tags = []string{"cuisine:italian", "course:Main", "tag-browser"}
+	curry := validRecipeInput()
+	curry.Title = "Chana Masala"
+	curry.Tags = []string{"cuisine:Indian", "diet:Vegan", "tag-browser"}
+	var created []models.Recipe
+	for _, input := range []recipes.RecipeInput{pasta, curry} {
+		w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "tag-owner", input)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var recipe models.Recipe
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &recipe))
+		created = append(created, recipe)
+	}
+	if assert.Len(t, created[0].Tags, 3) {
+		assert.Equal(t, models.Tag{ID: "cuisine:italian", Kind: "cuisine", Slug: "italian", Name: "Italian"}, created[0].Tags[1])
+	}
+
+	w := handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=tag-owner&tag=cuisine:Italian", "tag-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp models.RecipeQueryResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Recipes, 1) {
+		assert.Equal(t, "Cacio e Pepe", resp.Recipes[0].Title)
+		assert.Len(t, resp.Recipes[0].Tags, 3)
+	}
+
+	// PATCH keeps the tags unless they are sent.
+	w = handlertest.DoJSON(r, http.MethodPatch, "/recipe/"+created[1].ID, "tag-owner", map[string]interface{}{"servings": 4})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=tag-owner&tag=tag-browser&tag=diet:vegan", "tag-owner", nil)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Total)
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/tags?kind=tag", "tag-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var tags struct {
+		Tags []models.TagCount `json:"tags"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tags))
+	found := false
+	for _, tag := range tags.Tags {
+		if tag.ID == "tag:tag-browser" {
+			found = true
+			assert.Equal(t, 2, tag.RecipeCount)
+		}
+	}
+	assert.True(t, found, "user tag is listed")
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/tags?kind=flavour", "tag-owner", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	invalid := validRecipeInput()
+	invalid.Tags = []string{"course:Second Breakfast"}
+	w = handlertest.DoJSON(r, http.MethodPost, "/recipes", "tag-owner", invalid)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeNutritionIsCalculated(t *testing.T) {
+	r := setupCRUDRouter()
+
+	input := validRecipeInput()
+	input.Servings = 2
+	input.Ingredients = append(input.Ingredients, "salt, to taste")
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "nutrition-owner", input)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID+"/nutrition", "nutrition-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var estimate models.NutritionEstimate
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &estimate))
+	assert.Len(t, estimate.Ingredients, 3)
+	assert.Equal(t, []models.UnmatchedIngredient{{Raw: "salt, to taste", Reason: "to taste"}}, estimate.Unmatched)
+	assert.True(t, estimate.Complete)
+	assert.Greater(t, estimate.PerServing.Calories, 0.0)
+	assert.Equal(t, estimate.PerServing, created.NutritionalInfo, "blank nutrition is calculated on save")
+
+	// Nutrition sent by the client is kept.
+	input.NutritionalInfo = models.NutritionalInfo{Calories: 123}
+	w = handlertest.DoJSON(r, http.MethodPut, "/recipe/"+created.ID, "nutrition-owner", input)
+	var updated models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, models.NutritionalInfo{Calories: 123}, updated.NutritionalInfo)
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/missing/nutrition", "nutrition-owner", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRecipeExport(t *testing.T) {
+	r := setupCRUDRouter()
+
+	input := validRecipeInput()
+	input.Title = "Export <Test> Pancakes"
+	input.Visibility = models.VisibilityPrivate
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "export-owner", input)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	path := "/recipe/" + created.ID + "/export"
+
+	w = handlertest.DoJSON(r, http.MethodGet, path+"?format=md", "export-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `inline; filename=export-test-pancakes.md`, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), "# Export \\<Test\\> Pancakes\n")
+	assert.Contains(t, w.Body.String(), "1. "+input.Steps[0])
+
+	w = handlertest.DoJSON(r, http.MethodGet, path+"?format=html&download=true", "export-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename=export-test-pancakes.html`, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), "<h1>Export &lt;Test&gt; Pancakes</h1>")
+
+	w = handlertest.DoJSON(r, http.MethodGet, path, "export-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code, "PDF is the default")
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")))
+
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodGet, path+"?format=docx", "export-owner", nil).Code)
+	assert.Equal(t, http.StatusNotFound, handlertest.DoJSON(r, http.MethodGet, path+"?format=md", "export-stranger", nil).Code)
+}
+
+func TestListRecipesWithCursor(t *testing.T) {
+	r := setupCRUDRouter()
+
+	for _, title := range []string{"Cursor C", "Cursor A", "Cursor B"} {
+		input := validRecipeInput()
+		input.Title = title
+		assert.Equal(t, http.StatusCreated, handlertest.DoJSON(r, http.MethodPost, "/recipes", "cursor-owner", input).Code)
+	}
+
+	var first models.RecipeQueryResponse
+	w := handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=cursor-owner&sort=title&limit=2", "cursor-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+	assert.Equal(t, 3, first.Total)
+	if assert.Len(t, first.Recipes, 2) {
+		assert.Equal(t, "Cursor A", first.Recipes[0].Title)
+	}
+	assert.NotEmpty(t, first.NextCursor)
+
+	var second models.RecipeQueryResponse
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=cursor-owner&sort=title&limit=2&total=none&cursor="+first.NextCursor, "cursor-owner", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+	if assert.Len(t, second.Recipes, 1) {
+		assert.Equal(t, "Cursor C", second.Recipes[0].Title)
+	}
+	assert.Empty(t, second.NextCursor)
+	assert.NotEmpty(t, second.PrevCursor)
+	assert.Equal(t, models.TotalNone, second.TotalMode)
+
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodGet, "/recipes?cursor=garbage", "cursor-owner", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, handlertest.DoJSON(r, http.MethodGet, "/recipes?sort=spiciest", "cursor-owner", nil).Code)
+}
+
+func TestRecipeRevisionHistoryAndRevert(t *testing.T) {
+	r := setupCRUDRouter()
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "owner-1", validRecipeInput())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created models.Recipe
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+
+	edit := validRecipeInput()
+	edit.Steps = []string{"Whisk everything, then rest.", "Cook on a buttered griddle."}
+	w = handlertest.DoJSON(r, http.MethodPut, "/recipe/"+created.ID, "owner-1", edit)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID+"/revisions", "someone-else", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Revisions []models.RecipeRevision `json:"revisions"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if assert.Len(t, list.Revisions, 2) {
+		assert.Equal(t, 2, list.Revisions[0].Number)
+		assert.Equal(t, "owner-1", list.Revisions[0].UserID)
+		assert.Equal(t, models.StringArray{"steps"}, list.Revisions[0].ChangedFields)
+	}
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID+"/revisions/diff?from=1&to=2", "owner-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var diff models.RecipeDiff
+	_ = json.Unmarshal(w.Body.Bytes(), &diff)
+	assert.Equal(t, []models.ListChange{{Index: 1, From: "Cook on a hot griddle.", To: "Cook on a buttered griddle."}}, diff.Steps.Changed)
+	assert.Nil(t, diff.Title)
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID+"/revisions/7", "owner-1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID+"/revisions/first", "owner-1", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = handlertest.DoJSON(r, http.MethodPost, "/recipe/"+created.ID+"/revisions/1/revert", "someone-else", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = handlertest.DoJSON(r, http.MethodPost, "/recipe/"+created.ID+"/revisions/1/revert", "owner-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var reverted models.Recipe
+	_ = json.Unmarshal(w.Body.Bytes(), &reverted)
+	assert.Equal(t, validRecipeInput().Steps, []string(reverted.Steps))
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID+"/revisions/3", "owner-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var rev models.RecipeRevision
+	_ = json.Unmarshal(w.Body.Bytes(), &rev)
+	assert.Equal(t, models.RevisionRevert, rev.Action)
+	assert.Equal(t, 1, rev.RevertedFrom)
+}
+
+func TestForkRecipeLineage(t *testing.T) {
+	r := setupCRUDRouter()
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "owner-1", validRecipeInput())
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var original models.Recipe
+	_ = json.Unmarshal(w.Body.Bytes(), &original)
+
+	w = handlertest.DoJSON(r, http.MethodPost, "/recipe/"+original.ID+"/fork", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = handlertest.DoJSON(r, http.MethodPost, "/recipe/missing/fork", "forker-1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = handlertest.DoJSON(r, http.MethodPost, "/recipe/"+original.ID+"/fork", "forker-1", nil)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var fork models.Recipe
+	_ = json.Unmarshal(w.Body.Bytes(), &fork)
+	assert.Equal(t, "forker-1", fork.UserID)
+	assert.Equal(t, original.ID, fork.ParentRecipeID)
+	assert.Equal(t, "owner-1", fork.OriginalUserID)
+
+	// The fork belongs to its new owner, not the original author.
+	edit := validRecipeInput()
+	edit.Title = "Buttermilk Pancakes"
+	w = handlertest.DoJSON(r, http.MethodPut, "/recipe/"+fork.ID, "owner-1", edit)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = handlertest.DoJSON(r, http.MethodPut, "/recipe/"+fork.ID, "forker-1", edit)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+original.ID+"/forks", "owner-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var forks struct {
+		Forks []models.Recipe `json:"forks"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &forks)
+	if assert.Len(t, forks.Forks, 1) {
+		assert.Equal(t, "Buttermilk Pancakes", forks.Forks[0].Title)
+	}
+
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+fork.ID+"/ancestry", "owner-1", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ancestry struct {
+		Ancestry []models.Recipe `json:"ancestry"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &ancestry)
+	if assert.Len(t, ancestry.Ancestry, 1) {
+		assert.Equal(t, original.ID, ancestry.Ancestry[0].ID)
+		assert.Equal(t, "Pancakes", ancestry.Ancestry[0].Title)
+	}
+}
+
+func TestGetRecipeAsJSONLD(t *testing.T) {
+	r := setupCRUDRouter()
+	input := validRecipeInput()
+	input.Servings = 2
+	w := handlertest.DoJSON(r, http.MethodPost, "/recipes", "owner-1", input)
+	var created models.Recipe
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+
+	req := httptest.NewRequest(http.MethodGet, "/recipe/"+created.ID, nil)
+	req.Header.Set("Accept", "application/ld+json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/ld+json", w.Header().Get("Content-Type"))
+	var doc map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &doc)
+	assert.Equal(t, "Recipe", doc["@type"])
+	assert.Equal(t, "Pancakes", doc["name"])
+	assert.Equal(t, "2 servings", doc["recipeYield"])
+	assert.Len(t, doc["recipeInstructions"], 2)
+
+	// Plain JSON stays the default.
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipe/"+created.ID, "owner-1", nil)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
+
+func TestImportJSONLD(t *testing.T) {
+	r := setupCRUDRouter()
+	doc := `{"@context": "https://schema.org", "@type": "Recipe", "name": "Imported Soup",
+		"recipeIngredient": ["1 onion", "4 cups stock"],
+		"recipeInstructions": [{"@type": "HowToStep", "text": "Simmer."}],
+		"recipeYield": "4", "tool": ["Stockpot"]}`
+	send := func(body, contentType, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/recipes/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		if userID != "" {
+			req.Header.Set(handlertest.UserHeader, userID)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, send(doc, "application/ld+json", "").Code)
+	w := send(doc, "application/ld+json", "importer-1")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp struct {
+		Recipes []models.Recipe `json:"recipes"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if assert.Len(t, resp.Recipes, 1) {
+		assert.Equal(t, "importer-1", resp.Recipes[0].UserID)
+		assert.Equal(t, 4, resp.Recipes[0].Servings)
+		assert.Equal(t, models.StringArray{"Stockpot"}, resp.Recipes[0].Appliances)
+	}
+
+	// HTML pages can be uploaded as a file.
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, _ := mw.CreateFormFile("file", "soup.html")
+	_, _ = part.Write([]byte(`<html><head><script type="application/ld+json">` + doc + `</script></head></html>`))
+	_ = mw.Close()
+	w = send(body.String(), mw.FormDataContentType(), "importer-1")
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Documents without recipes, or with invalid ones, store nothing.
+	assert.Equal(t, http.StatusBadRequest, send(`{"@type": "Person"}`, "application/ld+json", "importer-1").Code)
+	invalid := `[` + doc + `, {"@type": "Recipe", "name": "No Steps", "recipeIngredient": ["1 egg"]}]`
+	w = send(invalid, "application/ld+json", "importer-2")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "recipe 2")
+	w = handlertest.DoJSON(r, http.MethodGet, "/recipes?user_id=importer-2", "importer-2", nil)
+	assert.Contains(t, w.Body.String(), `"total":0`)
+}
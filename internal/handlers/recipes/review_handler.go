@@ -0,0 +1,89 @@
+package recipes
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pageza/recipe-book-api-v2/internal/middleware"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// ReviewInput is the request body accepted when writing or editing a review.
+type ReviewInput struct {
+	Rating int    `json:"rating"` // 1 to 5 stars
+	Body   string `json:"body"`   // optional review text
+}
+
+// toModel converts the input payload into a review model.
+func (in *ReviewInput) toModel() *models.Review {
+	return &models.Review{Rating: in.Rating, Body: in.Body}
+}
+
+// CreateReview handles POST /recipe/:id/reviews, rating and reviewing the
+// recipe as the logged-in user. Each user reviews a recipe once and edits
+// that review afterwards.
+func (h *RecipeHandler) CreateReview(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input ReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	review, err := h.service.CreateReview(userID, c.Param("id"), input.toModel())
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, review)
+}
+
+// UpdateReview handles PUT /recipe/:id/reviews/:reviewID, replacing the
+// rating and text of the logged-in user's review.
+func (h *RecipeHandler) UpdateReview(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input ReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	review, err := h.service.UpdateReview(userID, c.Param("id"), c.Param("reviewID"), input.toModel())
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// DeleteReview handles DELETE /recipe/:id/reviews/:reviewID.
+func (h *RecipeHandler) DeleteReview(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteReview(userID, c.Param("id"), c.Param("reviewID")); err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Reviews handles GET /recipe/:id/reviews[?page=&limit=], listing the
+// recipe's reviews newest first with its rating summary.
+func (h *RecipeHandler) Reviews(c *gin.Context) {
+	page, limit, ok := pageParams(c)
+	if !ok {
+		return
+	}
+	resp, err := h.service.ListReviews(viewerID(c), c.Param("id"), page, limit)
+	if err != nil {
+		respondRecipeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
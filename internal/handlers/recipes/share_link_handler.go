@@ -0,0 +1,128 @@
+package recipes
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pageza/recipe-book-api-v2/internal/middleware"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+)
+
+// ShareLinkService defines the share link operations used by ShareLinkHandler.
+type ShareLinkService interface {
+	// CreateShareLink creates a link to a recipe owned by userID that stays valid for ttl.
+	CreateShareLink(userID, recipeID string, ttl time.Duration) (*models.ShareLink, error)
+	// ListShareLinks returns the links created for a recipe owned by userID.
+	ListShareLinks(userID, recipeID string) ([]*models.ShareLink, error)
+	// RevokeShareLink disables a link to a recipe owned by userID.
+	RevokeShareLink(userID, recipeID, linkID string) error
+	// ResolveShareLink returns the recipe a share token grants access to.
+	ResolveShareLink(token string) (*models.Recipe, error)
+}
+
+// ShareLinkInput is the request body accepted when creating a share link.
+type ShareLinkInput struct {
+	// ExpiresIn is the link lifetime as a Go duration, e.g. "72h"; empty
+	// selects the default of a week.
+	ExpiresIn string `json:"expires_in"`
+}
+
+// ShareLinkHandler handles HTTP requests for recipe share links.
+type ShareLinkHandler struct {
+	service ShareLinkService
+}
+
+// NewShareLinkHandler constructs a ShareLinkHandler with the given ShareLinkService.
+func NewShareLinkHandler(service ShareLinkService) *ShareLinkHandler {
+	return &ShareLinkHandler{service: service}
+}
+
+// Create handles POST /recipe/:id/share-links, minting a link to a recipe
+// owned by the authenticated user. The response carries the token to append
+// to /shared/.
+func (h *ShareLinkHandler) Create(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	var input ShareLinkInput
+	// An empty body selects the defaults.
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			return
+		}
+	}
+	var ttl time.Duration
+	if input.ExpiresIn != "" {
+		var err error
+		if ttl, err = time.ParseDuration(input.ExpiresIn); err != nil || ttl <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expires_in must be a positive duration such as 72h"})
+			return
+		}
+	}
+	link, err := h.service.CreateShareLink(userID, c.Param("id"), ttl)
+	if err != nil {
+		respondShareLinkError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, link)
+}
+
+// List handles GET /recipe/:id/share-links, listing the links of a recipe
+// owned by the authenticated user, newest first.
+func (h *ShareLinkHandler) List(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	links, err := h.service.ListShareLinks(userID, c.Param("id"))
+	if err != nil {
+		respondShareLinkError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"share_links": links})
+}
+
+// Revoke handles DELETE /recipe/:id/share-links/:linkID.
+func (h *ShareLinkHandler) Revoke(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.RevokeShareLink(userID, c.Param("id"), c.Param("linkID")); err != nil {
+		respondShareLinkError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Resolve handles GET /shared/:token, showing the shared recipe to anyone
+// holding a valid token, without authentication.
+func (h *ShareLinkHandler) Resolve(c *gin.Context) {
+	recipe, err := h.service.ResolveShareLink(c.Param("token"))
+	if err != nil {
+		respondShareLinkError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "private, no-store")
+	c.JSON(http.StatusOK, recipe)
+}
+
+// respondShareLinkError maps share link errors to HTTP status codes and
+// defers everything else to respondRecipeError.
+func respondShareLinkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidShareLink):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrShareLinkNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrShareLinkExpired):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		respondRecipeError(c, err)
+	}
+}
@@ -0,0 +1,162 @@
+// Package images validates uploaded photos and prepares them for storage:
+// the content type is sniffed from the bytes, oversized images are rejected,
+// metadata such as EXIF is dropped by re-encoding, and resized thumbnails
+// are generated. Only the standard library codecs are used.
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	_ "image/gif" // register the GIF decoder
+)
+
+// Limits applied to uploaded images.
+const (
+	// MaxUploadSize is the largest accepted file, in bytes.
+	MaxUploadSize = 10 << 20
+	// MaxPixels bounds width × height so small files cannot decode into huge bitmaps.
+	MaxPixels = 24_000_000
+	// MaxDimension is the longest side of the stored full-size image; larger
+	// uploads are scaled down.
+	MaxDimension = 2048
+)
+
+// jpegQuality is used when re-encoding JPEG images.
+const jpegQuality = 85
+
+var (
+	// ErrUnsupportedType is returned for files that are not JPEG, PNG or GIF images.
+	ErrUnsupportedType = errors.New("unsupported image type")
+	// ErrTooLarge is returned for files or bitmaps over the upload limits.
+	ErrTooLarge = errors.New("image too large")
+	// ErrInvalidImage is returned for files that cannot be decoded.
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// ThumbnailSize names a thumbnail and the length of its longest side.
+type ThumbnailSize struct {
+	Name         string
+	MaxDimension int
+}
+
+// ThumbnailSizes lists the thumbnails generated for every upload, smallest first.
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "small", MaxDimension: 320},
+	{Name: "medium", MaxDimension: 1024},
+}
+
+// Variant is one encoded rendition of an uploaded image.
+type Variant struct {
+	Name   string // "original" or a ThumbnailSize name
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// Processed is an upload ready to be stored.
+type Processed struct {
+	ContentType string // of every variant: image/jpeg, or image/png for PNG and GIF uploads
+	Extension   string // file extension matching ContentType, with the dot
+	Original    Variant
+	Thumbnails  []Variant // in ThumbnailSizes order
+}
+
+// decodable maps sniffed content types to the format image.Decode reports.
+var decodable = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// Process validates an uploaded image and renders the stored variants. The
+// content type is sniffed rather than trusted from the client. JPEG EXIF
+// orientation is applied to the pixels before the metadata is discarded, so
+// photos keep facing the right way up. Images are never scaled up.
+func Process(data []byte) (*Processed, error) {
+	if len(data) > MaxUploadSize {
+		return nil, fmt.Errorf("%w: %d bytes exceeds %d", ErrTooLarge, len(data), MaxUploadSize)
+	}
+	contentType := http.DetectContentType(data)
+	format, ok := decodable[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+	cfg, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decoded != format {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width < 1 || cfg.Height < 1 {
+		return nil, fmt.Errorf("%w: empty image", ErrInvalidImage)
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d exceeds %d pixels", ErrTooLarge, cfg.Width, cfg.Height, MaxPixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = exifOrientation(data)
+	}
+	src := toRGBA(img)
+	out := &Processed{ContentType: "image/png", Extension: ".png"}
+	if format == "jpeg" {
+		out.ContentType, out.Extension = "image/jpeg", ".jpg"
+	}
+	render := func(name string, maxDim int) (Variant, error) {
+		w, h := fit(src.Bounds().Dx(), src.Bounds().Dy(), maxDim)
+		scaled := orient(resize(src, w, h), orientation)
+		var buf bytes.Buffer
+		var err error
+		if format == "jpeg" {
+			err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: jpegQuality})
+		} else {
+			err = png.Encode(&buf, scaled)
+		}
+		b := scaled.Bounds()
+		return Variant{Name: name, Width: b.Dx(), Height: b.Dy(), Data: buf.Bytes()}, err
+	}
+	if out.Original, err = render("original", MaxDimension); err != nil {
+		return nil, err
+	}
+	for _, size := range ThumbnailSizes {
+		thumb, err := render(size.Name, size.MaxDimension)
+		if err != nil {
+			return nil, err
+		}
+		out.Thumbnails = append(out.Thumbnails, thumb)
+	}
+	return out, nil
+}
+
+// fit returns the size of a w×h image scaled down, keeping its aspect ratio,
+// so neither side exceeds maxDim.
+func fit(w, h, maxDim int) (int, int) {
+	if w <= maxDim && h <= maxDim {
+		return w, h
+	}
+	if w >= h {
+		return maxDim, max(1, (h*maxDim+w/2)/w)
+	}
+	return max(1, (w*maxDim+h/2)/h), maxDim
+}
+
+// toRGBA converts img to an RGBA bitmap whose bounds start at the origin.
+func toRGBA(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	if rgba, ok := img.(*image.RGBA); ok && b.Min == (image.Point{}) {
+		return rgba
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,113 @@
+package images_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pageza/recipe-book-api-v2/internal/images"
+)
+
+// testImage is w×h, red in its left half and blue in its right half.
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF segment holding the given orientation
+// right after the JPEG start-of-image marker.
+func withOrientation(jpg []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], 0x0112)
+	binary.BigEndian.PutUint16(entry[2:], 3)
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	payload := append(append([]byte("Exif\x00\x00"), tiff...), entry...)
+	payload = append(payload, 0, 0, 0, 0) // no next IFD
+
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+	return append(append(append([]byte(nil), jpg[:2]...), segment...), jpg[2:]...)
+}
+
+func TestProcessPNGMakesThumbnails(t *testing.T) {
+	out, err := images.Process(encodePNG(t, testImage(1600, 800)))
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", out.ContentType)
+	assert.Equal(t, ".png", out.Extension)
+	assert.Equal(t, [2]int{1600, 800}, [2]int{out.Original.Width, out.Original.Height})
+	require.Len(t, out.Thumbnails, 2)
+	assert.Equal(t, [2]int{320, 160}, [2]int{out.Thumbnails[0].Width, out.Thumbnails[0].Height})
+	assert.Equal(t, [2]int{1024, 512}, [2]int{out.Thumbnails[1].Width, out.Thumbnails[1].Height})
+
+	small, err := png.Decode(bytes.NewReader(out.Thumbnails[0].Data))
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, small.At(10, 80))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, small.At(310, 80))
+}
+
+func TestProcessAppliesAndStripsEXIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(400, 200), nil))
+	upload := withOrientation(buf.Bytes(), 6) // stored rotated left
+
+	out, err := images.Process(upload)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", out.ContentType)
+	assert.Equal(t, [2]int{200, 400}, [2]int{out.Original.Width, out.Original.Height}, "turned upright")
+	assert.NotContains(t, string(out.Original.Data), "Exif")
+
+	// The left half, red, ends up on top after turning right.
+	upright, err := jpeg.Decode(bytes.NewReader(out.Original.Data))
+	require.NoError(t, err)
+	r, _, b, _ := upright.At(100, 20).RGBA()
+	assert.Greater(t, r, b)
+	r, _, b, _ = upright.At(100, 380).RGBA()
+	assert.Greater(t, b, r)
+}
+
+func TestProcessRejectsBadUploads(t *testing.T) {
+	_, err := images.Process([]byte("<html>not an image</html>"))
+	assert.ErrorIs(t, err, images.ErrUnsupportedType)
+
+	truncated := encodePNG(t, testImage(10, 10))[:40]
+	_, err = images.Process(truncated)
+	assert.True(t, errors.Is(err, images.ErrInvalidImage), err)
+
+	_, err = images.Process(make([]byte, images.MaxUploadSize+1))
+	assert.ErrorIs(t, err, images.ErrTooLarge)
+
+	// A tiny file claiming a huge bitmap is refused before decoding.
+	bomb := encodePNG(t, testImage(1, 1))
+	binary.BigEndian.PutUint32(bomb[16:], 100_000)
+	binary.BigEndian.PutUint32(bomb[20:], 100_000)
+	binary.BigEndian.PutUint32(bomb[29:], crc32.ChecksumIEEE(bomb[12:29]))
+	_, err = images.Process(bomb)
+	assert.ErrorIs(t, err, images.ErrTooLarge)
+}
@@ -0,0 +1,70 @@
+package images
+
+import "image"
+
+// contribution is the share of one source row or column in a destination pixel.
+type contribution struct {
+	index  int
+	weight float32
+}
+
+// resize scales src to w×h with a box filter: every destination pixel
+// averages the source pixels it covers, weighted by the covered area. Colours
+// are averaged premultiplied, so transparent pixels do not darken edges.
+func resize(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw == w && sh == h {
+		return src
+	}
+	cols, rows := contributions(sw, w), contributions(sh, h)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	row := make([]float32, w*4)
+	acc := make([]float32, w*4)
+	for y := 0; y < h; y++ {
+		clear(acc)
+		for _, r := range rows[y] {
+			resampleRow(src, r.index, cols, row)
+			for i, v := range row {
+				acc[i] += v * r.weight
+			}
+		}
+		out := dst.Pix[y*dst.Stride : y*dst.Stride+w*4]
+		for i, v := range acc {
+			out[i] = uint8(min(v+0.5, 255))
+		}
+	}
+	return dst
+}
+
+// resampleRow scales source row y horizontally into out.
+func resampleRow(src *image.RGBA, y int, cols [][]contribution, out []float32) {
+	pix := src.Pix[y*src.Stride:]
+	for x, cs := range cols {
+		var r, g, b, a float32
+		for _, c := range cs {
+			p := pix[c.index*4 : c.index*4+4]
+			r += float32(p[0]) * c.weight
+			g += float32(p[1]) * c.weight
+			b += float32(p[2]) * c.weight
+			a += float32(p[3]) * c.weight
+		}
+		out[x*4], out[x*4+1], out[x*4+2], out[x*4+3] = r, g, b, a
+	}
+}
+
+// contributions maps each of m destination pixels to the n source pixels it
+// covers. The weights of each destination pixel sum to one.
+func contributions(n, m int) [][]contribution {
+	scale := float64(n) / float64(m)
+	out := make([][]contribution, m)
+	for i := range out {
+		start, end := float64(i)*scale, float64(i+1)*scale
+		for j := int(start); j < n && float64(j) < end; j++ {
+			overlap := min(end, float64(j+1)) - max(start, float64(j))
+			if overlap > 0 {
+				out[i] = append(out[i], contribution{index: j, weight: float32(overlap / scale)})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+)
+
+// DefaultBatchSize is the number of records written per transaction.
+const DefaultBatchSize = 500
+
+// Stats counts what happened to the records of an import.
+type Stats struct {
+	Read       int // records read from the file, including rejects
+	Inserted   int // new recipes stored
+	Updated    int // previously imported recipes whose content changed
+	Duplicates int // records whose content was already stored or seen earlier
+	Rejected   int // records that could not be parsed or failed validation
+}
+
+// Options configure an Importer.
+type Options struct {
+	// BatchSize is the number of records per transaction; DefaultBatchSize if zero.
+	BatchSize int
+	// OwnerID becomes the UserID of every imported recipe. It may be empty
+	// for recipes that belong to no user.
+	OwnerID string
+	// Rejects receives one JSON line per rejected record with its line,
+	// the reason and the raw record. It may be nil.
+	Rejects io.Writer
+	// Progress, when set, is called with the running totals after each batch.
+	Progress func(Stats)
+}
+
+// Importer validates records and writes them to the recipe store in batches.
+// Re-running an import is safe: unchanged records are skipped as duplicates.
+type Importer struct {
+	repo repository.RecipeImportRepository
+	opts Options
+}
+
+// New returns an Importer writing through repo.
+func New(repo repository.RecipeImportRepository, opts Options) *Importer {
+	if opts.BatchSize < 1 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	return &Importer{repo: repo, opts: opts}
+}
+
+// reject is the JSON line written for each rejected record.
+type reject struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+	Record string `json:"record,omitempty"`
+}
+
+// Run imports every record from src. Malformed and invalid records are
+// counted and reported to the rejects writer; the import only stops early on
+// a read error or a failed batch, in which case the returned stats cover the
+// batches committed so far.
+func (im *Importer) Run(src Source) (Stats, error) {
+	var stats Stats
+	seen := make(map[string]bool)
+	var batch []*models.Recipe
+	pending := make(map[string]bool) // IDs in batch
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		outcomes, err := im.repo.ImportRecipes(batch)
+		if err != nil {
+			return fmt.Errorf("failed to import batch ending at record %d: %w", stats.Read, err)
+		}
+		for _, outcome := range outcomes {
+			switch outcome {
+			case repository.ImportInserted:
+				stats.Inserted++
+			case repository.ImportUpdated:
+				stats.Updated++
+			case repository.ImportDuplicate:
+				stats.Duplicates++
+			}
+		}
+		batch, pending = batch[:0], make(map[string]bool)
+		if im.opts.Progress != nil {
+			im.opts.Progress(stats)
+		}
+		return nil
+	}
+
+	for {
+		rec, err := src.Next()
+		if err == io.EOF {
+			break
+		}
+		var recErr *RecordError
+		if errors.As(err, &recErr) {
+			stats.Read++
+			if err := im.reject(&stats, recErr.Line, recErr.Raw, recErr.Err); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		if err != nil {
+			return stats, err
+		}
+		stats.Read++
+
+		recipe := rec.toModel(im.opts.OwnerID)
+		if err := service.ValidateRecipe(recipe); err != nil {
+			if err := im.reject(&stats, rec.Line, rec.Raw, err); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		recipe.ContentHash = ContentHash(recipe)
+		if seen[recipe.ContentHash] {
+			stats.Duplicates++
+			continue
+		}
+		seen[recipe.ContentHash] = true
+		recipe.ID = recipeID(rec.ID, recipe.ContentHash)
+
+		// A later record for the same source ID must see the earlier one stored.
+		if pending[recipe.ID] {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+		}
+		batch = append(batch, recipe)
+		pending[recipe.ID] = true
+		if len(batch) >= im.opts.BatchSize {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+		}
+	}
+	return stats, flush()
+}
+
+// reject counts a rejected record and reports it.
+func (im *Importer) reject(stats *Stats, line int, raw string, reason error) error {
+	stats.Rejected++
+	if im.opts.Rejects == nil {
+		return nil
+	}
+	out, err := json.Marshal(reject{Line: line, Reason: reason.Error(), Record: raw})
+	if err != nil {
+		return err
+	}
+	if _, err := im.opts.Rejects.Write(append(out, '\n')); err != nil {
+		return fmt.Errorf("failed to write rejects: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package importer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/importer"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+)
+
+func newImportDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models.All()...))
+	return db
+}
+
+func runImport(t *testing.T, db *gorm.DB, format, input string, rejects *bytes.Buffer) importer.Stats {
+	src, err := importer.NewSource(strings.NewReader(input), format)
+	require.NoError(t, err)
+	imp := importer.New(repository.NewRecipeImportRepository(db), importer.Options{
+		BatchSize: 2,
+		OwnerID:   "corpus",
+		Rejects:   rejects,
+	})
+	stats, err := imp.Run(src)
+	require.NoError(t, err)
+	return stats
+}
+
+const corpusJSONL = `{"title": "Miso Soup", "ingredients": ["4 cups dashi", "3 tbsp miso"], "steps": ["Heat the dashi.", "Whisk in the miso."], "servings": 4}
+{"title": "Toast", "ingredients": ["1 slice bread"], "steps": []}
+
+{"title": "Broken", "ingredients": [
+{"title": "miso   soup", "ingredients": ["4 cups DASHI", "3 tbsp miso"], "steps": ["Heat the dashi.", "Whisk in the miso."]}
+{"title": "Rice", "ingredients": ["1 cup rice", "2 cups water"], "steps": ["Simmer covered."]}
+{"title": "Omelette", "ingredients": ["2 eggs"], "steps": ["Whisk.", "Fry."], "appliances": ["Stove"]}
+`
+
+func TestImportJSONLIsIdempotent(t *testing.T) {
+	db := newImportDB(t)
+	var rejects bytes.Buffer
+	stats := runImport(t, db, importer.FormatJSONL, corpusJSONL, &rejects)
+	assert.Equal(t, importer.Stats{Read: 6, Inserted: 3, Duplicates: 1, Rejected: 2}, stats)
+
+	lines := strings.Split(strings.TrimSpace(rejects.String()), "\n")
+	require.Len(t, lines, 2)
+	var first struct {
+		Line   int    `json:"line"`
+		Reason string `json:"reason"`
+		Record string `json:"record"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, 2, first.Line)
+	assert.Contains(t, first.Reason, "at least one step is required")
+	assert.Contains(t, first.Record, "Toast")
+	assert.Contains(t, lines[1], `"line":4`)
+
+	var stored []models.Recipe
+	require.NoError(t, db.Order("title").Find(&stored).Error)
+	require.Len(t, stored, 3)
+	assert.Equal(t, "Miso Soup", stored[0].Title)
+	assert.Equal(t, "corpus", stored[0].UserID)
+	assert.NotEmpty(t, stored[0].ContentHash)
+	assert.Len(t, stored[0].StructuredIngredients, 2)
+
+	var revisions int64
+	db.Model(&models.RecipeRevision{}).Where("action = ?", models.RevisionImport).Count(&revisions)
+	assert.Equal(t, int64(3), revisions)
+
+	// Running the same file again changes nothing.
+	rejects.Reset()
+	stats = runImport(t, db, importer.FormatJSONL, corpusJSONL, &rejects)
+	assert.Equal(t, importer.Stats{Read: 6, Duplicates: 4, Rejected: 2}, stats)
+	var count int64
+	db.Model(&models.Recipe{}).Count(&count)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestImportUpsertsBySourceID(t *testing.T) {
+	db := newImportDB(t)
+	stats := runImport(t, db, importer.FormatJSON,
+		`[{"id": "src-1", "title": "Pancakes", "ingredients": ["1 cup flour"], "steps": ["Fry."]}]`, nil)
+	assert.Equal(t, 1, stats.Inserted)
+
+	stats = runImport(t, db, importer.FormatJSON,
+		`[{"id": "src-1", "title": "Pancakes", "ingredients": ["1 cup flour", "1 egg"], "steps": ["Fry."]}]`, nil)
+	assert.Equal(t, importer.Stats{Read: 1, Updated: 1}, stats)
+
+	var stored []models.Recipe
+	require.NoError(t, db.Find(&stored).Error)
+	require.Len(t, stored, 1)
+	assert.Equal(t, models.StringArray{"1 cup flour", "1 egg"}, stored[0].Ingredients)
+	assert.Equal(t, models.StringArray{"egg", "wheat"}, stored[0].Allergens)
+	assert.Equal(t, "Contains egg and wheat (gluten).", stored[0].AllergyDisclaimer)
+
+	revs, err := repository.NewRecipeRepository(db, nil).ListRevisions(stored[0].ID)
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+	assert.Equal(t, models.StringArray{"ingredients", "nutritional_info", "allergy_disclaimer"}, revs[0].ChangedFields,
+		"calculated nutrition and the generated disclaimer follow the ingredients")
+}
+
+func TestImportCSV(t *testing.T) {
+	db := newImportDB(t)
+	input := "title,ingredients,steps,servings,calories,appliances,notes\n" +
+		"Chili,1 lb beef|1 can beans,Brown the beef.|Simmer.,6,450,Stove|Slow cooker,ignored\n" +
+		"Bad Number,1 egg,Boil.,two,,,\n"
+	var rejects bytes.Buffer
+	stats := runImport(t, db, importer.FormatCSV, input, &rejects)
+	assert.Equal(t, importer.Stats{Read: 2, Inserted: 1, Rejected: 1}, stats)
+	assert.Contains(t, rejects.String(), `"line":3`)
+	assert.Contains(t, rejects.String(), "servings")
+
+	var chili models.Recipe
+	require.NoError(t, db.First(&chili, "title = ?", "Chili").Error)
+	assert.Equal(t, models.StringArray{"1 lb beef", "1 can beans"}, chili.Ingredients)
+	assert.Equal(t, models.StringArray{"Brown the beef.", "Simmer."}, chili.Steps)
+	assert.Equal(t, 6, chili.Servings)
+	assert.Equal(t, 450.0, chili.NutritionalInfo.Calories)
+	assert.Equal(t, models.StringArray{"Stove", "Slow cooker"}, chili.Appliances)
+}
+
+func TestNewSourceErrors(t *testing.T) {
+	_, err := importer.NewSource(strings.NewReader(`{"title": "x"}`), importer.FormatJSON)
+	assert.Error(t, err)
+	_, err = importer.NewSource(strings.NewReader("name,steps\n"), importer.FormatCSV)
+	assert.Error(t, err)
+	_, err = importer.FormatFromPath("recipes.xml")
+	assert.Error(t, err)
+	format, err := importer.FormatFromPath("corpus/recipes.NDJSON")
+	require.NoError(t, err)
+	assert.Equal(t, importer.FormatJSONL, format)
+}
@@ -0,0 +1,98 @@
+// Package importer loads recipes in bulk from JSON, JSONL and CSV files.
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/allergens"
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/nutrition"
+	"github.com/pageza/recipe-book-api-v2/internal/steps"
+)
+
+// recipeNamespace seeds the name-based UUIDs given to imported recipes, so a
+// record maps to the same recipe ID every time it is imported.
+var recipeNamespace = uuid.MustParse("5f0b7c1e-3a52-4d8e-9a61-2c4f1e0d9b37")
+
+// Record is one recipe as it appears in an import file.
+type Record struct {
+	// ID is an optional stable key from the source corpus. Records that share
+	// an ID update the same recipe; without one, the content identifies it.
+	ID                string                 `json:"id"`
+	Title             string                 `json:"title"`
+	Ingredients       []string               `json:"ingredients"`
+	Steps             []string               `json:"steps"`
+	Servings          int                    `json:"servings"`
+	NutritionalInfo   models.NutritionalInfo `json:"nutritional_info"`
+	AllergyDisclaimer string                 `json:"allergy_disclaimer"`
+	Appliances        []string               `json:"appliances"`
+
+	// Line is the position of the record in its file, used in reject reports.
+	Line int `json:"-"`
+	// Raw is the record as read, echoed in reject reports.
+	Raw string `json:"-"`
+}
+
+// toModel converts the record into a recipe owned by ownerID.
+func (r *Record) toModel(ownerID string) *models.Recipe {
+	recipe := &models.Recipe{
+		Title:             r.Title,
+		Ingredients:       trimAll(r.Ingredients),
+		Steps:             trimAll(r.Steps),
+		Servings:          r.Servings,
+		NutritionalInfo:   r.NutritionalInfo,
+		AllergyDisclaimer: strings.TrimSpace(r.AllergyDisclaimer),
+		Appliances:        trimAll(r.Appliances),
+		UserID:            ownerID,
+		Visibility:        models.VisibilityPublic,
+	}
+	recipe.StructuredIngredients = ingredients.ParseAll(recipe.Ingredients)
+	steps.Annotate(recipe)
+	allergens.Annotate(recipe, nil)
+	nutrition.Fill(recipe, models.NutritionalInfo{})
+	return recipe
+}
+
+// ContentHash fingerprints a recipe's title, ingredients and steps. Case and
+// runs of whitespace are ignored, so trivially reformatted copies of a recipe
+// hash alike.
+func ContentHash(recipe *models.Recipe) string {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(strings.Join(strings.Fields(strings.ToLower(s)), " ")))
+		h.Write([]byte{0})
+	}
+	write(recipe.Title)
+	for _, ing := range recipe.Ingredients {
+		write(ing)
+	}
+	h.Write([]byte{1})
+	for _, step := range recipe.Steps {
+		write(step)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recipeID derives the ID of an imported recipe from the record's source ID
+// or, failing that, its content hash.
+func recipeID(sourceID, hash string) string {
+	if sourceID != "" {
+		return uuid.NewSHA1(recipeNamespace, []byte("id:"+sourceID)).String()
+	}
+	return uuid.NewSHA1(recipeNamespace, []byte("hash:"+hash)).String()
+}
+
+// trimAll trims each item and drops empty ones.
+func trimAll(items []string) models.StringArray {
+	out := make(models.StringArray, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,239 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Supported import file formats.
+const (
+	FormatJSON  = "json"  // a single JSON array of records
+	FormatJSONL = "jsonl" // one JSON record per line
+	FormatCSV   = "csv"   // a header row followed by one record per row
+)
+
+// csvListSeparator separates the items of list columns in CSV files.
+const csvListSeparator = "|"
+
+// Source yields records from an import file one at a time, so files of any
+// size can be streamed.
+type Source interface {
+	// Next returns the next record, or io.EOF when the file is exhausted.
+	// A *RecordError means only that record was malformed and reading may
+	// continue; any other error is fatal.
+	Next() (*Record, error)
+}
+
+// RecordError describes a record that could not be read or failed validation.
+type RecordError struct {
+	Line int
+	Raw  string
+	Err  error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record at line %d: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// FormatFromPath infers the import format from a file extension.
+func FormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".jsonl", ".ndjson":
+		return FormatJSONL, nil
+	case ".csv":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("cannot infer import format of %q; use .json, .jsonl or .csv", path)
+}
+
+// NewSource returns a Source reading records in the given format from r.
+func NewSource(r io.Reader, format string) (Source, error) {
+	switch format {
+	case FormatJSON:
+		return newJSONSource(r)
+	case FormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		return &jsonlSource{scanner: scanner}, nil
+	case FormatCSV:
+		return newCSVSource(r)
+	}
+	return nil, fmt.Errorf("unknown import format %q", format)
+}
+
+// jsonSource streams the elements of a top-level JSON array. Line holds the
+// element's index, counting from 1.
+type jsonSource struct {
+	dec   *json.Decoder
+	index int
+}
+
+func newJSONSource(r io.Reader) (*jsonSource, error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON array: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("JSON import file must contain an array of recipes")
+	}
+	return &jsonSource{dec: dec}, nil
+}
+
+func (s *jsonSource) Next() (*Record, error) {
+	if !s.dec.More() {
+		return nil, io.EOF
+	}
+	s.index++
+	var raw json.RawMessage
+	if err := s.dec.Decode(&raw); err != nil {
+		// The array itself is broken, so nothing after this point can be trusted.
+		return nil, fmt.Errorf("failed to decode element %d: %w", s.index, err)
+	}
+	return decodeRecord(raw, s.index)
+}
+
+// jsonlSource reads one JSON record per line, skipping blank lines.
+type jsonlSource struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (s *jsonlSource) Next() (*Record, error) {
+	for s.scanner.Scan() {
+		s.line++
+		raw := strings.TrimSpace(s.scanner.Text())
+		if raw == "" {
+			continue
+		}
+		return decodeRecord([]byte(raw), s.line)
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// decodeRecord parses a single JSON record.
+func decodeRecord(raw []byte, line int) (*Record, error) {
+	var rec Record
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return nil, &RecordError{Line: line, Raw: string(raw), Err: err}
+	}
+	rec.Line, rec.Raw = line, string(raw)
+	return &rec, nil
+}
+
+// csvSource reads records from CSV rows. Columns are matched by header name,
+// so their order does not matter and unknown columns are ignored. The list
+// columns ingredients, steps and appliances separate items with "|", and the
+// nutrition columns are calories, protein, carbohydrates, fat and fiber.
+type csvSource struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+func newCSVSource(r io.Reader) (*csvSource, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("CSV import file has no title column")
+	}
+	return &csvSource{r: cr, columns: columns}, nil
+}
+
+func (s *csvSource) Next() (*Record, error) {
+	row, err := s.r.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RecordError{Line: parseErr.StartLine, Err: err}
+		}
+		return nil, err
+	}
+	line, _ := s.r.FieldPos(0)
+	raw := csvLine(row)
+
+	get := func(name string) string {
+		if i, ok := s.columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	list := func(name string) []string {
+		if v := get(name); v != "" {
+			return strings.Split(v, csvListSeparator)
+		}
+		return nil
+	}
+	var numErr error
+	number := func(name string) float64 {
+		v := get(name)
+		if v == "" {
+			return 0
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil && numErr == nil {
+			numErr = fmt.Errorf("%s: %q is not a number", name, v)
+		}
+		return f
+	}
+
+	rec := &Record{
+		ID:                get("id"),
+		Title:             get("title"),
+		Ingredients:       list("ingredients"),
+		Steps:             list("steps"),
+		AllergyDisclaimer: get("allergy_disclaimer"),
+		Appliances:        list("appliances"),
+		Line:              line,
+		Raw:               raw,
+	}
+	if v := get("servings"); v != "" {
+		if rec.Servings, err = strconv.Atoi(v); err != nil {
+			numErr = fmt.Errorf("servings: %q is not a whole number", v)
+		}
+	}
+	rec.NutritionalInfo.Calories = number("calories")
+	rec.NutritionalInfo.Protein = number("protein")
+	rec.NutritionalInfo.Carbohydrates = number("carbohydrates")
+	rec.NutritionalInfo.Fat = number("fat")
+	rec.NutritionalInfo.Fiber = number("fiber")
+	if numErr != nil {
+		return nil, &RecordError{Line: line, Raw: raw, Err: numErr}
+	}
+	return rec, nil
+}
+
+// csvLine re-encodes a CSV row for reject reports.
+func csvLine(row []string) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	_ = w.Write(row)
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,130 @@
+package ingredients
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
+)
+
+// fractionDenominators are tried in order, so simpler fractions win ties.
+var fractionDenominators = []int{2, 3, 4, 8}
+
+// fractionTolerance is how far a value may be from a kitchen fraction and
+// still be rendered as one.
+const fractionTolerance = 0.02
+
+// Scale returns a copy of ing with its quantities multiplied by factor.
+// The Raw text of the copy is re-rendered so it reflects the new amount.
+// Ingredients without a quantity ("salt, to taste") are returned unchanged.
+func Scale(ing models.Ingredient, factor float64) models.Ingredient {
+	if ing.Quantity == 0 {
+		return ing
+	}
+	ing.Quantity *= factor
+	ing.QuantityMax *= factor
+	ing.Raw = Format(ing)
+	return ing
+}
+
+// Convert returns a copy of ing with its amount expressed in the given unit
+// system, re-rendering Raw to match. Ingredients whose unit is unknown or
+// already suitable for the system are returned unchanged.
+func Convert(ing models.Ingredient, system units.System) models.Ingredient {
+	if ing.Quantity == 0 || ing.Unit == "" {
+		return ing
+	}
+	qty, unit := units.ToSystem(ing.Quantity, ing.Unit, ing.Name, system)
+	if unit == ing.Unit {
+		return ing
+	}
+	if ing.QuantityMax > 0 {
+		max, err := units.ConvertFor(ing.QuantityMax, ing.Unit, unit, ing.Name)
+		if err != nil {
+			return ing
+		}
+		ing.QuantityMax = units.Round(max, unit)
+	}
+	ing.Quantity = qty
+	ing.Unit = unit
+	ing.Raw = Format(ing)
+	return ing
+}
+
+// Format renders an ingredient as a single line, e.g. "1 1/2 cups onion, finely chopped".
+func Format(ing models.Ingredient) string {
+	var parts []string
+	if ing.Quantity > 0 {
+		amount := FormatQuantity(ing.Quantity, ing.Unit)
+		if ing.QuantityMax > 0 {
+			amount += "-" + FormatQuantity(ing.QuantityMax, ing.Unit)
+		}
+		parts = append(parts, amount)
+	}
+	if ing.Unit != "" {
+		unit := ing.Unit
+		if math.Max(ing.Quantity, ing.QuantityMax) > 1 {
+			unit = pluralUnit(unit)
+		}
+		parts = append(parts, unit)
+	}
+	if ing.Name != "" {
+		parts = append(parts, ing.Name)
+	}
+	line := strings.Join(parts, " ")
+	if ing.Preparation != "" {
+		line += ", " + ing.Preparation
+	}
+	if ing.Optional {
+		line += " (optional)"
+	}
+	return line
+}
+
+// FormatQuantity renders a quantity for display. Metric units are shown as
+// rounded decimals ("375", "1.5"); everything else uses kitchen fractions
+// ("1/3", "2 1/2") when the value is close to one.
+func FormatQuantity(q float64, unit string) string {
+	if u, ok := units.Lookup(unit); ok && u.System == units.Metric {
+		if q >= 10 {
+			return strconv.FormatFloat(math.Round(q), 'f', -1, 64)
+		}
+		return strconv.FormatFloat(math.Round(q*10)/10, 'f', -1, 64)
+	}
+
+	whole := math.Floor(q)
+	frac := q - whole
+	if frac < fractionTolerance && whole > 0 {
+		return strconv.FormatFloat(whole, 'f', -1, 64)
+	}
+	if 1-frac < fractionTolerance {
+		return strconv.FormatFloat(whole+1, 'f', -1, 64)
+	}
+	for _, d := range fractionDenominators {
+		n := math.Round(frac * float64(d))
+		if n == 0 || n == float64(d) {
+			continue
+		}
+		if math.Abs(frac-n/float64(d)) <= fractionTolerance {
+			fraction := fmt.Sprintf("%d/%d", int(n), d)
+			if whole == 0 {
+				return fraction
+			}
+			return fmt.Sprintf("%d %s", int(whole), fraction)
+		}
+	}
+	return strconv.FormatFloat(math.Round(q*100)/100, 'f', -1, 64)
+}
+
+// pluralUnit returns the plural form of a canonical unit name.
+func pluralUnit(unit string) string {
+	for _, suffix := range []string{"ch", "sh", "x", "s"} {
+		if strings.HasSuffix(unit, suffix) {
+			return unit + "es"
+		}
+	}
+	return unit + "s"
+}
@@ -0,0 +1,88 @@
+package ingredients_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
+)
+
+func TestFormatQuantity(t *testing.T) {
+	cases := []struct {
+		q    float64
+		unit string
+		want string
+	}{
+		{1, "cup", "1"},
+		{0.5, "cup", "1/2"},
+		{1.0 / 3, "cup", "1/3"},
+		{2.0 / 3, "cup", "2/3"},
+		{2.5, "cup", "2 1/2"},
+		{1.25, "teaspoon", "1 1/4"},
+		{0.375, "cup", "3/8"},
+		{1.999, "cup", "2"},
+		{0.1, "teaspoon", "0.1"},
+		{375.4, "gram", "375"},
+		{1.53, "liter", "1.5"},
+		{7.5, "milliliter", "7.5"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, ingredients.FormatQuantity(tc.q, tc.unit), "%v %s", tc.q, tc.unit)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		ing  models.Ingredient
+		want string
+	}{
+		{models.Ingredient{Quantity: 1.5, Unit: "cup", Name: "onion", Preparation: "finely chopped"}, "1 1/2 cups onion, finely chopped"},
+		{models.Ingredient{Quantity: 1, Unit: "pinch", Name: "salt"}, "1 pinch salt"},
+		{models.Ingredient{Quantity: 2, Unit: "pinch", Name: "salt"}, "2 pinches salt"},
+		{models.Ingredient{Quantity: 2, QuantityMax: 3, Unit: "clove", Name: "garlic"}, "2-3 cloves garlic"},
+		{models.Ingredient{Quantity: 3, Name: "eggs"}, "3 eggs"},
+		{models.Ingredient{Quantity: 0.25, Unit: "cup", Name: "parsley", Optional: true}, "1/4 cup parsley (optional)"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, ingredients.Format(tc.ing))
+	}
+}
+
+func TestScale(t *testing.T) {
+	flour := ingredients.Parse("1 cup flour")
+	doubled := ingredients.Scale(flour, 2)
+	assert.Equal(t, 2.0, doubled.Quantity)
+	assert.Equal(t, "2 cups flour", doubled.Raw)
+	assert.Equal(t, "1 cup flour", flour.Raw, "the original must not change")
+
+	third := ingredients.Scale(ingredients.Parse("1 cup sugar"), 1.0/3)
+	assert.Equal(t, "1/3 cup sugar", third.Raw)
+
+	ranged := ingredients.Scale(ingredients.Parse("2-3 cloves garlic, minced"), 1.5)
+	assert.Equal(t, "3-4 1/2 cloves garlic, minced", ranged.Raw)
+
+	salt := ingredients.Parse("salt, to taste")
+	assert.Equal(t, salt, ingredients.Scale(salt, 4), "unquantified ingredients are unchanged")
+}
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		line   string
+		system units.System
+		want   string
+	}{
+		{"1 1/2 cups all-purpose flour, sifted", units.Metric, "188 grams all-purpose flour, sifted"},
+		{"2-3 cups chicken stock", units.Metric, "473-710 milliliters chicken stock"},
+		{"500 g potatoes, peeled", units.Imperial, "1 1/8 pounds potatoes, peeled"},
+		{"250 ml milk", units.Imperial, "1 cup milk"},
+		{"2 tsp vanilla extract", units.Metric, "2 tsp vanilla extract"},
+		{"3 cloves garlic", units.Imperial, "3 cloves garlic"},
+		{"salt, to taste", units.Metric, "salt, to taste"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, ingredients.Convert(ingredients.Parse(tc.line), tc.system).Raw, tc.line)
+	}
+}
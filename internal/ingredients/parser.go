@@ -0,0 +1,442 @@
+// Package ingredients turns free-text ingredient lines into structured
+// models.Ingredient values.
+package ingredients
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// unicodeFractions maps vulgar fraction characters to their ASCII form.
+var unicodeFractions = map[rune]string{
+	'½': "1/2", '⅓': "1/3", '⅔': "2/3", '¼': "1/4", '¾': "3/4",
+	'⅕': "1/5", '⅖': "2/5", '⅗': "3/5", '⅘': "4/5", '⅙': "1/6",
+	'⅚': "5/6", '⅛': "1/8", '⅜': "3/8", '⅝': "5/8", '⅞': "7/8",
+}
+
+// unitAliases maps lower-cased spellings to canonical unit names.
+// Case-sensitive abbreviations ("T" vs "t") are handled in lookupUnit.
+var unitAliases = map[string]string{
+	"teaspoon": "teaspoon", "teaspoons": "teaspoon", "tsp": "teaspoon", "tsps": "teaspoon",
+	"tablespoon": "tablespoon", "tablespoons": "tablespoon", "tbsp": "tablespoon", "tbsps": "tablespoon",
+	"tbs": "tablespoon", "tbl": "tablespoon", "tbls": "tablespoon",
+	"cup": "cup", "cups": "cup", "c": "cup",
+	"fluid ounce": "fluid ounce", "fluid ounces": "fluid ounce", "fl oz": "fluid ounce", "floz": "fluid ounce",
+	"ounce": "ounce", "ounces": "ounce", "oz": "ounce",
+	"pound": "pound", "pounds": "pound", "lb": "pound", "lbs": "pound",
+	"gram": "gram", "grams": "gram", "g": "gram", "gr": "gram", "gm": "gram",
+	"kilogram": "kilogram", "kilograms": "kilogram", "kg": "kilogram", "kgs": "kilogram",
+	"milligram": "milligram", "milligrams": "milligram", "mg": "milligram",
+	"milliliter": "milliliter", "milliliters": "milliliter", "millilitre": "milliliter", "millilitres": "milliliter", "ml": "milliliter",
+	"centiliter": "centiliter", "centiliters": "centiliter", "cl": "centiliter",
+	"deciliter": "deciliter", "deciliters": "deciliter", "dl": "deciliter",
+	"liter": "liter", "liters": "liter", "litre": "liter", "litres": "liter", "l": "liter",
+	"pint": "pint", "pints": "pint", "pt": "pint",
+	"quart": "quart", "quarts": "quart", "qt": "quart",
+	"gallon": "gallon", "gallons": "gallon", "gal": "gallon",
+	"pinch": "pinch", "pinches": "pinch",
+	"dash": "dash", "dashes": "dash",
+	"drop": "drop", "drops": "drop",
+	"clove": "clove", "cloves": "clove",
+	"can": "can", "cans": "can", "tin": "can", "tins": "can",
+	"package": "package", "packages": "package", "pkg": "package", "packet": "package", "packets": "package",
+	"stick": "stick", "sticks": "stick",
+	"slice": "slice", "slices": "slice",
+	"piece": "piece", "pieces": "piece",
+	"sprig": "sprig", "sprigs": "sprig",
+	"bunch": "bunch", "bunches": "bunch",
+	"head": "head", "heads": "head",
+	"handful": "handful", "handfuls": "handful",
+	"jar": "jar", "jars": "jar",
+	"bottle": "bottle", "bottles": "bottle",
+	"bag": "bag", "bags": "bag",
+	"box": "box", "boxes": "box",
+	"stalk": "stalk", "stalks": "stalk",
+	"sheet": "sheet", "sheets": "sheet",
+	"inch": "inch", "inches": "inch",
+}
+
+// wordNumbers maps spelled-out quantities to their values.
+var wordNumbers = map[string]float64{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"dozen": 12,
+}
+
+// prepWords are preparation terms that may precede the ingredient name
+// ("finely chopped onion").
+var prepWords = map[string]bool{
+	"chopped": true, "diced": true, "minced": true, "sliced": true, "grated": true,
+	"shredded": true, "crushed": true, "peeled": true, "softened": true, "melted": true,
+	"beaten": true, "cubed": true, "julienned": true, "halved": true, "quartered": true,
+	"trimmed": true, "rinsed": true, "drained": true, "toasted": true, "cooked": true,
+	"uncooked": true, "packed": true, "sifted": true, "mashed": true, "pitted": true,
+	"seeded": true, "zested": true, "squeezed": true, "cored": true, "deveined": true,
+	"thawed": true, "room-temperature": true,
+}
+
+// prepModifiers may only appear in front of a prep word ("finely", "freshly").
+var prepModifiers = map[string]bool{
+	"finely": true, "roughly": true, "coarsely": true, "thinly": true, "freshly": true,
+	"lightly": true, "firmly": true, "loosely": true, "well": true, "very": true,
+	"thickly": true, "and": true,
+}
+
+// noteStarters are words that, after a comma, begin a preparation note
+// ("garlic, minced", "parsley, for garnish") rather than continue the name
+// ("boneless, skinless chicken breasts").
+var noteStarters = map[string]bool{
+	"optional": true, "cut": true, "at": true, "for": true, "divided": true, "plus": true,
+	"or": true, "about": true, "preferably": true, "such": true, "if": true, "to": true,
+	"room": true, "cold": true, "warm": true, "hot": true, "torn": true, "into": true,
+	"as": true, "see": true, "from": true, "without": true, "with": true, "more": true,
+}
+
+var (
+	parenPattern     = regexp.MustCompile(`\(([^)]*)\)`)
+	decimalComma     = regexp.MustCompile(`(\d),(\d)`)
+	attachedUnit     = regexp.MustCompile(`^(\d+(?:\.\d+)?|\d+/\d+)([a-zA-Z]+\.?)$`)
+	spacePattern     = regexp.MustCompile(`\s+`)
+	bulletPattern    = regexp.MustCompile(`^[-*•·]+\s*`)
+	toTastePattern   = regexp.MustCompile(`(?i)\s*(,\s*)?(or\s+)?to taste$`)
+	optionalSuffix   = regexp.MustCompile(`(?i)\s*\boptional$`)
+	optionalPrefix   = regexp.MustCompile(`(?i)^optional:?\s+`)
+	rangeSeparator   = regexp.MustCompile(`^(-|to|or)$`)
+	mixedRangeSplits = regexp.MustCompile(`^([\d./]+)-([\d./]+)$`)
+)
+
+// ParseAll parses every line, skipping blank ones.
+func ParseAll(lines []string) []models.Ingredient {
+	result := make([]models.Ingredient, 0, len(lines))
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		result = append(result, Parse(line))
+	}
+	return result
+}
+
+// Parse converts a single ingredient line such as "2-3 cloves garlic, minced"
+// into a structured ingredient. Parsing is best effort: anything that cannot be
+// recognized as a quantity, unit or preparation note is kept in Name, and the
+// original line is always preserved in Raw.
+func Parse(line string) models.Ingredient {
+	ing := models.Ingredient{Raw: line}
+	s := normalize(line)
+
+	var notes []string
+
+	// Parenthetical remarks: "(optional)", "(14 oz)", "(about 2 cups)".
+	for _, m := range parenPattern.FindAllStringSubmatch(s, -1) {
+		note := strings.TrimSpace(m[1])
+		if strings.EqualFold(note, "optional") {
+			ing.Optional = true
+		} else if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	s = collapse(parenPattern.ReplaceAllString(s, " "))
+
+	if optionalPrefix.MatchString(s) {
+		ing.Optional = true
+		s = optionalPrefix.ReplaceAllString(s, "")
+	}
+
+	// Everything after the first comma that starts a note is preparation detail.
+	var tailNotes []string
+	if i := noteComma(s); i >= 0 {
+		for _, part := range strings.Split(s[i+1:], ",") {
+			part = strings.TrimSpace(part)
+			switch {
+			case part == "":
+			case strings.EqualFold(part, "optional"):
+				ing.Optional = true
+			default:
+				if optionalSuffix.MatchString(part) {
+					ing.Optional = true
+					part = strings.TrimSpace(optionalSuffix.ReplaceAllString(part, ""))
+				}
+				if part != "" {
+					tailNotes = append(tailNotes, part)
+				}
+			}
+		}
+		s = strings.TrimSpace(s[:i])
+	}
+
+	if optionalSuffix.MatchString(s) {
+		ing.Optional = true
+		s = strings.TrimSpace(optionalSuffix.ReplaceAllString(s, ""))
+	}
+	if toTastePattern.MatchString(s) {
+		s = strings.TrimSpace(toTastePattern.ReplaceAllString(s, ""))
+		tailNotes = append(tailNotes, "to taste")
+	}
+
+	tokens := strings.Fields(s)
+	i := 0
+
+	// Quantity, possibly with the unit glued on ("200g").
+	qty, qtyMax, n := parseQuantity(tokens)
+	if n > 0 {
+		ing.Quantity, ing.QuantityMax = qty, qtyMax
+		i = n
+	} else if len(tokens) > 0 {
+		if m := attachedUnit.FindStringSubmatch(tokens[0]); m != nil {
+			if unit, ok := lookupUnit(m[2]); ok {
+				if v, ok := parseNumber(m[1]); ok {
+					ing.Quantity, ing.Unit = v, unit
+					i = 1
+				}
+			}
+		}
+	}
+	if n == 0 && i == 0 && len(tokens) > 1 && isArticle(tokens[0]) {
+		// "a pinch of salt" - only treat the article as a quantity before a unit.
+		if _, _, ok := matchUnit(tokens[1:]); ok {
+			ing.Quantity = 1
+			i = 1
+		}
+	}
+
+	// Unit.
+	if ing.Unit == "" && i < len(tokens) && (ing.Quantity > 0 || i > 0) {
+		if unit, width, ok := matchUnit(tokens[i:]); ok && i+width < len(tokens) {
+			ing.Unit = unit
+			i += width
+		}
+	}
+	if i > 0 && i < len(tokens)-1 && strings.EqualFold(tokens[i], "of") {
+		i++
+	}
+
+	// Leading preparation words ("finely chopped onion").
+	rest := tokens[i:]
+	prepCount := leadingPrep(rest)
+	var leading []string
+	if prepCount > 0 {
+		leading = []string{strings.Join(rest[:prepCount], " ")}
+		rest = rest[prepCount:]
+	}
+
+	ing.Name = strings.ToLower(strings.Join(rest, " "))
+	all := append(append(leading, tailNotes...), notes...)
+	ing.Preparation = strings.Join(all, ", ")
+	return ing
+}
+
+// normalize rewrites unicode fractions, dashes and decimal commas and collapses whitespace.
+func normalize(line string) string {
+	var b strings.Builder
+	for _, r := range line {
+		if frac, ok := unicodeFractions[r]; ok {
+			b.WriteString(" " + frac + " ")
+			continue
+		}
+		switch r {
+		case '⁄':
+			b.WriteRune('/')
+		case '–', '—':
+			b.WriteRune('-')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	s := collapse(b.String())
+	s = bulletPattern.ReplaceAllString(s, "")
+	return decimalComma.ReplaceAllString(s, "$1.$2")
+}
+
+func collapse(s string) string {
+	return strings.TrimSpace(spacePattern.ReplaceAllString(s, " "))
+}
+
+// noteComma returns the index of the first comma that introduces a preparation
+// note, or -1 if there is none.
+func noteComma(s string) int {
+	offset := 0
+	for {
+		i := strings.Index(s[offset:], ",")
+		if i < 0 {
+			return -1
+		}
+		i += offset
+		next := strings.Fields(s[i+1:])
+		if len(next) == 0 || startsNote(next[0]) {
+			return i
+		}
+		offset = i + 1
+	}
+}
+
+// startsNote reports whether word typically begins a preparation note.
+// Past participles ("minced", "soaked") and adverbs ("finely") count as well.
+func startsNote(word string) bool {
+	w := strings.ToLower(strings.Trim(word, ".,;:"))
+	if noteStarters[w] || prepWords[w] || prepModifiers[w] {
+		return true
+	}
+	return len(w) > 4 && (strings.HasSuffix(w, "ed") || strings.HasSuffix(w, "ly"))
+}
+
+// parseQuantity reads a leading quantity from tokens. It understands integers,
+// decimals, fractions, mixed numbers ("1 1/2", "1-1/2"), ranges ("2-3",
+// "2 to 3") and spelled-out numbers. It returns the number of tokens consumed.
+func parseQuantity(tokens []string) (qty, qtyMax float64, consumed int) {
+	if len(tokens) == 0 {
+		return 0, 0, 0
+	}
+	first, ok := parseAmountToken(tokens[0])
+	if !ok {
+		return 0, 0, 0
+	}
+	qty, qtyMax = first.low, first.high
+	consumed = 1
+
+	// Mixed number: "1 1/2".
+	if first.high == 0 && isWhole(qty) && len(tokens) > 1 && strings.Contains(tokens[1], "/") {
+		if frac, ok := parseNumber(tokens[1]); ok && frac < 1 {
+			qty += frac
+			consumed = 2
+		}
+	}
+
+	// Range: "2 to 3", "2 - 3", "1 or 2".
+	if qtyMax == 0 && len(tokens) > consumed+1 && rangeSeparator.MatchString(strings.ToLower(tokens[consumed])) {
+		upper, ok := parseAmountToken(tokens[consumed+1])
+		if ok && upper.high == 0 {
+			width := 2
+			// Allow a mixed upper bound: "1 to 1 1/2".
+			if isWhole(upper.low) && len(tokens) > consumed+2 && strings.Contains(tokens[consumed+2], "/") {
+				if frac, ok := parseNumber(tokens[consumed+2]); ok && frac < 1 {
+					upper.low += frac
+					width++
+				}
+			}
+			if upper.low > qty {
+				qtyMax = upper.low
+				consumed += width
+			}
+		}
+	}
+	return qty, qtyMax, consumed
+}
+
+// amount is a single parsed quantity token; high is set for ranges.
+type amount struct {
+	low, high float64
+}
+
+// parseAmountToken parses a single token such as "2", "1.5", "1/2", "2-3",
+// "1-1/2" or "two".
+func parseAmountToken(tok string) (amount, bool) {
+	if v, ok := wordNumbers[strings.ToLower(tok)]; ok {
+		return amount{low: v}, true
+	}
+	if v, ok := parseNumber(tok); ok {
+		return amount{low: v}, true
+	}
+	if m := mixedRangeSplits.FindStringSubmatch(tok); m != nil {
+		left, okL := parseNumber(m[1])
+		right, okR := parseNumber(m[2])
+		if !okL || !okR {
+			return amount{}, false
+		}
+		// "1-1/2" is the US way of writing one and a half.
+		if isWhole(left) && strings.Contains(m[2], "/") && right < 1 {
+			return amount{low: left + right}, true
+		}
+		if right > left {
+			return amount{low: left, high: right}, true
+		}
+	}
+	return amount{}, false
+}
+
+// parseNumber parses an integer, decimal or simple fraction.
+func parseNumber(tok string) (float64, bool) {
+	if num, den, found := strings.Cut(tok, "/"); found {
+		n, err1 := strconv.Atoi(num)
+		d, err2 := strconv.Atoi(den)
+		if err1 != nil || err2 != nil || d == 0 {
+			return 0, false
+		}
+		return float64(n) / float64(d), true
+	}
+	for _, r := range tok {
+		if (r < '0' || r > '9') && r != '.' {
+			return 0, false
+		}
+	}
+	v, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func isWhole(v float64) bool {
+	return v == float64(int64(v))
+}
+
+func isArticle(tok string) bool {
+	t := strings.ToLower(tok)
+	return t == "a" || t == "an"
+}
+
+// matchUnit tries two-word units ("fl oz", "fluid ounces") before single words.
+func matchUnit(tokens []string) (unit string, width int, ok bool) {
+	if len(tokens) >= 2 {
+		pair := strings.ToLower(strings.TrimSuffix(tokens[0], ".") + " " + strings.TrimSuffix(tokens[1], "."))
+		if u, found := unitAliases[pair]; found {
+			return u, 2, true
+		}
+	}
+	if len(tokens) >= 1 {
+		if u, found := lookupUnit(tokens[0]); found {
+			return u, 1, true
+		}
+	}
+	return "", 0, false
+}
+
+// lookupUnit resolves a single unit token, honouring the "T" (tablespoon) versus
+// "t" (teaspoon) convention.
+func lookupUnit(tok string) (string, bool) {
+	tok = strings.TrimSuffix(tok, ".")
+	switch tok {
+	case "T", "Tb", "Tbsp", "TBSP", "TB":
+		return "tablespoon", true
+	case "t":
+		return "teaspoon", true
+	}
+	u, ok := unitAliases[strings.ToLower(tok)]
+	return u, ok
+}
+
+// leadingPrep returns how many leading tokens form a preparation phrase.
+// The phrase must end in a prep word and must leave a non-empty name.
+func leadingPrep(tokens []string) int {
+	count := 0
+	for j, tok := range tokens {
+		if j == len(tokens)-1 {
+			break
+		}
+		t := strings.ToLower(tok)
+		switch {
+		case prepWords[t]:
+			count = j + 1
+		case t == "ground" && j > 0 && prepModifiers[strings.ToLower(tokens[j-1])]:
+			count = j + 1
+		case prepModifiers[t]:
+			continue
+		default:
+			return count
+		}
+	}
+	return count
+}
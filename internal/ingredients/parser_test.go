@@ -0,0 +1,163 @@
+package ingredients_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// ingredientCases is a corpus of real-world ingredient lines and their expected parse.
+// Raw is filled in from the input line by the test.
+var ingredientCases = []struct {
+	line string
+	want models.Ingredient
+}{
+	// Whole numbers, decimals and fractions.
+	{"3 eggs", models.Ingredient{Quantity: 3, Name: "eggs"}},
+	{"2 cups all-purpose flour", models.Ingredient{Quantity: 2, Unit: "cup", Name: "all-purpose flour"}},
+	{"1.5 kg potatoes", models.Ingredient{Quantity: 1.5, Unit: "kilogram", Name: "potatoes"}},
+	{"1,5 kg potatoes", models.Ingredient{Quantity: 1.5, Unit: "kilogram", Name: "potatoes"}},
+	{"1/2 teaspoon salt", models.Ingredient{Quantity: 0.5, Unit: "teaspoon", Name: "salt"}},
+	{"3/4 cup sugar", models.Ingredient{Quantity: 0.75, Unit: "cup", Name: "sugar"}},
+	{"1 1/2 cups finely chopped onion", models.Ingredient{Quantity: 1.5, Unit: "cup", Name: "onion", Preparation: "finely chopped"}},
+	{"1-1/2 cups milk", models.Ingredient{Quantity: 1.5, Unit: "cup", Name: "milk"}},
+	{"2 1/4 tsp active dry yeast", models.Ingredient{Quantity: 2.25, Unit: "teaspoon", Name: "active dry yeast"}},
+
+	// Unicode fractions.
+	{"½ cup heavy cream", models.Ingredient{Quantity: 0.5, Unit: "cup", Name: "heavy cream"}},
+	{"1½ cups water", models.Ingredient{Quantity: 1.5, Unit: "cup", Name: "water"}},
+	{"¼ tsp cayenne pepper", models.Ingredient{Quantity: 0.25, Unit: "teaspoon", Name: "cayenne pepper"}},
+	{"2 ¾ cups bread flour", models.Ingredient{Quantity: 2.75, Unit: "cup", Name: "bread flour"}},
+	{"⅓ cup maple syrup", models.Ingredient{Quantity: 1.0 / 3, Unit: "cup", Name: "maple syrup"}},
+
+	// Ranges.
+	{"2-3 cloves garlic, minced", models.Ingredient{Quantity: 2, QuantityMax: 3, Unit: "clove", Name: "garlic", Preparation: "minced"}},
+	{"2 to 3 tablespoons lemon juice", models.Ingredient{Quantity: 2, QuantityMax: 3, Unit: "tablespoon", Name: "lemon juice"}},
+	{"4 – 6 chicken thighs", models.Ingredient{Quantity: 4, QuantityMax: 6, Name: "chicken thighs"}},
+	{"1 or 2 jalapeños, seeded and diced", models.Ingredient{Quantity: 1, QuantityMax: 2, Name: "jalapeños", Preparation: "seeded and diced"}},
+	{"1/2-1 tsp chili flakes", models.Ingredient{Quantity: 0.5, QuantityMax: 1, Unit: "teaspoon", Name: "chili flakes"}},
+	{"1 to 1 1/2 cups broth", models.Ingredient{Quantity: 1, QuantityMax: 1.5, Unit: "cup", Name: "broth"}},
+
+	// Unit spellings and abbreviations.
+	{"1 tbsp olive oil", models.Ingredient{Quantity: 1, Unit: "tablespoon", Name: "olive oil"}},
+	{"2 Tbsp. soy sauce", models.Ingredient{Quantity: 2, Unit: "tablespoon", Name: "soy sauce"}},
+	{"1 T butter", models.Ingredient{Quantity: 1, Unit: "tablespoon", Name: "butter"}},
+	{"1 t vanilla extract", models.Ingredient{Quantity: 1, Unit: "teaspoon", Name: "vanilla extract"}},
+	{"1 tsp. baking soda", models.Ingredient{Quantity: 1, Unit: "teaspoon", Name: "baking soda"}},
+	{"3 Tablespoons honey", models.Ingredient{Quantity: 3, Unit: "tablespoon", Name: "honey"}},
+	{"8 oz cream cheese, softened", models.Ingredient{Quantity: 8, Unit: "ounce", Name: "cream cheese", Preparation: "softened"}},
+	{"16 ounces spaghetti", models.Ingredient{Quantity: 16, Unit: "ounce", Name: "spaghetti"}},
+	{"2 fl oz dark rum", models.Ingredient{Quantity: 2, Unit: "fluid ounce", Name: "dark rum"}},
+	{"4 fl. oz. orange juice", models.Ingredient{Quantity: 4, Unit: "fluid ounce", Name: "orange juice"}},
+	{"8 fluid ounces tomato sauce", models.Ingredient{Quantity: 8, Unit: "fluid ounce", Name: "tomato sauce"}},
+	{"1 lb ground beef", models.Ingredient{Quantity: 1, Unit: "pound", Name: "ground beef"}},
+	{"2 lbs. chicken wings", models.Ingredient{Quantity: 2, Unit: "pound", Name: "chicken wings"}},
+	{"500 g pasta", models.Ingredient{Quantity: 500, Unit: "gram", Name: "pasta"}},
+	{"200g plain flour", models.Ingredient{Quantity: 200, Unit: "gram", Name: "plain flour"}},
+	{"250ml whole milk", models.Ingredient{Quantity: 250, Unit: "milliliter", Name: "whole milk"}},
+	{"1.5L chicken stock", models.Ingredient{Quantity: 1.5, Unit: "liter", Name: "chicken stock"}},
+	{"100 grams dark chocolate, chopped", models.Ingredient{Quantity: 100, Unit: "gram", Name: "dark chocolate", Preparation: "chopped"}},
+	{"1 litre vegetable stock", models.Ingredient{Quantity: 1, Unit: "liter", Name: "vegetable stock"}},
+	{"2 pints strawberries, hulled", models.Ingredient{Quantity: 2, Unit: "pint", Name: "strawberries", Preparation: "hulled"}},
+	{"1 quart buttermilk", models.Ingredient{Quantity: 1, Unit: "quart", Name: "buttermilk"}},
+	{"1 gallon water", models.Ingredient{Quantity: 1, Unit: "gallon", Name: "water"}},
+	{"5 mg saffron", models.Ingredient{Quantity: 5, Unit: "milligram", Name: "saffron"}},
+	{"2 dl cream", models.Ingredient{Quantity: 2, Unit: "deciliter", Name: "cream"}},
+
+	// Count-style units.
+	{"4 cloves garlic", models.Ingredient{Quantity: 4, Unit: "clove", Name: "garlic"}},
+	{"1 can chickpeas, drained and rinsed", models.Ingredient{Quantity: 1, Unit: "can", Name: "chickpeas", Preparation: "drained and rinsed"}},
+	{"1 (14 oz) can diced tomatoes", models.Ingredient{Quantity: 1, Unit: "can", Name: "tomatoes", Preparation: "diced, 14 oz"}},
+	{"2 (15-ounce) cans black beans, drained", models.Ingredient{Quantity: 2, Unit: "can", Name: "black beans", Preparation: "drained, 15-ounce"}},
+	{"1 package instant pudding mix", models.Ingredient{Quantity: 1, Unit: "package", Name: "instant pudding mix"}},
+	{"2 sticks unsalted butter", models.Ingredient{Quantity: 2, Unit: "stick", Name: "unsalted butter"}},
+	{"4 slices bacon", models.Ingredient{Quantity: 4, Unit: "slice", Name: "bacon"}},
+	{"3 sprigs fresh thyme", models.Ingredient{Quantity: 3, Unit: "sprig", Name: "fresh thyme"}},
+	{"1 bunch cilantro, roughly chopped", models.Ingredient{Quantity: 1, Unit: "bunch", Name: "cilantro", Preparation: "roughly chopped"}},
+	{"1 head cauliflower, cut into florets", models.Ingredient{Quantity: 1, Unit: "head", Name: "cauliflower", Preparation: "cut into florets"}},
+	{"2 stalks celery, sliced", models.Ingredient{Quantity: 2, Unit: "stalk", Name: "celery", Preparation: "sliced"}},
+	{"1 handful basil leaves", models.Ingredient{Quantity: 1, Unit: "handful", Name: "basil leaves"}},
+	{"1 inch ginger, peeled and grated", models.Ingredient{Quantity: 1, Unit: "inch", Name: "ginger", Preparation: "peeled and grated"}},
+	{"1 jar marinara sauce", models.Ingredient{Quantity: 1, Unit: "jar", Name: "marinara sauce"}},
+	{"2 sheets puff pastry, thawed", models.Ingredient{Quantity: 2, Unit: "sheet", Name: "puff pastry", Preparation: "thawed"}},
+	{"a pinch of salt", models.Ingredient{Quantity: 1, Unit: "pinch", Name: "salt"}},
+	{"A dash of hot sauce", models.Ingredient{Quantity: 1, Unit: "dash", Name: "hot sauce"}},
+	{"3 drops food coloring", models.Ingredient{Quantity: 3, Unit: "drop", Name: "food coloring"}},
+	{"2 cups of rice", models.Ingredient{Quantity: 2, Unit: "cup", Name: "rice"}},
+
+	// Word numbers.
+	{"two eggs", models.Ingredient{Quantity: 2, Name: "eggs"}},
+	{"One onion, diced", models.Ingredient{Quantity: 1, Name: "onion", Preparation: "diced"}},
+	{"1 dozen eggs", models.Ingredient{Quantity: 1, Name: "dozen eggs"}},
+
+	// Preparation notes before and after the name.
+	{"1 cup packed brown sugar", models.Ingredient{Quantity: 1, Unit: "cup", Name: "brown sugar", Preparation: "packed"}},
+	{"1/2 cup melted butter", models.Ingredient{Quantity: 0.5, Unit: "cup", Name: "butter", Preparation: "melted"}},
+	{"2 cups shredded mozzarella cheese", models.Ingredient{Quantity: 2, Unit: "cup", Name: "mozzarella cheese", Preparation: "shredded"}},
+	{"1 tsp freshly ground black pepper", models.Ingredient{Quantity: 1, Unit: "teaspoon", Name: "black pepper", Preparation: "freshly ground"}},
+	{"1 tbsp freshly squeezed lemon juice", models.Ingredient{Quantity: 1, Unit: "tablespoon", Name: "lemon juice", Preparation: "freshly squeezed"}},
+	{"2 cups peeled and diced potatoes", models.Ingredient{Quantity: 2, Unit: "cup", Name: "potatoes", Preparation: "peeled and diced"}},
+	{"1/4 cup thinly sliced green onions", models.Ingredient{Quantity: 0.25, Unit: "cup", Name: "green onions", Preparation: "thinly sliced"}},
+	{"1 large egg, lightly beaten", models.Ingredient{Quantity: 1, Name: "large egg", Preparation: "lightly beaten"}},
+	{"2 tablespoons butter, melted and cooled", models.Ingredient{Quantity: 2, Unit: "tablespoon", Name: "butter", Preparation: "melted and cooled"}},
+	{"1 cup butter, at room temperature", models.Ingredient{Quantity: 1, Unit: "cup", Name: "butter", Preparation: "at room temperature"}},
+	{"3 tbsp sugar, divided", models.Ingredient{Quantity: 3, Unit: "tablespoon", Name: "sugar", Preparation: "divided"}},
+	{"1 onion, halved and thinly sliced", models.Ingredient{Quantity: 1, Name: "onion", Preparation: "halved and thinly sliced"}},
+	{"2 carrots, peeled, diced", models.Ingredient{Quantity: 2, Name: "carrots", Preparation: "peeled, diced"}},
+	{"1/2 lb shrimp, peeled and deveined", models.Ingredient{Quantity: 0.5, Unit: "pound", Name: "shrimp", Preparation: "peeled and deveined"}},
+	{"1 cup frozen peas, thawed", models.Ingredient{Quantity: 1, Unit: "cup", Name: "frozen peas", Preparation: "thawed"}},
+	{"4 boneless, skinless chicken breasts", models.Ingredient{Quantity: 4, Name: "boneless, skinless chicken breasts"}},
+	{"1 tsp ground cinnamon", models.Ingredient{Quantity: 1, Unit: "teaspoon", Name: "ground cinnamon"}},
+	{"1/4 tsp ground cloves", models.Ingredient{Quantity: 0.25, Unit: "teaspoon", Name: "ground cloves"}},
+	{"1 tsp smoked paprika", models.Ingredient{Quantity: 1, Unit: "teaspoon", Name: "smoked paprika"}},
+	{"2 tbsp dried oregano", models.Ingredient{Quantity: 2, Unit: "tablespoon", Name: "dried oregano"}},
+	{"8 oz (225g) cream cheese, softened", models.Ingredient{Quantity: 8, Unit: "ounce", Name: "cream cheese", Preparation: "softened, 225g"}},
+	{"2 cups chicken broth (about 500 ml)", models.Ingredient{Quantity: 2, Unit: "cup", Name: "chicken broth", Preparation: "about 500 ml"}},
+	{"Fresh parsley, for garnish", models.Ingredient{Name: "fresh parsley", Preparation: "for garnish"}},
+	{"3 ripe bananas, mashed", models.Ingredient{Quantity: 3, Name: "ripe bananas", Preparation: "mashed"}},
+	{"1 red bell pepper, seeded and cut into strips", models.Ingredient{Quantity: 1, Name: "red bell pepper", Preparation: "seeded and cut into strips"}},
+	{"6 oz baby spinach, roughly torn", models.Ingredient{Quantity: 6, Unit: "ounce", Name: "baby spinach", Preparation: "roughly torn"}},
+	{"1 cup walnuts, toasted and chopped", models.Ingredient{Quantity: 1, Unit: "cup", Name: "walnuts", Preparation: "toasted and chopped"}},
+
+	// Optional ingredients and seasoning.
+	{"1/2 cup chopped walnuts (optional)", models.Ingredient{Quantity: 0.5, Unit: "cup", Name: "walnuts", Preparation: "chopped", Optional: true}},
+	{"1 tsp red pepper flakes, optional", models.Ingredient{Quantity: 1, Unit: "teaspoon", Name: "red pepper flakes", Optional: true}},
+	{"Optional: 2 tbsp capers", models.Ingredient{Quantity: 2, Unit: "tablespoon", Name: "capers", Optional: true}},
+	{"fresh basil, chopped, optional", models.Ingredient{Name: "fresh basil", Preparation: "chopped", Optional: true}},
+	{"1 tbsp sesame seeds, toasted (optional)", models.Ingredient{Quantity: 1, Unit: "tablespoon", Name: "sesame seeds", Preparation: "toasted", Optional: true}},
+	{"Salt, to taste", models.Ingredient{Name: "salt", Preparation: "to taste"}},
+	{"Salt and pepper to taste", models.Ingredient{Name: "salt and pepper", Preparation: "to taste"}},
+	{"Salt and freshly ground black pepper, to taste", models.Ingredient{Name: "salt and freshly ground black pepper", Preparation: "to taste"}},
+	{"Kosher salt", models.Ingredient{Name: "kosher salt"}},
+	{"Cooking spray", models.Ingredient{Name: "cooking spray"}},
+	{"Juice of 1 lemon", models.Ingredient{Name: "juice of 1 lemon"}},
+
+	// Formatting noise.
+	{"  - 2 cups   rolled oats ", models.Ingredient{Quantity: 2, Unit: "cup", Name: "rolled oats"}},
+	{"• 1 tsp cumin", models.Ingredient{Quantity: 1, Unit: "teaspoon", Name: "cumin"}},
+	{"2 CUPS FLOUR", models.Ingredient{Quantity: 2, Unit: "cup", Name: "flour"}},
+	{"2 cloves", models.Ingredient{Quantity: 2, Name: "cloves"}},
+}
+
+func TestParseCorpus(t *testing.T) {
+	for _, tc := range ingredientCases {
+		t.Run(tc.line, func(t *testing.T) {
+			want := tc.want
+			want.Raw = tc.line
+			got := ingredients.Parse(tc.line)
+			assert.InDelta(t, want.Quantity, got.Quantity, 1e-9, "quantity")
+			got.Quantity = want.Quantity
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestParseAllSkipsBlankLines(t *testing.T) {
+	got := ingredients.ParseAll([]string{"1 cup rice", "", "   ", "2 cups water"})
+	assert.Len(t, got, 2)
+	assert.Equal(t, "rice", got[0].Name)
+	assert.Equal(t, "water", got[1].Name)
+}
@@ -0,0 +1,44 @@
+// Package mentions finds @username mentions in user-written text.
+package mentions
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mention matches an @ followed by a username, unless the @ is part of a
+// word such as an email address.
+var mention = regexp.MustCompile(`(?:^|[^\w@])@([\w.-]+)`)
+
+// Parse returns the usernames mentioned in text, in order of first mention
+// and without duplicates. Trailing punctuation is not part of a username, so
+// "thanks @sam." mentions "sam".
+func Parse(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range mention.FindAllStringSubmatch(text, -1) {
+		name := strings.TrimRight(m[1], ".-")
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// Added returns the usernames mentioned in after but not in before, so that
+// editing a text only notifies the newly mentioned users.
+func Added(before, after string) []string {
+	old := make(map[string]bool)
+	for _, name := range Parse(before) {
+		old[name] = true
+	}
+	var added []string
+	for _, name := range Parse(after) {
+		if !old[name] {
+			added = append(added, name)
+		}
+	}
+	return added
+}
@@ -0,0 +1,30 @@
+package mentions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"no mentions here", nil},
+		{"@alice try less salt", []string{"alice"}},
+		{"thanks @bob.", []string{"bob"}},
+		{"@carol, @dan_b and @carol again", []string{"carol", "dan_b"}},
+		{"(cc @e.f-g)", []string{"e.f-g"}},
+		{"mail me at cook@example.com", nil},
+		{"@@nobody or a lone @", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Parse(tt.text), tt.text)
+	}
+}
+
+func TestAdded(t *testing.T) {
+	assert.Equal(t, []string{"carol"}, Added("hi @alice @bob", "hi @bob and @carol"))
+	assert.Empty(t, Added("hi @alice", "hello @alice"))
+}
@@ -37,6 +37,17 @@ func JWTAuth(secret string) gin.HandlerFunc {
 	}
 }
 
+// CurrentUserID returns the ID of the user authenticated by JWTAuth. It
+// writes a 401 response and returns false when there is none.
+func CurrentUserID(c *gin.Context) (string, bool) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return "", false
+	}
+	return userID, true
+}
+
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Write log output to gin.DefaultWriter so tests can capture it.
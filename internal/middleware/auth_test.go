@@ -114,3 +114,22 @@ func TestLoggerMiddleware_Output(t *testing.T) {
 	output := buf.String()
 	assert.True(t, strings.Contains(output, "DEBUG: Logger - request method:"), "Expected logger output to contain debug message")
 }
+
+func TestCurrentUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", "test-user-id")
+
+	userID, ok := middleware.CurrentUserID(c)
+	assert.True(t, ok)
+	assert.Equal(t, "test-user-id", userID)
+
+	// Without an authenticated user it answers 401.
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	_, ok = middleware.CurrentUserID(c)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "user not authenticated")
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Collection roles. The owner of a collection has every permission; other
+// users only reach it through a CollectionMember role.
+const (
+	CollectionRoleOwner        = "owner"        // renames, deletes and shares the collection
+	CollectionRoleCollaborator = "collaborator" // adds, removes and reorders recipes
+	CollectionRoleViewer       = "viewer"       // reads the collection
+)
+
+// Collection is a named, ordered list of recipes curated by a user, such as
+// "Weeknight dinners". It may hold recipes owned by anyone.
+type Collection struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	UserID      string    `json:"user_id" gorm:"index"` // owner
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// Role is the requesting user's CollectionRole constant.
+	Role string `json:"role,omitempty" gorm:"-"`
+	// Recipes are the recipes of the collection the requesting user may read,
+	// in collection order. They are only loaded for a single collection.
+	Recipes []*Recipe `json:"recipes,omitempty" gorm:"-"`
+	// Members lists the users the collection is shared with.
+	Members []CollectionMember `json:"members,omitempty" gorm:"-"`
+}
+
+// CollectionRecipe places a recipe in a collection. Position orders the
+// recipes of a collection, lowest first.
+type CollectionRecipe struct {
+	CollectionID string    `json:"collection_id" gorm:"primaryKey"`
+	RecipeID     string    `json:"recipe_id" gorm:"primaryKey;index"`
+	Position     int       `json:"position"`
+	AddedBy      string    `json:"added_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CollectionMember shares a collection with another user.
+type CollectionMember struct {
+	CollectionID string    `json:"-" gorm:"primaryKey"`
+	UserID       string    `json:"user_id" gorm:"primaryKey;index"`
+	Role         string    `json:"role"` // CollectionRoleViewer or CollectionRoleCollaborator
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CanEditCollection reports whether role may change the recipes of a collection.
+func CanEditCollection(role string) bool {
+	return role == CollectionRoleOwner || role == CollectionRoleCollaborator
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Comment is a message left under a recipe. Top-level comments have no
+// ParentID; replies point at the comment they answer and share its ThreadID,
+// the ID of the top-level comment of the thread.
+type Comment struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	RecipeID string `json:"recipe_id" gorm:"index:idx_comment_recipe_parent,priority:1"`
+	ParentID string `json:"parent_id,omitempty" gorm:"index:idx_comment_recipe_parent,priority:2"`
+	ThreadID string `json:"thread_id" gorm:"index"`
+	Depth    int    `json:"depth"` // 0 for top-level comments
+	UserID   string `json:"user_id"`
+	Body     string `json:"body"`
+	// Deleted marks a comment removed by its author while it had replies; its
+	// body is cleared but it stays in place to keep the thread together.
+	Deleted   bool      `json:"deleted,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// ReplyCount is the number of direct replies, loaded with the comment.
+	ReplyCount int `json:"reply_count" gorm:"-"`
+}
+
+// CommentPage is one page of comments at one level of a thread.
+type CommentPage struct {
+	Comments   []*Comment `json:"comments"`
+	NextCursor string     `json:"next_cursor,omitempty"` // cursor for the following page, if any
+}
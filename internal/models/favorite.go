@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Favorite records that a user saved a recipe. A user favorites a recipe at
+// most once.
+type Favorite struct {
+	UserID    string    `json:"user_id" gorm:"primaryKey"`
+	RecipeID  string    `json:"recipe_id" gorm:"primaryKey;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// RecipeImage is a photo attached to a recipe. The upload is stored
+// re-encoded, without its metadata, alongside resized thumbnails.
+type RecipeImage struct {
+	ID          string         `json:"id" gorm:"primaryKey"`
+	RecipeID    string         `json:"-" gorm:"index"`
+	UserID      string         `json:"user_id"` // uploader
+	ContentType string         `json:"content_type"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	URL         string         `json:"url" gorm:"-"`                                 // set by SetURLs
+	Thumbnails  []ImageVariant `json:"thumbnails" gorm:"serializer:json;type:jsonb"` // smallest first
+	// BlobKeys lists every stored object of the image, the upload first and
+	// then its thumbnails in order, so they can be deleted with it.
+	BlobKeys  StringArray `json:"-" gorm:"type:text[]"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// ImageVariant is a resized copy of a recipe image.
+type ImageVariant struct {
+	Name   string `json:"name"` // size name, e.g. "small"
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url,omitempty"` // set by SetURLs, never stored
+}
+
+// SetURLs fills in the download addresses of the image and its thumbnails
+// from their BlobKeys, using url to turn a key into an address.
+func (image *RecipeImage) SetURLs(url func(key string) string) {
+	if len(image.BlobKeys) == 0 {
+		return
+	}
+	image.URL = url(image.BlobKeys[0])
+	for i := range image.Thumbnails {
+		if i+1 < len(image.BlobKeys) {
+			image.Thumbnails[i].URL = url(image.BlobKeys[i+1])
+		}
+	}
+}
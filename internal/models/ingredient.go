@@ -0,0 +1,20 @@
+package models
+
+// Ingredient is the structured form of a single ingredient line,
+// e.g. "1 1/2 cups finely chopped onion".
+type Ingredient struct {
+	// Quantity is the (lower bound of the) amount; zero when none was given.
+	Quantity float64 `json:"quantity,omitempty"`
+	// QuantityMax is the upper bound for ranges such as "2-3"; zero otherwise.
+	QuantityMax float64 `json:"quantity_max,omitempty"`
+	// Unit is the canonical singular unit name (e.g. "cup", "gram"), if any.
+	Unit string `json:"unit,omitempty"`
+	// Name is the lower-cased ingredient name.
+	Name string `json:"name"`
+	// Preparation holds notes such as "finely chopped" or "minced".
+	Preparation string `json:"preparation,omitempty"`
+	// Optional is set when the line marks the ingredient as optional.
+	Optional bool `json:"optional,omitempty"`
+	// Raw is the original, unparsed line.
+	Raw string `json:"raw"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// All returns one value of every model stored in the database, in an order
+// AutoMigrate and DropTable accept. Migrations and test setups use it so new
+// tables are picked up everywhere at once.
+func All() []interface{} {
+	return []interface{}{
+		&User{}, &Recipe{}, &RecipeRevision{}, &Tag{}, &RecipeTag{}, &RecipeImage{}, &ShareLink{},
+		&Collection{}, &CollectionRecipe{}, &CollectionMember{}, &Favorite{}, &Review{}, &Comment{}, &Notification{},
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+// NutritionEstimate is the result of calculating a recipe's nutrition from
+// its structured ingredients.
+type NutritionEstimate struct {
+	PerServing NutritionalInfo `json:"per_serving"`
+	Total      NutritionalInfo `json:"total"`
+	Servings   int             `json:"servings"` // servings PerServing was divided by; at least 1
+	// Ingredients lists the contribution of each ingredient that was counted.
+	Ingredients []IngredientNutrition `json:"ingredients"`
+	// Unmatched lists the ingredients left out of the totals and why.
+	Unmatched []UnmatchedIngredient `json:"unmatched"`
+	// Complete reports whether every ingredient that matters was counted:
+	// only optional and to-taste ingredients may be missing. Only complete
+	// estimates are stored as a recipe's nutrition.
+	Complete bool `json:"complete"`
+}
+
+// IngredientNutrition is what one ingredient line contributes to a recipe.
+type IngredientNutrition struct {
+	Raw       string          `json:"raw"`
+	Food      string          `json:"food"`  // entry of the nutrient table it was matched to
+	Grams     float64         `json:"grams"` // estimated weight of the ingredient
+	Nutrients NutritionalInfo `json:"nutrients"`
+}
+
+// UnmatchedIngredient is an ingredient line the calculator could not count.
+type UnmatchedIngredient struct {
+	Raw    string `json:"raw"`
+	Reason string `json:"reason"`
+}
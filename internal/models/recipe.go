@@ -1,8 +1,13 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // NutritionalInfo represents nutritional information for a recipe.
+// Values stored on a recipe are per serving.
 type NutritionalInfo struct {
 	Calories      float64 `json:"calories"`
 	Protein       float64 `json:"protein"`
@@ -11,35 +16,170 @@ type NutritionalInfo struct {
 	Fiber         float64 `json:"fiber"`
 }
 
+// Scaled returns a copy of the nutritional values multiplied by factor.
+func (n NutritionalInfo) Scaled(factor float64) NutritionalInfo {
+	return NutritionalInfo{
+		Calories:      n.Calories * factor,
+		Protein:       n.Protein * factor,
+		Carbohydrates: n.Carbohydrates * factor,
+		Fat:           n.Fat * factor,
+		Fiber:         n.Fiber * factor,
+	}
+}
+
 // Recipe represents the domain model for a recipe.
 type Recipe struct {
-	ID                string          `json:"id" gorm:"primaryKey"`
-	Title             string          `json:"title"`
-	Ingredients       []string        `json:"ingredients" gorm:"type:text[]"`
-	Steps             []string        `json:"steps" gorm:"type:text[]"`
-	NutritionalInfo   NutritionalInfo `json:"nutritional_info" gorm:"embedded;embeddedPrefix:nutri_"`
-	AllergyDisclaimer string          `json:"allergy_disclaimer"`
-	Appliances        []string        `json:"appliances" gorm:"type:text[]"`
-	CreatedAt         time.Time       `json:"created_at"` // time of creation
-	UpdatedAt         time.Time       `json:"updated_at"` // time of last update
-	UserID            string          `json:"user_id,omitempty"`
+	ID                    string           `json:"id" gorm:"primaryKey"`
+	Title                 string           `json:"title"`
+	Ingredients           StringArray      `json:"ingredients" gorm:"type:text[]"`
+	StructuredIngredients []Ingredient     `json:"structured_ingredients,omitempty" gorm:"serializer:json;type:jsonb"` // parsed from Ingredients
+	Steps                 StringArray      `json:"steps" gorm:"type:text[]"`
+	StructuredSteps       []Step           `json:"structured_steps,omitempty" gorm:"serializer:json;type:jsonb"` // parsed from Steps
+	PrepMinutes           int              `json:"prep_minutes"`                                                 // time of steps that apply no heat
+	CookMinutes           int              `json:"cook_minutes"`                                                 // time of steps that apply heat
+	TotalMinutes          int              `json:"total_minutes" gorm:"index"`                                   // PrepMinutes plus CookMinutes; 0 when unknown
+	Servings              int              `json:"servings,omitempty"`                                           // number of servings the quantities yield
+	NutritionalInfo       NutritionalInfo  `json:"nutritional_info" gorm:"embedded;embeddedPrefix:nutri_"`
+	TotalNutritionalInfo  *NutritionalInfo `json:"total_nutritional_info,omitempty" gorm:"-"` // whole-recipe totals, set on scaled copies
+	AllergyDisclaimer     string           `json:"allergy_disclaimer"`
+	Allergens             StringArray      `json:"allergens,omitempty" gorm:"type:text[]"` // allergen groups detected in Ingredients
+	Appliances            StringArray      `json:"appliances" gorm:"type:text[]"`
+	Tags                  []Tag            `json:"tags,omitempty" gorm:"-"`   // loaded from recipe_tags
+	Images                []RecipeImage    `json:"images,omitempty" gorm:"-"` // loaded from recipe_images
+	FavoriteCount         int              `json:"favorite_count" gorm:"-"`   // users who favorited the recipe
+	IsFavorited           bool             `json:"is_favorited" gorm:"-"`     // whether the requesting user favorited it
+	CreatedAt             time.Time        `json:"created_at"`                // time of creation
+	UpdatedAt             time.Time        `json:"updated_at"`                // time of last update
+	UserID                string           `json:"user_id,omitempty"`
+	// DeletedAt is set while the recipe is in its owner's trash. GORM leaves
+	// such recipes out of queries unless they are made Unscoped.
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	// ParentRecipeID is the recipe this one was forked from. It is kept when
+	// the parent is deleted, so it may no longer resolve.
+	ParentRecipeID string `json:"parent_recipe_id,omitempty" gorm:"index"`
+	// OriginalUserID credits the author of the first recipe in a fork chain.
+	OriginalUserID string `json:"original_user_id,omitempty"`
+	// Visibility is one of the Visibility constants and controls who may
+	// read the recipe.
+	Visibility string `json:"visibility" gorm:"default:public;index"`
+	// Rating summarises the recipe's reviews. It is maintained by the
+	// repository as reviews change and never written by recipe updates.
+	Rating RatingSummary `json:"rating" gorm:"embedded;embeddedPrefix:rating_"`
+	// ContentHash fingerprints the content of imported recipes so re-imports
+	// can skip records that are already stored. It is empty for recipes
+	// created through the API.
+	ContentHash string `json:"-" gorm:"index"`
 }
 
+// Recipe visibility levels. Owners can always read their own recipes.
+const (
+	VisibilityPrivate  = "private"  // only the owner, or holders of a share link
+	VisibilityUnlisted = "unlisted" // anyone with the ID, but left out of listings
+	VisibilityPublic   = "public"   // anyone, and included in listings
+)
+
+// ValidVisibility reports whether v is one of the Visibility constants.
+func ValidVisibility(v string) bool {
+	switch v {
+	case VisibilityPrivate, VisibilityUnlisted, VisibilityPublic:
+		return true
+	}
+	return false
+}
+
+// IsFork reports whether the recipe was forked from another recipe.
+func (r *Recipe) IsFork() bool {
+	return r.ParentRecipeID != ""
+}
+
+// Sort orders accepted in RecipeQueryRequest.Sort.
+const (
+	SortNewest    = "newest"    // most recently created first
+	SortTitle     = "title"     // alphabetical by title
+	SortRelevance = "relevance" // best full-text match first; requires Query
+	SortRating    = "rating"    // highest average rating first
+)
+
+// Total count modes accepted in RecipeQueryRequest.TotalMode.
+const (
+	TotalExact    = "exact"    // count every matching recipe
+	TotalEstimate = "estimate" // use the query planner's row estimate
+	TotalNone     = "none"     // skip counting
+)
+
 // RecipeQueryRequest carries parameters for querying recipes.
 // An empty Query denotes a simple listing, while a non-empty value
-// triggers advanced search logic.
+// triggers advanced search logic. UserID restricts results to one owner.
+//
+// Results are paged either by Page/Limit or, preferably, by passing a
+// NextCursor or PrevCursor from a previous response as Cursor; a cursor
+// takes precedence over Page.
 type RecipeQueryRequest struct {
-	Query  string `json:"query"`
-	UserID string `json:"user_id,omitempty"`
-	Filter string `json:"filter,omitempty"`
-	Page   int    `json:"page,omitempty"`
-	Limit  int    `json:"limit,omitempty"`
+	Query   string        `json:"query" form:"query"`
+	UserID  string        `json:"user_id,omitempty" form:"user_id"`
+	Filter  string        `json:"filter,omitempty" form:"filter"`
+	Filters RecipeFilters `json:"filters,omitempty"`
+	Page    int           `json:"page,omitempty" form:"page"`
+	Limit   int           `json:"limit,omitempty" form:"limit"`
+	Cursor  string        `json:"cursor,omitempty" form:"cursor"`
+	// Sort is one of the Sort constants; it defaults to relevance for text
+	// queries and newest otherwise.
+	Sort string `json:"sort,omitempty" form:"sort"`
+	// TotalMode is one of the Total constants and defaults to TotalExact.
+	TotalMode string `json:"total_mode,omitempty" form:"total"`
+	// IncludeFacets requests facet counts over all matching recipes.
+	IncludeFacets bool `json:"include_facets,omitempty" form:"facets"`
+	// ViewerID is the authenticated caller. Results contain public recipes
+	// plus the viewer's own; it is set by the server, never by clients.
+	ViewerID string `json:"-" form:"-"`
+}
+
+// RecipeFilters holds structured filters applied on top of the query text.
+// Nil range bounds are open; appliance and allergen names match case-insensitively.
+type RecipeFilters struct {
+	Appliances        []string `json:"appliances,omitempty" form:"appliance"`                 // recipes must use all of these
+	ExcludeAppliances []string `json:"exclude_appliances,omitempty" form:"exclude_appliance"` // recipes must use none of these
+	ExcludeAllergens  []string `json:"exclude_allergens,omitempty" form:"exclude_allergen"`   // e.g. "peanut", "gluten"
+	Tags              []string `json:"tags,omitempty" form:"tag"`                             // recipes must have all of these, e.g. "diet:vegan"
+	MinCalories       *float64 `json:"min_calories,omitempty" form:"min_calories"`
+	MaxCalories       *float64 `json:"max_calories,omitempty" form:"max_calories"`
+	MinProtein        *float64 `json:"min_protein,omitempty" form:"min_protein"`
+	MaxProtein        *float64 `json:"max_protein,omitempty" form:"max_protein"`
+	MinCarbohydrates  *float64 `json:"min_carbohydrates,omitempty" form:"min_carbohydrates"`
+	MaxCarbohydrates  *float64 `json:"max_carbohydrates,omitempty" form:"max_carbohydrates"`
+	MinFat            *float64 `json:"min_fat,omitempty" form:"min_fat"`
+	MaxFat            *float64 `json:"max_fat,omitempty" form:"max_fat"`
+	// Time ceilings in minutes, e.g. MaxTotalMinutes 30 for "under 30
+	// minutes". Recipes whose time is unknown never match them.
+	MaxPrepMinutes  *int `json:"max_prep_minutes,omitempty" form:"max_prep_minutes"`
+	MaxCookMinutes  *int `json:"max_cook_minutes,omitempty" form:"max_cook_minutes"`
+	MaxTotalMinutes *int `json:"max_total_minutes,omitempty" form:"max_total_minutes"`
 }
 
 // RecipeQueryResponse represents the response structure for recipe queries.
 type RecipeQueryResponse struct {
-	Recipes []*Recipe `json:"recipes"`
-	Page    int       `json:"page"`  // current page number
-	Limit   int       `json:"limit"` // number of recipes per page
-	Total   int       `json:"total"` // total recipes matching the query
+	Recipes []*Recipe     `json:"recipes"`
+	Page    int           `json:"page"`             // current page number
+	Limit   int           `json:"limit"`            // number of recipes per page
+	Total   int           `json:"total"`            // total recipes matching the query; 0 when not counted
+	Facets  *RecipeFacets `json:"facets,omitempty"` // set when IncludeFacets was requested
+
+	TotalMode  string `json:"total_mode"`            // how Total was computed
+	NextCursor string `json:"next_cursor,omitempty"` // cursor for the following page, if any
+	PrevCursor string `json:"prev_cursor,omitempty"` // cursor for the preceding page, if any
+}
+
+// RecipeFacets summarises all recipes matching a query, for rendering filters.
+type RecipeFacets struct {
+	Appliances map[string]int `json:"appliances"` // lower-cased appliance name -> number of recipes
+	Calories   []RangeFacet   `json:"calories"`   // recipes per calorie band
+}
+
+// RangeFacet counts the recipes whose value falls in [Min, Max).
+// A nil bound is open.
+type RangeFacet struct {
+	Label string   `json:"label"`
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int      `json:"count"`
 }
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Review is a user's star rating of a recipe with optional review text. Each
+// user reviews a recipe at most once and edits that review afterwards.
+type Review struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	RecipeID  string    `json:"recipe_id" gorm:"uniqueIndex:idx_review_recipe_user,priority:1"`
+	UserID    string    `json:"user_id" gorm:"uniqueIndex:idx_review_recipe_user,priority:2;index"`
+	Rating    int       `json:"rating"` // 1 to 5 stars
+	Body      string    `json:"body,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RatingSummary aggregates the reviews of a recipe. It is stored on the
+// recipe and kept up to date as reviews change, so recipes can be sorted by
+// rating without reading their reviews.
+type RatingSummary struct {
+	Average   float64         `json:"average" gorm:"index"` // 0 when unrated
+	Count     int             `json:"count"`
+	Total     int             `json:"-"` // sum of all ratings
+	Histogram RatingHistogram `json:"histogram" gorm:"embedded;embeddedPrefix:stars_"`
+}
+
+// RatingHistogram counts the reviews giving each number of stars.
+type RatingHistogram struct {
+	One   int `json:"1"`
+	Two   int `json:"2"`
+	Three int `json:"3"`
+	Four  int `json:"4"`
+	Five  int `json:"5"`
+}
+
+// ReviewListResponse is one page of a recipe's reviews with its rating summary.
+type ReviewListResponse struct {
+	Reviews []*Review     `json:"reviews"`
+	Summary RatingSummary `json:"summary"`
+	Page    int           `json:"page"`
+	Limit   int           `json:"limit"`
+	Total   int           `json:"total"`
+}
@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+// Revision actions recorded in RecipeRevision.Action.
+const (
+	RevisionCreate = "create"
+	RevisionUpdate = "update"
+	RevisionRevert = "revert"
+	RevisionFork   = "fork"
+	RevisionImport = "import"
+)
+
+// RecipeRevision is an immutable record of a recipe's content after a change.
+// Revisions are numbered from 1 per recipe in the order they were made.
+type RecipeRevision struct {
+	ID            string         `json:"id" gorm:"primaryKey"`
+	RecipeID      string         `json:"recipe_id" gorm:"uniqueIndex:idx_recipe_revision_number,priority:1"`
+	Number        int            `json:"number" gorm:"uniqueIndex:idx_recipe_revision_number,priority:2"`
+	UserID        string         `json:"user_id"` // user who made the change
+	Action        string         `json:"action"`  // one of the Revision actions
+	RevertedFrom  int            `json:"reverted_from,omitempty"`
+	ChangedFields StringArray    `json:"changed_fields" gorm:"type:text[]"`
+	Snapshot      RecipeSnapshot `json:"snapshot" gorm:"serializer:json;type:jsonb"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// RecipeSnapshot captures the user-editable content of a recipe.
+type RecipeSnapshot struct {
+	Title             string          `json:"title"`
+	Ingredients       []string        `json:"ingredients"`
+	Steps             []string        `json:"steps"`
+	Servings          int             `json:"servings,omitempty"`
+	NutritionalInfo   NutritionalInfo `json:"nutritional_info"`
+	AllergyDisclaimer string          `json:"allergy_disclaimer,omitempty"`
+	Appliances        []string        `json:"appliances"`
+	Tags              []string        `json:"tags,omitempty"` // tag references, see Tag.Ref
+}
+
+// Snapshot returns a copy of the recipe's user-editable content.
+func (r *Recipe) Snapshot() RecipeSnapshot {
+	return RecipeSnapshot{
+		Title:             r.Title,
+		Ingredients:       append([]string(nil), r.Ingredients...),
+		Steps:             append([]string(nil), r.Steps...),
+		Servings:          r.Servings,
+		NutritionalInfo:   r.NutritionalInfo,
+		AllergyDisclaimer: r.AllergyDisclaimer,
+		Appliances:        append([]string(nil), r.Appliances...),
+		Tags:              TagRefs(r.Tags),
+	}
+}
+
+// RecipeDiff describes what changed between two revisions of a recipe.
+// Scalar fields are only set when they differ.
+type RecipeDiff struct {
+	From              int          `json:"from"`
+	To                int          `json:"to"`
+	Title             *ValueChange `json:"title,omitempty"`
+	Servings          *ValueChange `json:"servings,omitempty"`
+	NutritionalInfo   *ValueChange `json:"nutritional_info,omitempty"`
+	AllergyDisclaimer *ValueChange `json:"allergy_disclaimer,omitempty"`
+	Ingredients       ListDiff     `json:"ingredients"`
+	Steps             ListDiff     `json:"steps"`
+	Appliances        ListDiff     `json:"appliances"`
+	Tags              ListDiff     `json:"tags"`
+}
+
+// ValueChange holds the old and new value of a field.
+type ValueChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ListDiff describes changes to an ordered list. An entry replaced in place
+// is reported as Changed rather than as a removal plus an addition.
+type ListDiff struct {
+	Added   []ListEntry  `json:"added,omitempty"`
+	Removed []ListEntry  `json:"removed,omitempty"`
+	Changed []ListChange `json:"changed,omitempty"`
+}
+
+// ListEntry is an item at a zero-based position. Positions of added items
+// refer to the new list and positions of removed items to the old one.
+type ListEntry struct {
+	Index int    `json:"index"`
+	Text  string `json:"text"`
+}
+
+// ListChange is an item edited in place; Index is its position in the new list.
+type ListChange struct {
+	Index int    `json:"index"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Empty reports whether the list is unchanged.
+func (d ListDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
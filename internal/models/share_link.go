@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// ShareLink grants read access to a single recipe, whatever its visibility,
+// to anyone holding its token until it expires or is revoked.
+type ShareLink struct {
+	ID        string     `json:"id" gorm:"primaryKey"`
+	RecipeID  string     `json:"recipe_id" gorm:"index"`
+	UserID    string     `json:"user_id"` // owner of the recipe who created the link
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	// Token is the signed credential placed in the share URL. It is derived
+	// from the link rather than stored.
+	Token string `json:"token,omitempty" gorm:"-"`
+}
+
+// Active reports whether the link can still be used at time now.
+func (l *ShareLink) Active(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
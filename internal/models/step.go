@@ -0,0 +1,44 @@
+package models
+
+// Step is the structured form of a single preparation step,
+// e.g. "Bake 25 minutes at 180°C until golden".
+type Step struct {
+	// Text is the original step text.
+	Text string `json:"text"`
+	// ActiveMinutes is hands-on time, such as stirring or kneading.
+	ActiveMinutes float64 `json:"active_minutes,omitempty"`
+	// PassiveMinutes is unattended time, such as baking, resting or chilling.
+	PassiveMinutes float64 `json:"passive_minutes,omitempty"`
+	// Cooking is set when the step applies heat; its time counts as cook
+	// time rather than prep time.
+	Cooking bool `json:"cooking,omitempty"`
+	// Temperature is the oven or cooking temperature, if one is given.
+	Temperature *Temperature `json:"temperature,omitempty"`
+	// Ingredients names the recipe's structured ingredients the step uses.
+	Ingredients []string `json:"ingredients,omitempty"`
+}
+
+// Minutes returns the step's total active and passive time.
+func (s Step) Minutes() float64 {
+	return s.ActiveMinutes + s.PassiveMinutes
+}
+
+// Temperature is a temperature in degrees of Unit.
+type Temperature struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"` // TemperatureCelsius or TemperatureFahrenheit
+}
+
+// Temperature units.
+const (
+	TemperatureCelsius    = "C"
+	TemperatureFahrenheit = "F"
+)
+
+// Celsius returns the temperature in degrees Celsius.
+func (t Temperature) Celsius() float64 {
+	if t.Unit == TemperatureFahrenheit {
+		return (t.Value - 32) * 5 / 9
+	}
+	return t.Value
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tag kinds. Cuisine, course, diet and occasion tags come from the controlled
+// vocabularies in TagVocabularies; user tags are free-form.
+const (
+	TagKindCuisine  = "cuisine"
+	TagKindCourse   = "course"
+	TagKindDiet     = "diet"
+	TagKindOccasion = "occasion"
+	TagKindUser     = "tag"
+)
+
+// TagVocabularies lists the display names allowed for each controlled tag kind.
+var TagVocabularies = map[string][]string{
+	TagKindCuisine: {
+		"American", "British", "Caribbean", "Chinese", "French", "German", "Greek",
+		"Indian", "Italian", "Japanese", "Korean", "Mediterranean", "Mexican",
+		"Middle Eastern", "Spanish", "Thai", "Vietnamese",
+	},
+	TagKindCourse: {
+		"Breakfast", "Appetizer", "Soup", "Salad", "Main", "Side", "Dessert",
+		"Snack", "Drink",
+	},
+	TagKindDiet: {
+		"Vegetarian", "Vegan", "Pescatarian", "Gluten-Free", "Dairy-Free",
+		"Nut-Free", "Low-Carb", "Keto", "Paleo", "Halal", "Kosher",
+	},
+	TagKindOccasion: {
+		"Weeknight", "Brunch", "Party", "Picnic", "Holiday", "Thanksgiving",
+		"Christmas", "Meal Prep",
+	},
+}
+
+// Tag classifies recipes. Its ID is "<kind>:<slug>", e.g. "cuisine:italian",
+// so the same tag is shared by every recipe that uses it.
+type Tag struct {
+	ID   string `json:"id" gorm:"primaryKey"`
+	Kind string `json:"kind" gorm:"index"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// Ref returns the "kind:name" reference that ParseTag reads back into t.
+func (t Tag) Ref() string {
+	return t.Kind + ":" + t.Name
+}
+
+// RecipeTag links a recipe to one of its tags.
+type RecipeTag struct {
+	RecipeID string `gorm:"primaryKey"`
+	TagID    string `gorm:"primaryKey;index"`
+}
+
+// TagCount is a tag together with the number of recipes using it.
+type TagCount struct {
+	Tag
+	RecipeCount int `json:"recipe_count"`
+}
+
+// ParseTag reads a tag reference of the form "kind:name", such as
+// "cuisine:Italian" or "diet:gluten-free". References without a known kind
+// prefix are free-form user tags. It does not check the vocabularies.
+func ParseTag(ref string) Tag {
+	kind, name := TagKindUser, strings.TrimSpace(ref)
+	if prefix, rest, ok := strings.Cut(name, ":"); ok {
+		prefix = strings.ToLower(strings.TrimSpace(prefix))
+		if _, controlled := TagVocabularies[prefix]; controlled || prefix == TagKindUser {
+			kind, name = prefix, strings.TrimSpace(rest)
+		}
+	}
+	slug := TagSlug(name)
+	return Tag{ID: kind + ":" + slug, Kind: kind, Slug: slug, Name: name}
+}
+
+// ParseTags reads a list of tag references with ParseTag.
+func ParseTags(refs []string) []Tag {
+	if len(refs) == 0 {
+		return nil
+	}
+	tags := make([]Tag, len(refs))
+	for i, ref := range refs {
+		tags[i] = ParseTag(ref)
+	}
+	return tags
+}
+
+// TagRefs returns the references of tags, or nil if there are none.
+func TagRefs(tags []Tag) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	refs := make([]string, len(tags))
+	for i, tag := range tags {
+		refs[i] = tag.Ref()
+	}
+	return refs
+}
+
+// TagSlug lower-cases name and joins its words with hyphens, dropping
+// punctuation: "Middle Eastern" becomes "middle-eastern".
+func TagSlug(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		default:
+			hyphen = true
+		}
+	}
+	return b.String()
+}
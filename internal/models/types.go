@@ -0,0 +1,103 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// StringArray is a []string that is stored as a Postgres text[] column.
+// It encodes to and decodes from the Postgres array literal format ({"a","b"}),
+// which also lets SQLite (used in tests) persist it as plain text.
+type StringArray []string
+
+// Value implements driver.Valuer.
+func (a StringArray) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, s := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		for _, r := range s {
+			if r == '"' || r == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (a *StringArray) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into StringArray", src)
+	}
+	parsed, err := parseArrayLiteral(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// parseArrayLiteral decodes a one-dimensional Postgres array literal.
+func parseArrayLiteral(s string) ([]string, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("invalid array literal: %q", s)
+	}
+	body := s[1 : len(s)-1]
+	result := []string{}
+	if body == "" {
+		return result, nil
+	}
+
+	var cur strings.Builder
+	quoted, inQuotes, escaped := false, false, false
+	for _, r := range body {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case r == ',' && !inQuotes:
+			result = append(result, arrayElement(cur.String(), quoted))
+			cur.Reset()
+			quoted = false
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuotes || escaped {
+		return nil, fmt.Errorf("invalid array literal: %q", s)
+	}
+	return append(result, arrayElement(cur.String(), quoted)), nil
+}
+
+// arrayElement normalizes an unquoted element; quoted elements are taken verbatim.
+func arrayElement(s string, quoted bool) string {
+	if quoted {
+		return s
+	}
+	return strings.TrimSpace(s)
+}
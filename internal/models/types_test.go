@@ -0,0 +1,56 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringArrayRoundTrip(t *testing.T) {
+	cases := []models.StringArray{
+		{},
+		{"flour"},
+		{"1 egg, beaten", "salt & pepper"},
+		{`say "cheese"`, `back\slash`, "{braces}", "  padded  "},
+	}
+	for _, in := range cases {
+		v, err := in.Value()
+		assert.NoError(t, err)
+
+		var out models.StringArray
+		assert.NoError(t, out.Scan(v))
+		assert.Equal(t, in, out)
+
+		// Drivers may hand the literal back as bytes.
+		var fromBytes models.StringArray
+		assert.NoError(t, fromBytes.Scan([]byte(v.(string))))
+		assert.Equal(t, in, fromBytes)
+	}
+}
+
+func TestStringArrayScanPostgresLiteral(t *testing.T) {
+	// Postgres only quotes elements that need it.
+	var out models.StringArray
+	assert.NoError(t, out.Scan(`{Oven,"Stand mixer","a,b"}`))
+	assert.Equal(t, models.StringArray{"Oven", "Stand mixer", "a,b"}, out)
+
+	assert.NoError(t, out.Scan(nil))
+	assert.Nil(t, out)
+
+	assert.Error(t, out.Scan(`{"unterminated}`))
+	assert.Error(t, out.Scan(42))
+}
+
+func TestParseTag(t *testing.T) {
+	cases := map[string]models.Tag{
+		"cuisine:Middle Eastern": {ID: "cuisine:middle-eastern", Kind: "cuisine", Slug: "middle-eastern", Name: "Middle Eastern"},
+		" Diet : gluten-free ":   {ID: "diet:gluten-free", Kind: "diet", Slug: "gluten-free", Name: "gluten-free"},
+		"Date Night!":            {ID: "tag:date-night", Kind: "tag", Slug: "date-night", Name: "Date Night!"},
+		"ratio 1:2":              {ID: "tag:ratio-1-2", Kind: "tag", Slug: "ratio-1-2", Name: "ratio 1:2"},
+		"tag:crème brûlée":       {ID: "tag:crème-brûlée", Kind: "tag", Slug: "crème-brûlée", Name: "crème brûlée"},
+	}
+	for ref, want := range cases {
+		assert.Equal(t, want, models.ParseTag(ref), ref)
+	}
+}
@@ -5,7 +5,12 @@ All Rights Reserved.
 // internal/models/user.go
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
+)
 
 type User struct {
 	ID           string    `gorm:"type:uuid;primaryKey" json:"id"`
@@ -16,3 +21,22 @@ type User struct {
 	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
+
+// UnitSystemPreference is the key in Preferences that selects the measurement
+// system recipes are displayed in ("metric" or "imperial").
+const UnitSystemPreference = "unit_system"
+
+// UnitSystem returns the user's preferred measurement system. Missing,
+// malformed or unknown preferences yield units.Original.
+func (u *User) UnitSystem() units.System {
+	var prefs map[string]interface{}
+	if err := json.Unmarshal([]byte(u.Preferences), &prefs); err != nil {
+		return units.Original
+	}
+	name, _ := prefs[UnitSystemPreference].(string)
+	system, err := units.ParseSystem(name)
+	if err != nil {
+		return units.Original
+	}
+	return system
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,3 +33,18 @@ func TestUserModelInitialization(t *testing.T) {
 	assert.False(t, user.CreatedAt.IsZero(), "CreatedAt should be set")
 	assert.False(t, user.UpdatedAt.IsZero(), "UpdatedAt should be set")
 }
+
+func TestUserUnitSystem(t *testing.T) {
+	cases := map[string]units.System{
+		`{"unit_system":"metric"}`:   units.Metric,
+		`{"unit_system":"Imperial"}`: units.Imperial,
+		`{"diet":"vegan"}`:           units.Original,
+		`{"unit_system":"cubits"}`:   units.Original,
+		`{"unit_system":42}`:         units.Original,
+		``:                           units.Original,
+	}
+	for prefs, want := range cases {
+		user := models.User{Preferences: prefs}
+		assert.Equal(t, want, user.UnitSystem(), prefs)
+	}
+}
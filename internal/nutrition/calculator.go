@@ -0,0 +1,161 @@
+// Package nutrition estimates a recipe's nutrition from its structured
+// ingredients using a bundled table of per-100 g nutrient values.
+package nutrition
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
+)
+
+// Reasons reported for ingredients left out of an estimate.
+const (
+	ReasonUnknownFood   = "not in the nutrient table"
+	ReasonNoQuantity    = "no quantity given"
+	ReasonToTaste       = "to taste"
+	ReasonUnknownAmount = "amount cannot be converted to grams"
+	ReasonOptional      = "optional"
+)
+
+// pieceUnits are count units meaning one whole item of the food, weighed
+// with Food.PieceGrams. The empty unit covers lines like "2 eggs".
+var pieceUnits = map[string]bool{"": true, "piece": true, "clove": true, "slice": true, "stalk": true}
+
+// fixedUnitGrams weighs count units whose size does not depend on the food.
+var fixedUnitGrams = map[string]float64{
+	"pinch": 0.3, "dash": 0.6, "drop": 0.05, "handful": 30, "can": 400,
+}
+
+// Calculate estimates the nutrition of a recipe that yields servings
+// servings; values below 1 count as one serving. Ranges such as "2-3 eggs"
+// use the midpoint. Ingredients that cannot be matched to the table or
+// weighed are listed in Unmatched rather than guessed. Seasonings added "to
+// taste" are listed too but do not make the estimate incomplete.
+func Calculate(ingredients []models.Ingredient, servings int) *models.NutritionEstimate {
+	estimate := &models.NutritionEstimate{
+		Servings:    max(servings, 1),
+		Ingredients: []models.IngredientNutrition{},
+		Unmatched:   []models.UnmatchedIngredient{},
+	}
+	var total models.NutritionalInfo
+	for _, ing := range ingredients {
+		if ing.Optional {
+			estimate.Unmatched = append(estimate.Unmatched, models.UnmatchedIngredient{Raw: ing.Raw, Reason: ReasonOptional})
+			continue
+		}
+		food, ok := Lookup(ing.Name)
+		if !ok {
+			estimate.Unmatched = append(estimate.Unmatched, models.UnmatchedIngredient{Raw: ing.Raw, Reason: ReasonUnknownFood})
+			continue
+		}
+		if ing.Quantity == 0 {
+			reason := ReasonNoQuantity
+			if strings.Contains(ing.Preparation, "to taste") {
+				reason = ReasonToTaste
+			}
+			estimate.Unmatched = append(estimate.Unmatched, models.UnmatchedIngredient{Raw: ing.Raw, Reason: reason})
+			continue
+		}
+		g, ok := grams(ing, food)
+		if !ok {
+			estimate.Unmatched = append(estimate.Unmatched, models.UnmatchedIngredient{Raw: ing.Raw, Reason: ReasonUnknownAmount})
+			continue
+		}
+		nutrients := food.Per100g.Scaled(g / 100)
+		total = add(total, nutrients)
+		estimate.Ingredients = append(estimate.Ingredients, models.IngredientNutrition{
+			Raw:       ing.Raw,
+			Food:      food.Name,
+			Grams:     round(g),
+			Nutrients: roundAll(nutrients),
+		})
+	}
+	estimate.Complete = len(estimate.Ingredients) > 0
+	for _, u := range estimate.Unmatched {
+		if u.Reason != ReasonOptional && u.Reason != ReasonToTaste {
+			estimate.Complete = false
+		}
+	}
+	estimate.Total = roundAll(total)
+	estimate.PerServing = roundAll(total.Scaled(1 / float64(estimate.Servings)))
+	return estimate
+}
+
+// Fill sets recipe.NutritionalInfo to the value calculated from its
+// structured ingredients when it is blank or equals previous, the value
+// calculated for the recipe before it was edited. Nutrition entered by hand
+// or supplied by the resolver is kept. Incomplete estimates would understate
+// the nutrition, so the value is left blank unless the estimate is complete.
+func Fill(recipe *models.Recipe, previous models.NutritionalInfo) {
+	if n := recipe.NutritionalInfo; n != (models.NutritionalInfo{}) && n != previous {
+		return
+	}
+	estimate := Calculate(recipe.StructuredIngredients, recipe.Servings)
+	if estimate.Complete {
+		recipe.NutritionalInfo = estimate.PerServing
+	} else {
+		recipe.NutritionalInfo = models.NutritionalInfo{}
+	}
+}
+
+// grams estimates the weight of an ingredient.
+func grams(ing models.Ingredient, food *Food) (float64, bool) {
+	qty := ing.Quantity
+	if ing.QuantityMax > qty {
+		qty = (qty + ing.QuantityMax) / 2
+	}
+	if g, ok := fixedUnitGrams[ing.Unit]; ok {
+		return qty * g, true
+	}
+	if pieceUnits[ing.Unit] {
+		return qty * food.PieceGrams, food.PieceGrams > 0
+	}
+	unit, ok := units.Lookup(ing.Unit)
+	if !ok {
+		return 0, false
+	}
+	switch unit.Dimension {
+	case units.Mass:
+		return qty * unit.Base, true
+	case units.Volume:
+		density := food.GramsPerMilliliter
+		if density == 0 {
+			d, ok := units.DensityOf(food.Name)
+			if !ok {
+				return 0, false
+			}
+			density = d.GramsPerMilliliter
+		}
+		return qty * unit.Base * density, true
+	}
+	return 0, false
+}
+
+// add sums two sets of nutrients.
+func add(a, b models.NutritionalInfo) models.NutritionalInfo {
+	return models.NutritionalInfo{
+		Calories:      a.Calories + b.Calories,
+		Protein:       a.Protein + b.Protein,
+		Carbohydrates: a.Carbohydrates + b.Carbohydrates,
+		Fat:           a.Fat + b.Fat,
+		Fiber:         a.Fiber + b.Fiber,
+	}
+}
+
+// roundAll rounds every nutrient to one decimal place.
+func roundAll(n models.NutritionalInfo) models.NutritionalInfo {
+	return models.NutritionalInfo{
+		Calories:      round(n.Calories),
+		Protein:       round(n.Protein),
+		Carbohydrates: round(n.Carbohydrates),
+		Fat:           round(n.Fat),
+		Fiber:         round(n.Fiber),
+	}
+}
+
+// round rounds v to one decimal place.
+func round(v float64) float64 {
+	return math.Round(v*10) / 10
+}
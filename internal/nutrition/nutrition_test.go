@@ -0,0 +1,113 @@
+package nutrition_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/nutrition"
+)
+
+func TestLookup(t *testing.T) {
+	cases := map[string]string{
+		"all-purpose flour":         "all-purpose flour",
+		"Chopped Tomatoes":          "canned tomatoes",
+		"tomatoes":                  "tomato",
+		"extra virgin olive oil":    "olive oil",
+		"low-sodium chicken broth":  "stock",
+		"boneless chicken breasts":  "chicken breast",
+		"pepper jack cheese":        "cheddar",
+		"jalapeño peppers":          "jalapeño",
+		"egg noodles":               "egg noodles",
+		"large eggs":                "egg",
+		"crushed red pepper flakes": "red pepper flakes",
+	}
+	for name, want := range cases {
+		food, ok := nutrition.Lookup(name)
+		if assert.True(t, ok, name) {
+			assert.Equal(t, want, food.Name, name)
+		}
+	}
+	_, ok := nutrition.Lookup("dragon fruit")
+	assert.False(t, ok)
+}
+
+func TestCalculate(t *testing.T) {
+	lines := []string{
+		"2 cups all-purpose flour",    // 2 × 236.588 ml × 0.53 g/ml
+		"2 eggs",                      // 2 × 50 g
+		"100 g butter",                // by weight
+		"1 cup milk",                  // 236.588 ml × 1.03 g/ml
+		"salt, to taste",              // no quantity, but seasoning
+		"1 cup dragon fruit",          // not in the table
+		"2 bunches parsley",           // bunch size unknown
+		"1/4 cup walnuts (optional)",  // optional
+		"1-3 pinches ground cinnamon", // midpoint of the range
+	}
+	estimate := nutrition.Calculate(ingredients.ParseAll(lines), 4)
+
+	assert.Equal(t, 4, estimate.Servings)
+	require.Len(t, estimate.Ingredients, 5)
+	flour := estimate.Ingredients[0]
+	assert.Equal(t, "all-purpose flour", flour.Food)
+	assert.Equal(t, 250.8, flour.Grams)
+	assert.Equal(t, 912.9, flour.Nutrients.Calories)
+	assert.Equal(t, 100.0, estimate.Ingredients[1].Grams)
+	assert.Equal(t, 0.6, estimate.Ingredients[4].Grams)
+
+	// 912.9 + 143 + 717 + 148.7 + 1.5 kcal.
+	assert.InDelta(t, 1923.0, estimate.Total.Calories, 0.2)
+	assert.InDelta(t, estimate.Total.Calories/4, estimate.PerServing.Calories, 0.1)
+
+	assert.Equal(t, []models.UnmatchedIngredient{
+		{Raw: "salt, to taste", Reason: nutrition.ReasonToTaste},
+		{Raw: "1 cup dragon fruit", Reason: nutrition.ReasonUnknownFood},
+		{Raw: "2 bunches parsley", Reason: nutrition.ReasonUnknownAmount},
+		{Raw: "1/4 cup walnuts (optional)", Reason: nutrition.ReasonOptional},
+	}, estimate.Unmatched)
+	assert.False(t, estimate.Complete, "dragon fruit and parsley are missing")
+
+	empty := nutrition.Calculate(nil, 0)
+	assert.Equal(t, 1, empty.Servings)
+	assert.Empty(t, empty.Ingredients)
+	assert.False(t, empty.Complete)
+}
+
+func TestFill(t *testing.T) {
+	recipe := &models.Recipe{Servings: 2, StructuredIngredients: ingredients.ParseAll([]string{"200 g rolled oats"})}
+	nutrition.Fill(recipe, models.NutritionalInfo{})
+	assert.Equal(t, models.NutritionalInfo{Calories: 379, Protein: 13.2, Carbohydrates: 67.7, Fat: 6.5, Fiber: 10.1}, recipe.NutritionalInfo)
+
+	// A value calculated earlier is recalculated...
+	previous := recipe.NutritionalInfo
+	recipe.Servings = 4
+	nutrition.Fill(recipe, previous)
+	assert.Equal(t, 189.5, recipe.NutritionalInfo.Calories)
+
+	// ...but one entered by hand is kept.
+	recipe.NutritionalInfo = models.NutritionalInfo{Calories: 150}
+	nutrition.Fill(recipe, previous)
+	assert.Equal(t, 150.0, recipe.NutritionalInfo.Calories)
+
+	// Nothing is set when no ingredient can be counted...
+	unknown := &models.Recipe{StructuredIngredients: ingredients.ParseAll([]string{"1 dragon fruit"})}
+	nutrition.Fill(unknown, models.NutritionalInfo{})
+	assert.Equal(t, models.NutritionalInfo{}, unknown.NutritionalInfo)
+
+	// ...or when only some of them can, and a calculated value is cleared.
+	recipe.Servings = 2
+	previous = models.NutritionalInfo{}
+	nutrition.Fill(recipe, previous)
+	previous = recipe.NutritionalInfo
+	recipe.StructuredIngredients = ingredients.ParseAll([]string{"200 g rolled oats", "1 dragon fruit"})
+	nutrition.Fill(recipe, previous)
+	assert.Equal(t, models.NutritionalInfo{}, recipe.NutritionalInfo)
+
+	// Optional and to-taste ingredients do not count against completeness.
+	recipe.StructuredIngredients = ingredients.ParseAll([]string{"200 g rolled oats", "salt, to taste", "1 dragon fruit (optional)"})
+	nutrition.Fill(recipe, models.NutritionalInfo{})
+	assert.Equal(t, 379.0, recipe.NutritionalInfo.Calories)
+}
@@ -0,0 +1,143 @@
+package nutrition
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// Food is an entry of the nutrient table.
+type Food struct {
+	Name string
+	// Per100g holds the nutrients in 100 grams of the food.
+	Per100g models.NutritionalInfo
+	// PieceGrams is the weight of one whole item, such as an egg or a clove
+	// of garlic; zero when the food is not counted in pieces.
+	PieceGrams float64
+	// GramsPerMilliliter is the density used for volume measures; zero falls
+	// back to the densities in pkg/units.
+	GramsPerMilliliter float64
+}
+
+//go:embed foods.csv
+var foodsCSV string
+
+// foods indexes the table by normalized name and alias.
+var foods = mustLoadFoods(foodsCSV)
+
+// maxKeyWords is the number of words in the longest name or alias.
+var maxKeyWords = longestKey(foods)
+
+// mustLoadFoods parses the bundled table; it panics on malformed data, which
+// the package tests catch.
+func mustLoadFoods(data string) map[string]*Food {
+	index, err := loadFoods(data)
+	if err != nil {
+		panic(fmt.Sprintf("nutrition: bundled table: %v", err))
+	}
+	return index
+}
+
+// loadFoods parses a nutrient table with the columns name, aliases
+// ("|"-separated), calories, protein, carbohydrates, fat, fiber, piece_g and
+// g_per_ml. Lines starting with # are comments.
+func loadFoods(data string) (map[string]*Food, error) {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = 9
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]*Food)
+	for i, row := range rows {
+		if i == 0 {
+			continue // header
+		}
+		var values [7]float64
+		for j := range values {
+			if row[j+2] == "" {
+				continue
+			}
+			if values[j], err = strconv.ParseFloat(row[j+2], 64); err != nil {
+				return nil, fmt.Errorf("%s: %w", row[0], err)
+			}
+		}
+		food := &Food{
+			Name: row[0],
+			Per100g: models.NutritionalInfo{
+				Calories: values[0], Protein: values[1], Carbohydrates: values[2], Fat: values[3], Fiber: values[4],
+			},
+			PieceGrams:         values[5],
+			GramsPerMilliliter: values[6],
+		}
+		names := []string{row[0]}
+		if row[1] != "" {
+			names = append(names, strings.Split(row[1], "|")...)
+		}
+		for _, name := range names {
+			words := keyWords(name)
+			key := strings.Join(words, " ")
+			if other, ok := index[key]; ok {
+				return nil, fmt.Errorf("%q is listed under both %s and %s", name, other.Name, food.Name)
+			}
+			index[key] = food
+		}
+	}
+	return index, nil
+}
+
+// longestKey returns the number of words in the longest key of index.
+func longestKey(index map[string]*Food) int {
+	n := 0
+	for key := range index {
+		n = max(n, len(strings.Fields(key)))
+	}
+	return n
+}
+
+// Lookup finds the table entry for an ingredient name. The longest name or
+// alias whose words appear in the ingredient wins; between equally long
+// matches the later one wins, as the last noun of a name is usually the
+// food ("pepper jack cheese" is cheese).
+func Lookup(name string) (*Food, bool) {
+	words := keyWords(name)
+	for n := min(maxKeyWords, len(words)); n > 0; n-- {
+		for i := len(words) - n; i >= 0; i-- {
+			if food, ok := foods[strings.Join(words[i:i+n], " ")]; ok {
+				return food, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// keyWords lower-cases name, splits it into words and makes each singular,
+// so "Chopped Tomatoes" and "chopped tomato" compare equal.
+func keyWords(name string) []string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for i, word := range words {
+		words[i] = singular(word)
+	}
+	return words
+}
+
+// singular strips a regular English plural ending from word.
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "ches"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && len(word) > 3:
+		return word[:len(word)-1]
+	}
+	return word
+}
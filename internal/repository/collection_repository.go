@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CollectionRepository defines the data access interface for recipe collections.
+type CollectionRepository interface {
+	// CreateCollection persists a new collection.
+	CreateCollection(collection *models.Collection) error
+	// UpdateCollection saves the name and description of a collection.
+	UpdateCollection(collection *models.Collection) error
+	// DeleteCollection removes a collection with its recipe list and members.
+	DeleteCollection(collectionID string) error
+	// GetCollection retrieves a collection by its unique ID, without its
+	// recipes or members.
+	GetCollection(collectionID string) (*models.Collection, error)
+	// ListCollections returns the collections userID owns or is a member of,
+	// most recently updated first.
+	ListCollections(userID string) ([]*models.Collection, error)
+	// ListCollectionRecipes returns the recipes of a collection that viewerID
+	// may read, in collection order. Recipes in the trash are left out.
+	ListCollectionRecipes(collectionID, viewerID string) ([]*models.Recipe, error)
+	// CollectionRecipeIDs returns the IDs of every recipe in a collection, in
+	// collection order.
+	CollectionRecipeIDs(collectionID string) ([]string, error)
+	// AddCollectionRecipe appends a recipe to a collection. Adding a recipe
+	// that is already there leaves its position unchanged.
+	AddCollectionRecipe(entry *models.CollectionRecipe) error
+	// RemoveCollectionRecipe takes a recipe out of a collection.
+	RemoveCollectionRecipe(collectionID, recipeID string) error
+	// SetCollectionOrder renumbers the recipes of a collection in the order
+	// of recipeIDs, which must list every recipe of the collection.
+	SetCollectionOrder(collectionID string, recipeIDs []string) error
+	// GetCollectionMember retrieves userID's membership of a collection.
+	GetCollectionMember(collectionID, userID string) (*models.CollectionMember, error)
+	// ListCollectionMembers returns the members of a collection, oldest first.
+	ListCollectionMembers(collectionID string) ([]models.CollectionMember, error)
+	// ListMemberships returns every collection membership of userID.
+	ListMemberships(userID string) ([]models.CollectionMember, error)
+	// SaveCollectionMember adds a member to a collection or changes their role.
+	SaveCollectionMember(member *models.CollectionMember) error
+	// RemoveCollectionMember stops sharing a collection with userID.
+	RemoveCollectionMember(collectionID, userID string) error
+}
+
+// collectionRepository implements CollectionRepository.
+type collectionRepository struct {
+	db    *gorm.DB
+	media blobstore.BlobStore
+}
+
+// NewCollectionRepository returns an implementation of CollectionRepository.
+// Image URLs of listed recipes are resolved with media, which may be nil.
+func NewCollectionRepository(db *gorm.DB, media blobstore.BlobStore) CollectionRepository {
+	return &collectionRepository{db: db, media: media}
+}
+
+// CreateCollection inserts a collection row.
+func (r *collectionRepository) CreateCollection(collection *models.Collection) error {
+	return r.db.Create(collection).Error
+}
+
+// UpdateCollection writes the editable columns of a collection back to the database.
+func (r *collectionRepository) UpdateCollection(collection *models.Collection) error {
+	return r.db.Model(collection).Select("name", "description", "updated_at").Updates(collection).Error
+}
+
+// DeleteCollection deletes a collection, its recipe entries and its
+// members in one transaction. It returns gorm.ErrRecordNotFound when no
+// collection matched.
+func (r *collectionRepository) DeleteCollection(collectionID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.CollectionRecipe{}, "collection_id = ?", collectionID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.CollectionMember{}, "collection_id = ?", collectionID).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Collection{}, "id = ?", collectionID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// GetCollection retrieves a collection by its ID.
+func (r *collectionRepository) GetCollection(collectionID string) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.db.First(&collection, "id = ?", collectionID).Error; err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// ListCollections returns the collections owned by or shared with userID.
+func (r *collectionRepository) ListCollections(userID string) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	err := r.db.
+		Where("user_id = ? OR id IN (?)", userID,
+			r.db.Model(&models.CollectionMember{}).Select("collection_id").Where("user_id = ?", userID)).
+		Order("updated_at DESC, id DESC").
+		Find(&collections).Error
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// ListCollectionRecipes joins the collection's entries with the recipes
+// readable by viewerID.
+func (r *collectionRepository) ListCollectionRecipes(collectionID, viewerID string) ([]*models.Recipe, error) {
+	var recipes []*models.Recipe
+	err := readableBy(r.db.Model(&models.Recipe{}), viewerID).
+		Joins("JOIN collection_recipes ON collection_recipes.recipe_id = recipes.id").
+		Where("collection_recipes.collection_id = ?", collectionID).
+		Order("collection_recipes.position, collection_recipes.created_at").
+		Find(&recipes).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := loadDetails(r.db, r.media, recipes, viewerID); err != nil {
+		return nil, err
+	}
+	return recipes, nil
+}
+
+// CollectionRecipeIDs returns the recipe IDs of a collection in order,
+// including recipes hidden from the caller.
+func (r *collectionRepository) CollectionRecipeIDs(collectionID string) ([]string, error) {
+	var ids []string
+	err := r.db.Model(&models.CollectionRecipe{}).
+		Where("collection_id = ?", collectionID).
+		Order("position, created_at").
+		Pluck("recipe_id", &ids).Error
+	return ids, err
+}
+
+// AddCollectionRecipe inserts the entry after the last recipe of the
+// collection, ignoring recipes that are already present.
+func (r *collectionRepository) AddCollectionRecipe(entry *models.CollectionRecipe) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last *int
+		if err := tx.Model(&models.CollectionRecipe{}).
+			Where("collection_id = ?", entry.CollectionID).
+			Select("MAX(position)").Scan(&last).Error; err != nil {
+			return err
+		}
+		if last != nil {
+			entry.Position = *last + 1
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(entry).Error; err != nil {
+			return err
+		}
+		return touchCollection(tx, entry.CollectionID)
+	})
+}
+
+// RemoveCollectionRecipe deletes a recipe entry. It returns
+// gorm.ErrRecordNotFound when the recipe was not in the collection.
+func (r *collectionRepository) RemoveCollectionRecipe(collectionID, recipeID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.CollectionRecipe{}, "collection_id = ? AND recipe_id = ?", collectionID, recipeID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return touchCollection(tx, collectionID)
+	})
+}
+
+// SetCollectionOrder stores each recipe's index in recipeIDs as its position.
+func (r *collectionRepository) SetCollectionOrder(collectionID string, recipeIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range recipeIDs {
+			err := tx.Model(&models.CollectionRecipe{}).
+				Where("collection_id = ? AND recipe_id = ?", collectionID, id).
+				Update("position", i).Error
+			if err != nil {
+				return err
+			}
+		}
+		return touchCollection(tx, collectionID)
+	})
+}
+
+// GetCollectionMember retrieves a membership row.
+func (r *collectionRepository) GetCollectionMember(collectionID, userID string) (*models.CollectionMember, error) {
+	var member models.CollectionMember
+	if err := r.db.First(&member, "collection_id = ? AND user_id = ?", collectionID, userID).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ListMemberships returns the membership rows of userID.
+func (r *collectionRepository) ListMemberships(userID string) ([]models.CollectionMember, error) {
+	var members []models.CollectionMember
+	if err := r.db.Where("user_id = ?", userID).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ListCollectionMembers returns the membership rows of a collection.
+func (r *collectionRepository) ListCollectionMembers(collectionID string) ([]models.CollectionMember, error) {
+	var members []models.CollectionMember
+	err := r.db.Where("collection_id = ?", collectionID).Order("created_at, user_id").Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// SaveCollectionMember upserts a membership row, updating the role of an
+// existing member.
+func (r *collectionRepository) SaveCollectionMember(member *models.CollectionMember) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "collection_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).Create(member).Error
+}
+
+// RemoveCollectionMember deletes a membership row. It returns
+// gorm.ErrRecordNotFound when userID was not a member.
+func (r *collectionRepository) RemoveCollectionMember(collectionID, userID string) error {
+	result := r.db.Delete(&models.CollectionMember{}, "collection_id = ? AND user_id = ?", collectionID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// touchCollection bumps the updated_at column of a collection after its
+// recipes changed.
+func touchCollection(tx *gorm.DB, collectionID string) error {
+	return tx.Model(&models.Collection{}).Where("id = ?", collectionID).Update("updated_at", tx.NowFunc()).Error
+}
@@ -0,0 +1,86 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+)
+
+func TestCollectionRepository(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models.All()...))
+	recipes := repository.NewRecipeRepository(db, nil)
+	repo := repository.NewCollectionRepository(db, nil)
+
+	for _, r := range []*models.Recipe{
+		{ID: "soup", Title: "Soup", UserID: "alice"},
+		{ID: "bread", Title: "Bread", UserID: "bob"},
+		{ID: "secret", Title: "Secret", UserID: "alice", Visibility: models.VisibilityPrivate},
+	} {
+		require.NoError(t, recipes.CreateRecipe(r, nil))
+	}
+	require.NoError(t, repo.CreateCollection(&models.Collection{ID: "c1", UserID: "alice", Name: "Weeknight dinners"}))
+	require.NoError(t, repo.CreateCollection(&models.Collection{ID: "c2", UserID: "carol", Name: "Holiday baking"}))
+
+	for _, id := range []string{"soup", "bread", "secret", "soup"} {
+		require.NoError(t, repo.AddCollectionRecipe(&models.CollectionRecipe{CollectionID: "c1", RecipeID: id, AddedBy: "alice"}))
+	}
+	ids, err := repo.CollectionRecipeIDs("c1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"soup", "bread", "secret"}, ids, "re-adding keeps the first position")
+
+	got, err := repo.ListCollectionRecipes("c1", "bob")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"soup", "bread"}, recipeIDs(got), "private recipes are hidden from other users")
+
+	require.NoError(t, repo.SetCollectionOrder("c1", []string{"secret", "bread", "soup"}))
+	got, err = repo.ListCollectionRecipes("c1", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"secret", "bread", "soup"}, recipeIDs(got))
+
+	require.NoError(t, repo.RemoveCollectionRecipe("c1", "bread"))
+	assert.ErrorIs(t, repo.RemoveCollectionRecipe("c1", "bread"), gorm.ErrRecordNotFound)
+
+	// Members see shared collections; saving again changes the role.
+	require.NoError(t, repo.SaveCollectionMember(&models.CollectionMember{CollectionID: "c2", UserID: "alice", Role: models.CollectionRoleViewer}))
+	require.NoError(t, repo.SaveCollectionMember(&models.CollectionMember{CollectionID: "c2", UserID: "alice", Role: models.CollectionRoleCollaborator}))
+	member, err := repo.GetCollectionMember("c2", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, models.CollectionRoleCollaborator, member.Role)
+	listed, err := repo.ListCollections("alice")
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+	listed, err = repo.ListCollections("bob")
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	memberships, err := repo.ListMemberships("alice")
+	require.NoError(t, err)
+	if assert.Len(t, memberships, 1) {
+		assert.Equal(t, "c2", memberships[0].CollectionID)
+		assert.Equal(t, models.CollectionRoleCollaborator, memberships[0].Role)
+	}
+
+	// Trashed recipes drop out of collections, and purging removes their entries.
+	require.NoError(t, recipes.DeleteRecipe("soup"))
+	got, err = repo.ListCollectionRecipes("c1", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"secret"}, recipeIDs(got))
+	_, _, err = recipes.PurgeDeletedRecipes(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	ids, err = repo.CollectionRecipeIDs("c1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"secret"}, ids)
+
+	require.NoError(t, repo.DeleteCollection("c2"))
+	_, err = repo.GetCollectionMember("c2", "alice")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, repo.DeleteCollection("c2"), gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// CommentRepository defines the data access interface for recipe comments.
+type CommentRepository interface {
+	// CreateComment persists a new comment or reply.
+	CreateComment(comment *models.Comment) error
+	// GetComment retrieves a comment by its unique ID with its reply count.
+	GetComment(commentID string) (*models.Comment, error)
+	// UpdateComment saves the body of a comment.
+	UpdateComment(comment *models.Comment) error
+	// DeleteComment removes a comment. Comments with replies are kept as
+	// deleted placeholders instead; it reports which happened.
+	DeleteComment(commentID string) (placeholder bool, err error)
+	// ListComments returns one page of the direct replies to parentID, or of
+	// the top-level comments of a recipe when parentID is empty, oldest first.
+	// cursor is empty for the first page or a NextCursor from a previous page.
+	ListComments(recipeID, parentID, cursor string, limit int) (*models.CommentPage, error)
+}
+
+// commentRepository implements CommentRepository.
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository returns an implementation of CommentRepository.
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// commentCursor is the decoded form of the cursors handed out by
+// ListComments: the sort keys of the last comment of a page.
+type commentCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"id"`
+}
+
+// CreateComment inserts a comment row.
+func (r *commentRepository) CreateComment(comment *models.Comment) error {
+	return r.db.Create(comment).Error
+}
+
+// GetComment retrieves a comment by its ID.
+func (r *commentRepository) GetComment(commentID string) (*models.Comment, error) {
+	var comment models.Comment
+	if err := r.db.First(&comment, "id = ?", commentID).Error; err != nil {
+		return nil, err
+	}
+	if err := loadReplyCounts(r.db, []*models.Comment{&comment}); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// UpdateComment writes the body, deleted flag and update time of a comment.
+func (r *commentRepository) UpdateComment(comment *models.Comment) error {
+	return r.db.Model(comment).Select("body", "deleted", "updated_at").Updates(comment).Error
+}
+
+// DeleteComment deletes a comment without replies, together with any
+// deleted placeholders above it left without replies, or turns a comment
+// with replies into a placeholder. It returns gorm.ErrRecordNotFound when
+// the comment does not exist.
+func (r *commentRepository) DeleteComment(commentID string) (bool, error) {
+	placeholder := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var comment models.Comment
+		if err := tx.First(&comment, "id = ?", commentID).Error; err != nil {
+			return err
+		}
+		var replies int64
+		if err := tx.Model(&models.Comment{}).Where("parent_id = ?", comment.ID).Count(&replies).Error; err != nil {
+			return err
+		}
+		if replies > 0 {
+			placeholder = true
+			comment.Body = ""
+			comment.Deleted = true
+			return tx.Model(&comment).Select("body", "deleted", "updated_at").Updates(&comment).Error
+		}
+		for {
+			if err := tx.Delete(&comment).Error; err != nil {
+				return err
+			}
+			if comment.ParentID == "" {
+				return nil
+			}
+			parentID := comment.ParentID
+			comment = models.Comment{}
+			err := tx.Where("id = ? AND deleted = ?", parentID, true).
+				Where("NOT EXISTS (?)", tx.Model(&models.Comment{}).Select("1").Where("parent_id = ?", parentID)).
+				First(&comment).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				return err
+			}
+		}
+	})
+	return placeholder, err
+}
+
+// ListComments reads one page of comments at a level of a thread by keyset
+// on (created_at, id), fetching one extra row to tell whether another page
+// follows.
+func (r *commentRepository) ListComments(recipeID, parentID, cursor string, limit int) (*models.CommentPage, error) {
+	q := r.db.Where("recipe_id = ? AND parent_id = ?", recipeID, parentID)
+	if cursor != "" {
+		c, err := decodeCommentCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where("(created_at > ? OR (created_at = ? AND id > ?))", c.CreatedAt, c.CreatedAt, c.ID)
+	}
+	var comments []*models.Comment
+	if err := q.Order("created_at, id").Limit(limit + 1).Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	page := &models.CommentPage{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		last := page.Comments[limit-1]
+		raw, _ := json.Marshal(commentCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		page.NextCursor = base64.RawURLEncoding.EncodeToString(raw)
+	}
+	if err := loadReplyCounts(r.db, page.Comments); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// decodeCommentCursor parses a cursor issued by ListComments.
+func decodeCommentCursor(s string) (*commentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c commentCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// loadReplyCounts fills in the ReplyCount of each comment with one query.
+func loadReplyCounts(db *gorm.DB, comments []*models.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	byID := make(map[string]*models.Comment, len(comments))
+	ids := make([]string, len(comments))
+	for i, comment := range comments {
+		byID[comment.ID] = comment
+		ids[i] = comment.ID
+	}
+	var counts []struct {
+		ParentID string
+		Count    int
+	}
+	if err := db.Model(&models.Comment{}).
+		Select("parent_id, COUNT(*) AS count").
+		Where("parent_id IN ?", ids).
+		Group("parent_id").
+		Scan(&counts).Error; err != nil {
+		return err
+	}
+	for _, c := range counts {
+		byID[c.ParentID].ReplyCount = c.Count
+	}
+	return nil
+}
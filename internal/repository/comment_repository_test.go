@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+)
+
+func TestCommentRepository(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models.All()...))
+	repo := repository.NewCommentRepository(db)
+
+	// Five top-level comments created in order, with a reply thread on c0.
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("c%d", i)
+		require.NoError(t, repo.CreateComment(&models.Comment{ID: id, RecipeID: "r1", ThreadID: id, UserID: "alice", Body: "top", CreatedAt: start.Add(time.Duration(i) * time.Minute)}))
+	}
+	require.NoError(t, repo.CreateComment(&models.Comment{ID: "reply", RecipeID: "r1", ParentID: "c0", ThreadID: "c0", Depth: 1, UserID: "bob", Body: "reply"}))
+	require.NoError(t, repo.CreateComment(&models.Comment{ID: "nested", RecipeID: "r1", ParentID: "reply", ThreadID: "c0", Depth: 2, UserID: "alice", Body: "nested"}))
+	require.NoError(t, repo.CreateComment(&models.Comment{ID: "elsewhere", RecipeID: "r2", ThreadID: "elsewhere", UserID: "alice", Body: "other recipe"}))
+
+	var ids []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5)
+		page, err := repo.ListComments("r1", "", cursor, 2)
+		require.NoError(t, err)
+		for _, c := range page.Comments {
+			ids = append(ids, c.ID)
+			if c.ID == "c0" {
+				assert.Equal(t, 1, c.ReplyCount)
+			}
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"c0", "c1", "c2", "c3", "c4"}, ids)
+	_, err = repo.ListComments("r1", "", "not-a-cursor", 2)
+	assert.ErrorIs(t, err, repository.ErrInvalidCursor)
+
+	replies, err := repo.ListComments("r1", "c0", "", 10)
+	require.NoError(t, err)
+	require.Len(t, replies.Comments, 1)
+	assert.Equal(t, 1, replies.Comments[0].ReplyCount)
+
+	comment, err := repo.GetComment("c1")
+	require.NoError(t, err)
+	comment.Body = "edited"
+	require.NoError(t, repo.UpdateComment(comment))
+	comment, err = repo.GetComment("c1")
+	require.NoError(t, err)
+	assert.Equal(t, "edited", comment.Body)
+
+	// Deleting a comment with replies leaves a placeholder, which goes once
+	// its last reply is deleted.
+	placeholder, err := repo.DeleteComment("reply")
+	require.NoError(t, err)
+	assert.True(t, placeholder)
+	comment, err = repo.GetComment("reply")
+	require.NoError(t, err)
+	assert.True(t, comment.Deleted)
+	assert.Empty(t, comment.Body)
+
+	placeholder, err = repo.DeleteComment("nested")
+	require.NoError(t, err)
+	assert.False(t, placeholder)
+	_, err = repo.GetComment("reply")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	comment, err = repo.GetComment("c0")
+	require.NoError(t, err, "live ancestors are kept")
+	assert.Zero(t, comment.ReplyCount)
+
+	_, err = repo.DeleteComment("nested")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"log"
 
+	"github.com/google/uuid"
 	"github.com/pageza/recipe-book-api-v2/internal/models"
 	"gorm.io/gorm"
 )
@@ -24,6 +25,7 @@ func (r *NotificationRepository) SaveNotification(userID, message string) error
 	}
 
 	notification := models.Notification{
+		ID:      uuid.New().String(),
 		UserID:  userID,
 		Message: message,
 		Status:  "sent",
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/pageza/recipe-book-api-v2/internal/allergens"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// BackfillRecipeAllergens detects the allergens of every stored recipe,
+// including those in the trash, and writes back the allergens and generated
+// disclaimer wherever they differ from what is stored. Recipes saved before
+// allergens were detected would otherwise pass every allergen filter. It
+// returns how many recipes were updated and is safe to run repeatedly.
+func BackfillRecipeAllergens(db *gorm.DB) (int, error) {
+	updated := 0
+	var batch []*models.Recipe
+	result := db.Unscoped().Model(&models.Recipe{}).FindInBatches(&batch, backfillBatchSize, func(_ *gorm.DB, _ int) error {
+		for _, recipe := range batch {
+			before := *recipe
+			allergens.Annotate(recipe, before.Allergens)
+			if slices.Equal(recipe.Allergens, before.Allergens) && recipe.AllergyDisclaimer == before.AllergyDisclaimer {
+				continue
+			}
+			err := db.Unscoped().Model(recipe).
+				Select("allergens", "allergy_disclaimer").
+				UpdateColumns(recipe).Error
+			if err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	return updated, result.Error
+}
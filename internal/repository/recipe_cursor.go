@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded or
+// was issued for a different sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// recipeCursor is the decoded form of the opaque cursors handed to clients.
+// It records the sort keys of the row at the edge of a page; the next page
+// starts strictly after it (or, when Backward is set, strictly before it).
+type recipeCursor struct {
+	Sort      string    `json:"s"`
+	Backward  bool      `json:"b,omitempty"`
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"c,omitempty"`
+	Title     string    `json:"t,omitempty"`
+	Rank      float64   `json:"r,omitempty"`
+	Rating    float64   `json:"a,omitempty"`
+}
+
+// encode serialises the cursor as URL-safe base64 JSON.
+func (c recipeCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses a cursor produced by encode and checks it matches sort.
+func decodeCursor(s, sort string) (*recipeCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c recipeCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != sort {
+		return nil, fmt.Errorf("%w: issued for sort %q", ErrInvalidCursor, c.Sort)
+	}
+	return &c, nil
+}
+
+// rankedRecipe is a query row carrying the full-text rank alongside the recipe.
+type rankedRecipe struct {
+	models.Recipe `gorm:"embedded"`
+	SearchRank    float64
+}
+
+// recipeOrder describes a keyset sort: a leading key with its direction,
+// broken by the recipe ID in the same direction so the order is total.
+type recipeOrder struct {
+	sort   string
+	column clause.Expr
+	desc   bool
+	// set copies the leading key of a row into a cursor, and get reads it back.
+	set func(*recipeCursor, *rankedRecipe)
+	get func(*recipeCursor) interface{}
+}
+
+// orderFor returns the keyset order for a request. Relevance needs full-text
+// ranking, so without a query or off Postgres it falls back to newest first.
+func orderFor(db *gorm.DB, req *models.RecipeQueryRequest) recipeOrder {
+	sort := req.Sort
+	if sort == models.SortRelevance && (req.Query == "" || db.Dialector.Name() != "postgres") {
+		sort = models.SortNewest
+	}
+	switch sort {
+	case models.SortTitle:
+		return recipeOrder{
+			sort:   sort,
+			column: clause.Expr{SQL: "title"},
+			set:    func(c *recipeCursor, r *rankedRecipe) { c.Title = r.Title },
+			get:    func(c *recipeCursor) interface{} { return c.Title },
+		}
+	case models.SortRating:
+		return recipeOrder{
+			sort:   sort,
+			column: clause.Expr{SQL: "rating_average"},
+			desc:   true,
+			set:    func(c *recipeCursor, r *rankedRecipe) { c.Rating = r.Rating.Average },
+			get:    func(c *recipeCursor) interface{} { return c.Rating },
+		}
+	case models.SortRelevance:
+		return recipeOrder{
+			sort:   sort,
+			column: rankExpr(req.Query),
+			desc:   true,
+			set:    func(c *recipeCursor, r *rankedRecipe) { c.Rank = r.SearchRank },
+			get:    func(c *recipeCursor) interface{} { return c.Rank },
+		}
+	default:
+		return recipeOrder{
+			sort:   models.SortNewest,
+			column: clause.Expr{SQL: "created_at"},
+			desc:   true,
+			set:    func(c *recipeCursor, r *rankedRecipe) { c.CreatedAt = r.CreatedAt },
+			get:    func(c *recipeCursor) interface{} { return c.CreatedAt },
+		}
+	}
+}
+
+// apply orders q by the keyset, reversed when reading backwards.
+func (o recipeOrder) apply(q *gorm.DB, backward bool) *gorm.DB {
+	dir := "ASC"
+	if o.desc != backward {
+		dir = "DESC"
+	}
+	return q.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "? " + dir + ", id " + dir,
+		Vars: []interface{}{o.column},
+	}})
+}
+
+// after restricts q to rows strictly past the cursor in reading direction.
+func (o recipeOrder) after(q *gorm.DB, c *recipeCursor) *gorm.DB {
+	op := ">"
+	if o.desc != c.Backward {
+		op = "<"
+	}
+	value := o.get(c)
+	return q.Where("(? "+op+" ? OR (? = ? AND id "+op+" ?))", o.column, value, o.column, value, c.ID)
+}
+
+// cursor builds the cursor for row r, to be read in the given direction.
+func (o recipeOrder) cursor(r *rankedRecipe, backward bool) string {
+	c := recipeCursor{Sort: o.sort, Backward: backward, ID: r.ID}
+	o.set(&c, r)
+	return c.encode()
+}
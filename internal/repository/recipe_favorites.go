@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AddFavorite records that userID favorited a recipe. Favoriting a recipe
+// twice keeps the original record.
+func (r *recipeRepository) AddFavorite(userID, recipeID string) error {
+	favorite := &models.Favorite{UserID: userID, RecipeID: recipeID}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(favorite).Error
+}
+
+// RemoveFavorite deletes a favorite. It returns gorm.ErrRecordNotFound when
+// userID had not favorited the recipe.
+func (r *recipeRepository) RemoveFavorite(userID, recipeID string) error {
+	result := r.db.Delete(&models.Favorite{}, "user_id = ? AND recipe_id = ?", userID, recipeID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListFavorites returns one page of the recipes userID favorited and can
+// still read, most recently favorited first, with the number of such recipes.
+func (r *recipeRepository) ListFavorites(userID string, page, limit int) ([]*models.Recipe, int, error) {
+	favorited := func() *gorm.DB {
+		return readableBy(r.db.Model(&models.Recipe{}), userID).
+			Joins("JOIN favorites ON favorites.recipe_id = recipes.id").
+			Where("favorites.user_id = ?", userID)
+	}
+	var total int64
+	if err := favorited().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var recipes []*models.Recipe
+	err := favorited().
+		Order("favorites.created_at DESC, recipes.id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&recipes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := loadDetails(r.db, r.media, recipes, userID); err != nil {
+		return nil, 0, err
+	}
+	return recipes, int(total), nil
+}
+
+// loadFavorites fills in the FavoriteCount of each recipe, and IsFavorited
+// when viewerID is set, with one query each regardless of the number of
+// recipes.
+func loadFavorites(db *gorm.DB, recipes []*models.Recipe, viewerID string) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+	byID := make(map[string]*models.Recipe, len(recipes))
+	ids := make([]string, len(recipes))
+	for i, recipe := range recipes {
+		byID[recipe.ID] = recipe
+		ids[i] = recipe.ID
+	}
+	var counts []struct {
+		RecipeID string
+		Count    int
+	}
+	if err := db.Model(&models.Favorite{}).
+		Select("recipe_id, COUNT(*) AS count").
+		Where("recipe_id IN ?", ids).
+		Group("recipe_id").
+		Scan(&counts).Error; err != nil {
+		return err
+	}
+	for _, c := range counts {
+		byID[c.RecipeID].FavoriteCount = c.Count
+	}
+	if viewerID == "" {
+		return nil
+	}
+	var mine []string
+	if err := db.Model(&models.Favorite{}).
+		Where("user_id = ? AND recipe_id IN ?", viewerID, ids).
+		Pluck("recipe_id", &mine).Error; err != nil {
+		return err
+	}
+	for _, id := range mine {
+		byID[id].IsFavorited = true
+	}
+	return nil
+}
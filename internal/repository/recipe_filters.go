@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pageza/recipe-book-api-v2/internal/allergens"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// calorieBands are the per-serving calorie ranges reported as facets.
+var calorieBands = []models.RangeFacet{
+	{Label: "under 300", Max: float64Ptr(300)},
+	{Label: "300-500", Min: float64Ptr(300), Max: float64Ptr(500)},
+	{Label: "500-800", Min: float64Ptr(500), Max: float64Ptr(800)},
+	{Label: "800 and over", Min: float64Ptr(800)},
+}
+
+// filterRecipes applies the structured filters to q.
+// Appliances are stored as text[] on Postgres and as an array literal
+// ({"Oven","Stove"}) elsewhere, so membership tests differ per dialect.
+func filterRecipes(q *gorm.DB, f *models.RecipeFilters) *gorm.DB {
+	isPostgres := q.Dialector.Name() == "postgres"
+	for _, ref := range f.Tags {
+		if tag := models.ParseTag(ref); tag.Slug != "" {
+			q = q.Where(tagCondition, tag.ID)
+		}
+	}
+	for _, name := range f.Appliances {
+		if cond, arg, ok := applianceCondition(isPostgres, name); ok {
+			q = q.Where(cond, arg)
+		}
+	}
+	for _, name := range f.ExcludeAppliances {
+		if cond, arg, ok := applianceCondition(isPostgres, name); ok {
+			q = q.Where("NOT ("+cond+")", arg)
+		}
+	}
+	for _, allergen := range f.ExcludeAllergens {
+		if cond, args, ok := allergenCondition(isPostgres, allergen); ok {
+			q = q.Where("NOT ("+cond+")", args...)
+		}
+	}
+
+	// Recipes whose nutrition is unknown store zero calories; they must not
+	// pass for light recipes, so any nutrient range needs known nutrition.
+	ranges := []struct {
+		column   string
+		min, max *float64
+	}{
+		{"nutri_calories", f.MinCalories, f.MaxCalories},
+		{"nutri_protein", f.MinProtein, f.MaxProtein},
+		{"nutri_carbohydrates", f.MinCarbohydrates, f.MaxCarbohydrates},
+		{"nutri_fat", f.MinFat, f.MaxFat},
+	}
+	for _, r := range ranges {
+		if r.min != nil || r.max != nil {
+			q = q.Where("nutri_calories > 0")
+			break
+		}
+	}
+	for _, r := range ranges {
+		if r.min != nil {
+			q = q.Where(r.column+" >= ?", *r.min)
+		}
+		if r.max != nil {
+			q = q.Where(r.column+" <= ?", *r.max)
+		}
+	}
+
+	// Times are derived from the steps; a zero total means none were found,
+	// which must not pass for a quick recipe.
+	ceilings := []struct {
+		column string
+		max    *int
+	}{
+		{"prep_minutes", f.MaxPrepMinutes},
+		{"cook_minutes", f.MaxCookMinutes},
+		{"total_minutes", f.MaxTotalMinutes},
+	}
+	for _, c := range ceilings {
+		if c.max != nil {
+			q = q.Where("total_minutes > 0 AND "+c.column+" <= ?", *c.max)
+		}
+	}
+	return q
+}
+
+// applianceCondition returns a condition that holds when a recipe uses the
+// named appliance. It reports false for blank names.
+func applianceCondition(isPostgres bool, name string) (string, interface{}, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", nil, false
+	}
+	cond, arg := arrayContains(isPostgres, "appliances", name)
+	return cond, arg, true
+}
+
+// allergenCondition returns a condition that holds when a recipe contains the
+// named allergen. Allergens of a known group match the groups detected in the
+// ingredients; the allergy disclaimer is only consulted for names outside the
+// known groups, matched by whole words so "lupin" does not match "lupini". It
+// reports false for blank names.
+func allergenCondition(isPostgres bool, name string) (string, []interface{}, bool) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" {
+		return "", nil, false
+	}
+	if group, ok := allergens.Parse(name); ok {
+		cond, arg := arrayContains(isPostgres, "allergens", group)
+		return cond, []interface{}{arg}, true
+	}
+	cond, args := disclaimerMentions(name)
+	return cond, args, true
+}
+
+// disclaimerWords is the lower-cased allergy disclaimer with punctuation
+// turned into spaces and a space at either end, so LIKE '% word %' matches
+// whole words. Hyphens are kept: "nut-free" is one word.
+const disclaimerWords = "' ' || replace(replace(replace(replace(replace(replace(replace(" +
+	"lower(coalesce(allergy_disclaimer, '')), '.', ' '), ',', ' '), ';', ' '), ':', ' '), '(', ' '), ')', ' '), '/', ' ') || ' '"
+
+// disclaimerMentions returns a condition that holds when the allergy
+// disclaimer contains term, or its plural, as whole words.
+func disclaimerMentions(term string) (string, []interface{}) {
+	return "(" + disclaimerWords + " LIKE ? OR " + disclaimerWords + " LIKE ?)",
+		[]interface{}{"% " + term + " %", "% " + term + "s %"}
+}
+
+// arrayContains returns a condition that holds when the StringArray column
+// holds value, compared case-insensitively; value must be lower-case.
+func arrayContains(isPostgres bool, column, value string) (string, interface{}) {
+	if isPostgres {
+		return "EXISTS (SELECT 1 FROM unnest(" + column + ") AS a WHERE lower(a) = ?)", value
+	}
+	return "lower(coalesce(" + column + ", '')) LIKE ?", `%"` + value + `"%`
+}
+
+// FacetRecipes counts the recipes matching req per appliance and calorie band.
+// Pagination fields of req are ignored.
+func (r *recipeRepository) FacetRecipes(req *models.RecipeQueryRequest) (*models.RecipeFacets, error) {
+	appliances, err := r.applianceFacets(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count appliances: %v", err)
+	}
+	calories, err := r.calorieFacets(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count calorie bands: %v", err)
+	}
+	return &models.RecipeFacets{Appliances: appliances, Calories: calories}, nil
+}
+
+// applianceFacets counts matching recipes per lower-cased appliance name.
+func (r *recipeRepository) applianceFacets(req *models.RecipeQueryRequest) (map[string]int, error) {
+	counts := make(map[string]int)
+	if r.db.Dialector.Name() == "postgres" {
+		var rows []struct {
+			Name string
+			N    int
+		}
+		err := r.db.Table("(?) AS m, unnest(m.appliances) AS a", r.matching(req).Select("id, appliances")).
+			Select("lower(a) AS name, count(DISTINCT m.id) AS n").
+			Group("lower(a)").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			counts[row.Name] = row.N
+		}
+		return counts, nil
+	}
+
+	var lists []models.StringArray
+	if err := r.matching(req).Pluck("appliances", &lists).Error; err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		seen := make(map[string]bool)
+		for _, name := range list {
+			name = strings.ToLower(name)
+			if !seen[name] {
+				seen[name] = true
+				counts[name]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// calorieFacets counts matching recipes in each calorie band with a single
+// query. Recipes with unknown nutrition (zero calories) are in no band.
+func (r *recipeRepository) calorieFacets(req *models.RecipeQueryRequest) ([]models.RangeFacet, error) {
+	selects := make([]string, len(calorieBands))
+	var args []interface{}
+	for i, band := range calorieBands {
+		conds := []string{"nutri_calories > 0"}
+		if band.Min != nil {
+			conds = append(conds, "nutri_calories >= ?")
+			args = append(args, *band.Min)
+		}
+		if band.Max != nil {
+			conds = append(conds, "nutri_calories < ?")
+			args = append(args, *band.Max)
+		}
+		selects[i] = "COUNT(CASE WHEN " + strings.Join(conds, " AND ") + " THEN 1 END)"
+	}
+
+	counts := make([]int, len(calorieBands))
+	dest := make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	row := r.matching(req).Select(strings.Join(selects, ", "), args...).Row()
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	facets := make([]models.RangeFacet, len(calorieBands))
+	for i, band := range calorieBands {
+		facets[i] = models.RangeFacet{Label: band.Label, Count: counts[i]}
+		if band.Min != nil {
+			facets[i].Min = float64Ptr(*band.Min)
+		}
+		if band.Max != nil {
+			facets[i].Max = float64Ptr(*band.Max)
+		}
+	}
+	return facets, nil
+}
+
+// float64Ptr returns a pointer to v.
+func float64Ptr(v float64) *float64 {
+	return &v
+}
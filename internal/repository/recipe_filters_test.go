@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+func TestFilterRecipesOnPostgres(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=recipes"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	maxCalories := 500.0
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var recipes []*models.Recipe
+		return filterRecipes(tx.Model(&models.Recipe{}), &models.RecipeFilters{
+			Appliances:        []string{"Stove"},
+			ExcludeAppliances: []string{"Oven", " "},
+			MaxCalories:       &maxCalories,
+			ExcludeAllergens:  []string{"fish", "lupin"},
+		}).Find(&recipes)
+	})
+	assert.Contains(t, sql, `EXISTS (SELECT 1 FROM unnest(appliances) AS a WHERE lower(a) = 'stove')`)
+	assert.Contains(t, sql, `NOT (EXISTS (SELECT 1 FROM unnest(appliances) AS a WHERE lower(a) = 'oven'))`)
+	assert.Contains(t, sql, `nutri_calories > 0 AND nutri_calories <= 500`)
+	assert.NotContains(t, sql, `lower(a) = ''`, "blank names are ignored")
+	assert.Contains(t, sql, `EXISTS (SELECT 1 FROM unnest(allergens) AS a WHERE lower(a) = 'fish')`)
+	assert.Contains(t, sql, `LIKE '% lupin %'`, "names outside the known groups match the disclaimer")
+	assert.NotContains(t, sql, `LIKE '% fish %'`, "known groups only match detected allergens")
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// AddImage records an uploaded image of a recipe.
+func (r *recipeRepository) AddImage(image *models.RecipeImage) error {
+	return r.db.Create(image).Error
+}
+
+// loadImages fills in the Images of each recipe with a single query, oldest
+// upload first, with their URLs taken from media. Images loaded without a
+// media store have no URLs.
+func loadImages(db *gorm.DB, media blobstore.BlobStore, recipes []*models.Recipe) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+	byID := make(map[string]*models.Recipe, len(recipes))
+	ids := make([]string, len(recipes))
+	for i, recipe := range recipes {
+		byID[recipe.ID] = recipe
+		ids[i] = recipe.ID
+	}
+	var images []models.RecipeImage
+	if err := db.Where("recipe_id IN ?", ids).Order("created_at, id").Find(&images).Error; err != nil {
+		return err
+	}
+	for _, image := range images {
+		if media != nil {
+			image.SetURLs(media.URL)
+		}
+		recipe := byID[image.RecipeID]
+		recipe.Images = append(recipe.Images, image)
+	}
+	return nil
+}
+
+// loadDetails fills in the data kept outside the recipes table: tags, images
+// and favorites. media resolves image URLs, and viewerID is the user whose
+// favorites are flagged; it is empty when there is none.
+func loadDetails(db *gorm.DB, media blobstore.BlobStore, recipes []*models.Recipe, viewerID string) error {
+	if err := loadTags(db, recipes); err != nil {
+		return err
+	}
+	if err := loadImages(db, media, recipes); err != nil {
+		return err
+	}
+	return loadFavorites(db, recipes, viewerID)
+}
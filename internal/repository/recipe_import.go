@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/revisions"
+	"gorm.io/gorm"
+)
+
+// ImportOutcome reports what happened to one recipe passed to ImportRecipes.
+type ImportOutcome int
+
+const (
+	// ImportInserted means the recipe was stored as a new row.
+	ImportInserted ImportOutcome = iota
+	// ImportUpdated means a previously imported recipe with the same ID was
+	// overwritten with new content.
+	ImportUpdated
+	// ImportDuplicate means a recipe with the same content hash was already
+	// stored, so nothing was written.
+	ImportDuplicate
+)
+
+// importInsertBatchSize caps the rows sent in a single INSERT statement.
+const importInsertBatchSize = 100
+
+// RecipeImportRepository writes recipes loaded in bulk from external files.
+type RecipeImportRepository interface {
+	// ImportRecipes upserts a batch of recipes in one transaction and returns
+	// the outcome for each, in order. Every recipe must have its ID and
+	// ContentHash set. Each write is recorded as an "import" revision.
+	ImportRecipes(recipes []*models.Recipe) ([]ImportOutcome, error)
+}
+
+// NewRecipeImportRepository returns an implementation of RecipeImportRepository.
+func NewRecipeImportRepository(db *gorm.DB) RecipeImportRepository {
+	return &recipeRepository{db: db}
+}
+
+// ImportRecipes upserts recipes keyed by ID. A recipe is skipped as a
+// duplicate when its content hash is already stored, whether on the same row
+// or another one; otherwise an existing row with its ID is updated and a
+// missing one inserted. Recipes in the trash count as stored, and an updated
+// one stays in the trash. New rows are inserted in multi-row statements.
+func (r *recipeRepository) ImportRecipes(recipes []*models.Recipe) ([]ImportOutcome, error) {
+	outcomes := make([]ImportOutcome, len(recipes))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		ids := make([]string, len(recipes))
+		hashes := make([]string, len(recipes))
+		for i, recipe := range recipes {
+			ids[i], hashes[i] = recipe.ID, recipe.ContentHash
+		}
+
+		var stored []string
+		if err := tx.Unscoped().Model(&models.Recipe{}).Where("content_hash IN ?", hashes).Pluck("content_hash", &stored).Error; err != nil {
+			return err
+		}
+		known := make(map[string]bool, len(stored))
+		for _, hash := range stored {
+			known[hash] = true
+		}
+		var existing []*models.Recipe
+		if err := tx.Unscoped().Where("id IN ?", ids).Find(&existing).Error; err != nil {
+			return err
+		}
+		byID := make(map[string]*models.Recipe, len(existing))
+		for _, recipe := range existing {
+			byID[recipe.ID] = recipe
+		}
+
+		var inserts []*models.Recipe
+		var insertRevisions []*models.RecipeRevision
+		for i, recipe := range recipes {
+			switch current, ok := byID[recipe.ID]; {
+			case known[recipe.ContentHash]:
+				outcomes[i] = ImportDuplicate
+			case ok:
+				changed := revisions.ChangedFields(current.Snapshot(), recipe.Snapshot())
+				recipe.CreatedAt, recipe.DeletedAt = current.CreatedAt, current.DeletedAt
+				recipe.Visibility = current.Visibility
+				if err := tx.Unscoped().Omit(ratingColumns...).Save(recipe).Error; err != nil {
+					return err
+				}
+				if err := addRevision(tx, recipe.ID, importRevision(recipe, changed)); err != nil {
+					return err
+				}
+				outcomes[i] = ImportUpdated
+			default:
+				inserts = append(inserts, recipe)
+				revision := importRevision(recipe, nil)
+				revision.RecipeID, revision.Number = recipe.ID, 1
+				insertRevisions = append(insertRevisions, revision)
+				outcomes[i] = ImportInserted
+			}
+			known[recipe.ContentHash] = true
+		}
+		if len(inserts) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(inserts, importInsertBatchSize).Error; err != nil {
+			return err
+		}
+		return tx.CreateInBatches(insertRevisions, importInsertBatchSize).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcomes, nil
+}
+
+// importRevision builds the revision recording an imported recipe's content.
+func importRevision(recipe *models.Recipe, changed []string) *models.RecipeRevision {
+	return &models.RecipeRevision{
+		ID:            uuid.New().String(),
+		UserID:        recipe.UserID,
+		Action:        models.RevisionImport,
+		ChangedFields: changed,
+		Snapshot:      recipe.Snapshot(),
+	}
+}
@@ -1,29 +1,176 @@
 package repository
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
 	"github.com/pageza/recipe-book-api-v2/internal/models"
 	"gorm.io/gorm"
 )
 
 // RecipeRepository defines the data access interface for recipes.
 type RecipeRepository interface {
-	// GetRecipeByID retrieves a recipe by its unique ID.
+	// CreateRecipe persists a new recipe, its tags and its first revision.
+	// revision may be nil when no history should be recorded.
+	CreateRecipe(recipe *models.Recipe, revision *models.RecipeRevision) error
+	// CreateRecipes persists several new recipes, their tags and first
+	// revisions in one transaction: either all are stored or none are.
+	// revisions[i] belongs to recipes[i] and may be nil.
+	CreateRecipes(recipes []*models.Recipe, revisions []*models.RecipeRevision) error
+	// UpdateRecipe saves all fields and tags of an existing recipe and, when
+	// revision is not nil, appends it to the recipe's history in the same transaction.
+	UpdateRecipe(recipe *models.Recipe, revision *models.RecipeRevision) error
+	// DeleteRecipe moves a recipe to its owner's trash by the recipe's unique ID.
+	// Its tag links and revision history are kept until it is purged.
+	DeleteRecipe(recipeID string) error
+	// GetDeletedRecipe retrieves a recipe in the trash by its unique ID.
+	GetDeletedRecipe(recipeID string) (*models.Recipe, error)
+	// ListDeletedRecipes returns the recipes in a user's trash, most recently deleted first.
+	ListDeletedRecipes(userID string) ([]*models.Recipe, error)
+	// RestoreRecipe takes a recipe out of the trash.
+	RestoreRecipe(recipeID string) error
+	// PurgeDeletedRecipes permanently removes recipes moved to the trash
+	// before the given time, with their tag links, revisions, share links,
+	// collection entries, favorites, reviews, comments and image records. It
+	// returns how many recipes were removed and the blob keys of their images,
+	// which the caller must delete from the blob store.
+	PurgeDeletedRecipes(before time.Time) (purged int, blobKeys []string, err error)
+	// GetRecipeByID retrieves a recipe by its unique ID, whatever its visibility.
+	// Recipes returned by the repository carry their favorite count; those
+	// read for a viewer also flag whether the viewer favorited them.
 	GetRecipeByID(recipeID string) (*models.Recipe, error)
+	// GetVisibleRecipe retrieves a recipe by its unique ID if viewerID may
+	// read it: the recipe is not private or viewerID owns it. viewerID is
+	// empty for anonymous readers.
+	GetVisibleRecipe(recipeID, viewerID string) (*models.Recipe, error)
 	// QueryRecipes performs a search and filtering query on recipes.
-	// It returns the matched recipes, the total count for pagination, and an error (if any).
-	QueryRecipes(query, userID, filter string, page, limit int) ([]*models.Recipe, int, error)
+	// It returns one page of matches together with cursors for the adjacent pages.
+	QueryRecipes(req *models.RecipeQueryRequest) (*RecipePage, error)
+	// FacetRecipes counts all recipes matching req by appliance and calorie band.
+	FacetRecipes(req *models.RecipeQueryRequest) (*models.RecipeFacets, error)
+	// ListRevisions returns the revisions of a recipe, newest first.
+	ListRevisions(recipeID string) ([]*models.RecipeRevision, error)
+	// GetRevision retrieves one revision of a recipe by its number.
+	GetRevision(recipeID string, number int) (*models.RecipeRevision, error)
+	// ListForks returns the public recipes, and those owned by viewerID,
+	// forked directly from recipeID, newest first.
+	ListForks(recipeID, viewerID string) ([]*models.Recipe, error)
+	// ListTags returns tags of the given kind (all kinds if empty) with recipe counts.
+	ListTags(kind string) ([]models.TagCount, error)
+	// AddImage records an image uploaded for a recipe.
+	AddImage(image *models.RecipeImage) error
+	// CreateShareLink records a share link for a recipe.
+	CreateShareLink(link *models.ShareLink) error
+	// GetShareLink retrieves a share link by its unique ID.
+	GetShareLink(linkID string) (*models.ShareLink, error)
+	// ListShareLinks returns the share links of a recipe, newest first.
+	ListShareLinks(recipeID string) ([]*models.ShareLink, error)
+	// RevokeShareLink marks a share link as revoked at the given time, unless
+	// it was revoked before.
+	RevokeShareLink(linkID string, at time.Time) error
+	// AddFavorite records that userID favorited a recipe; repeating it has no effect.
+	AddFavorite(userID, recipeID string) error
+	// RemoveFavorite deletes userID's favorite of a recipe.
+	RemoveFavorite(userID, recipeID string) error
+	// ListFavorites returns one page of the recipes userID favorited and may
+	// still read, most recently favorited first, and their total number.
+	ListFavorites(userID string, page, limit int) ([]*models.Recipe, int, error)
+	// CreateReview stores a review and adds its rating to the recipe's summary.
+	// It returns ErrDuplicateReview if the user already reviewed the recipe.
+	CreateReview(review *models.Review) error
+	// UpdateReview saves the rating and body of a review, updating the recipe's summary.
+	UpdateReview(review *models.Review) error
+	// DeleteReview removes a review and its rating from the recipe's summary.
+	DeleteReview(reviewID string) error
+	// GetReview retrieves a review by its unique ID.
+	GetReview(reviewID string) (*models.Review, error)
+	// GetUserReview retrieves userID's review of a recipe.
+	GetUserReview(recipeID, userID string) (*models.Review, error)
+	// ListReviews returns one page of a recipe's reviews, newest first, and their total number.
+	ListReviews(recipeID string, page, limit int) ([]*models.Review, int, error)
+}
+
+// RecipePage is one page of recipe query results.
+type RecipePage struct {
+	Recipes    []*models.Recipe
+	Total      int    // matching recipes, per req.TotalMode; 0 when not counted
+	NextCursor string // empty on the last page
+	PrevCursor string // empty on the first page
 }
 
 // recipeRepository is the struct that implements RecipeRepository
 type recipeRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	media blobstore.BlobStore
+}
+
+// NewRecipeRepository returns an implementation of RecipeRepository. Image
+// URLs are resolved with media, which may be nil when images are not served.
+func NewRecipeRepository(db *gorm.DB, media blobstore.BlobStore) RecipeRepository {
+	return &recipeRepository{db: db, media: media}
+}
+
+// CreateRecipe inserts a new recipe row, its tag links and its first revision.
+func (r *recipeRepository) CreateRecipe(recipe *models.Recipe, revision *models.RecipeRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(recipe).Error; err != nil {
+			return err
+		}
+		if err := setTags(tx, recipe); err != nil {
+			return err
+		}
+		return addRevision(tx, recipe.ID, revision)
+	})
 }
 
-// NewRecipeRepository returns an implementation of RecipeRepository
-func NewRecipeRepository(db *gorm.DB) RecipeRepository {
-	return &recipeRepository{db: db}
+// CreateRecipes inserts a batch of new recipes in one transaction.
+func (r *recipeRepository) CreateRecipes(recipes []*models.Recipe, revisions []*models.RecipeRevision) error {
+	if len(revisions) != len(recipes) {
+		return fmt.Errorf("CreateRecipes: %d revisions for %d recipes", len(revisions), len(recipes))
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, recipe := range recipes {
+			if err := tx.Create(recipe).Error; err != nil {
+				return err
+			}
+			if err := setTags(tx, recipe); err != nil {
+				return err
+			}
+			if err := addRevision(tx, recipe.ID, revisions[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateRecipe writes every column of the given recipe except its rating
+// summary back to the database, replaces its tags and records the revision.
+func (r *recipeRepository) UpdateRecipe(recipe *models.Recipe, revision *models.RecipeRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(ratingColumns...).Save(recipe).Error; err != nil {
+			return err
+		}
+		if err := setTags(tx, recipe); err != nil {
+			return err
+		}
+		return addRevision(tx, recipe.ID, revision)
+	})
+}
+
+// DeleteRecipe soft-deletes a recipe by its ID, stamping its deleted_at column.
+// It returns gorm.ErrRecordNotFound when no recipe outside the trash matched.
+func (r *recipeRepository) DeleteRecipe(recipeID string) error {
+	result := r.db.Delete(&models.Recipe{}, "id = ?", recipeID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 // GetRecipeByID retrieves a recipe by its ID.
@@ -32,41 +179,171 @@ func (r *recipeRepository) GetRecipeByID(recipeID string) (*models.Recipe, error
 	if err := r.db.First(&recipe, "id = ?", recipeID).Error; err != nil {
 		return nil, err
 	}
+	if err := loadDetails(r.db, r.media, []*models.Recipe{&recipe}, ""); err != nil {
+		return nil, err
+	}
+	return &recipe, nil
+}
+
+// GetVisibleRecipe retrieves a recipe by its ID, treating recipes hidden
+// from viewerID as missing.
+func (r *recipeRepository) GetVisibleRecipe(recipeID, viewerID string) (*models.Recipe, error) {
+	var recipe models.Recipe
+	if err := readableBy(r.db, viewerID).First(&recipe, "id = ?", recipeID).Error; err != nil {
+		return nil, err
+	}
+	if err := loadDetails(r.db, r.media, []*models.Recipe{&recipe}, viewerID); err != nil {
+		return nil, err
+	}
 	return &recipe, nil
 }
 
+// ListForks returns the direct forks of a recipe listed for viewerID, newest first.
+func (r *recipeRepository) ListForks(recipeID, viewerID string) ([]*models.Recipe, error) {
+	var forks []*models.Recipe
+	if err := listedFor(r.db, viewerID).Where("parent_recipe_id = ?", recipeID).Order("created_at DESC, id DESC").Find(&forks).Error; err != nil {
+		return nil, err
+	}
+	if err := loadDetails(r.db, r.media, forks, viewerID); err != nil {
+		return nil, err
+	}
+	return forks, nil
+}
+
 // QueryRecipes performs a query with optional filters:
-//   - If userID is provided, it filters by recipe creator.
-//   - If filter is provided, it applies additional filtering on the title.
-//   - If query text is provided, it searches in title and ingredients.
+//   - Only public recipes and those owned by ViewerID are included.
+//   - If UserID is provided, it filters by recipe creator.
+//   - If Filter is provided, it applies additional filtering on the title.
+//   - Structured Filters restrict tags, appliances, allergens and nutrition.
+//   - If Query text is provided, it runs a full-text search over the title,
+//     ingredients and steps.
 //
-// Pagination is applied via page and limit parameters.
-func (r *recipeRepository) QueryRecipes(query, userID, filter string, page, limit int) ([]*models.Recipe, int, error) {
-	var recipes []*models.Recipe
-	dbQuery := r.db.Model(&models.Recipe{})
+// Results are ordered by req.Sort with the recipe ID as a tie-breaker and
+// paged by keyset: a cursor resumes strictly after (or before) the row it
+// was issued for, so inserts never shift or duplicate rows. Without a cursor,
+// Page and Limit select an offset page as before. One extra row is fetched
+// to tell whether another page follows.
+func (r *recipeRepository) QueryRecipes(req *models.RecipeQueryRequest) (*RecipePage, error) {
+	order := orderFor(r.db, req)
+	var cursor *recipeCursor
+	if req.Cursor != "" {
+		var err error
+		if cursor, err = decodeCursor(req.Cursor, order.sort); err != nil {
+			return nil, err
+		}
+	}
+
+	page := &RecipePage{}
+	switch req.TotalMode {
+	case models.TotalNone:
+	case models.TotalEstimate:
+		total, err := r.estimateCount(r.matching(req))
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate recipe count: %v", err)
+		}
+		page.Total = total
+	default:
+		var total int64
+		if err := r.matching(req).Count(&total).Error; err != nil {
+			return nil, fmt.Errorf("failed to count recipes: %v", err)
+		}
+		page.Total = int(total)
+	}
+
+	backward := cursor != nil && cursor.Backward
+	// Select explicitly: rankedRecipe is not the model, and GORM would
+	// otherwise list its fields, including search_rank, as table columns.
+	dbQuery := r.matching(req).Select("*")
+	if order.sort == models.SortRelevance {
+		dbQuery = dbQuery.Select("*, ? AS search_rank", order.column)
+	}
+	dbQuery = order.apply(dbQuery, backward)
+	if cursor != nil {
+		dbQuery = order.after(dbQuery, cursor)
+	} else if req.Page > 1 {
+		dbQuery = dbQuery.Offset((req.Page - 1) * req.Limit)
+	}
+
+	var rows []*rankedRecipe
+	if err := dbQuery.Limit(req.Limit + 1).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query recipes: %v", err)
+	}
+	hasMore := len(rows) > req.Limit
+	if hasMore {
+		rows = rows[:req.Limit]
+	}
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
 
-	if userID != "" {
-		dbQuery = dbQuery.Where("user_id = ?", userID)
+	page.Recipes = make([]*models.Recipe, len(rows))
+	for i, row := range rows {
+		page.Recipes[i] = &row.Recipe
 	}
-	if filter != "" {
-		dbQuery = dbQuery.Where("title LIKE ?", "%"+filter+"%")
+	if err := loadDetails(r.db, r.media, page.Recipes, req.ViewerID); err != nil {
+		return nil, fmt.Errorf("failed to load recipe details: %v", err)
 	}
-	if query != "" {
-		// For advanced search, search in both title and ingredients.
-		dbQuery = dbQuery.Where("title LIKE ? OR ingredients LIKE ?", "%"+query+"%", "%"+query+"%")
+	if len(rows) > 0 {
+		// Reading forwards, a next page exists if the extra row was found and a
+		// previous one if we started past the beginning; backwards the reverse.
+		morePrev, moreNext := cursor != nil || req.Page > 1, hasMore
+		if backward {
+			morePrev, moreNext = hasMore, true
+		}
+		if moreNext {
+			page.NextCursor = order.cursor(rows[len(rows)-1], false)
+		}
+		if morePrev {
+			page.PrevCursor = order.cursor(rows[0], true)
+		}
 	}
+	return page, nil
+}
 
-	// Retrieve the total count before pagination.
-	var total int64
-	if err := dbQuery.Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to count recipes: %v", err)
+// estimateCount returns the query planner's row estimate for q, which avoids
+// scanning every match. Databases other than Postgres count exactly.
+func (r *recipeRepository) estimateCount(q *gorm.DB) (int, error) {
+	if r.db.Dialector.Name() != "postgres" {
+		var total int64
+		err := q.Count(&total).Error
+		return int(total), err
 	}
 
-	// Calculate the offset based on the page number.
-	offset := (page - 1) * limit
-	if err := dbQuery.Offset(offset).Limit(limit).Find(&recipes).Error; err != nil {
-		return nil, 0, fmt.Errorf("failed to query recipes: %v", err)
+	stmt := q.Session(&gorm.Session{DryRun: true}).Select("id").Find(&[]models.Recipe{}).Statement
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return 0, err
+	}
+	var plan string
+	if err := sqlDB.QueryRow("EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Scan(&plan); err != nil {
+		return 0, err
+	}
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
 	}
+	if err := json.Unmarshal([]byte(plan), &explained); err != nil || len(explained) == 0 {
+		return 0, fmt.Errorf("unexpected EXPLAIN output: %s", plan)
+	}
+	return int(explained[0].Plan.Rows), nil
+}
+
+// matching builds the filtered, unordered query shared by QueryRecipes and FacetRecipes.
+func (r *recipeRepository) matching(req *models.RecipeQueryRequest) *gorm.DB {
+	dbQuery := listedFor(r.db.Model(&models.Recipe{}), req.ViewerID)
 
-	return recipes, int(total), nil
+	if req.UserID != "" {
+		dbQuery = dbQuery.Where("user_id = ?", req.UserID)
+	}
+	if req.Filter != "" {
+		dbQuery = dbQuery.Where("title LIKE ?", "%"+req.Filter+"%")
+	}
+	dbQuery = filterRecipes(dbQuery, &req.Filters)
+	if req.Query != "" {
+		dbQuery = searchRecipes(dbQuery, req.Query)
+	}
+	return dbQuery
 }
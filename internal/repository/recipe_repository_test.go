@@ -0,0 +1,694 @@
+package repository_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+)
+
+// newRecipeTestRepo returns a recipe repository over a fresh in-memory SQLite database.
+func newRecipeTestRepo(t *testing.T) repository.RecipeRepository {
+	return repository.NewRecipeRepository(newRecipeTestDB(t), nil)
+}
+
+// newRecipeTestDB opens a fresh in-memory SQLite database with the recipe tables.
+func newRecipeTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models.All()...))
+	return db
+}
+
+func TestRecipeRepository_QueryRecipesSearchesAllText(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	for _, r := range []*models.Recipe{
+		{ID: "1", Title: "Garlic Bread", Ingredients: []string{"1 baguette", "4 cloves garlic"}, Steps: []string{"Bake."}, UserID: "u1"},
+		{ID: "2", Title: "Tomato Soup", Ingredients: []string{"6 tomatoes", "1 clove garlic"}, Steps: []string{"Simmer gently."}, UserID: "u1"},
+		{ID: "3", Title: "Pancakes", Ingredients: []string{"1 cup flour"}, Steps: []string{"Fry in butter."}, UserID: "u2"},
+	} {
+		require.NoError(t, repo.CreateRecipe(r, nil))
+	}
+
+	page, err := repo.QueryRecipes(&models.RecipeQueryRequest{Query: "garlic", Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.Total, "matches in the title and in ingredients")
+	assert.Len(t, page.Recipes, 2)
+
+	page, err = repo.QueryRecipes(&models.RecipeQueryRequest{Query: "butter", Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total, "steps are searched too")
+
+	page, err = repo.QueryRecipes(&models.RecipeQueryRequest{Query: "garlic simmer", Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total, "every word must match")
+	assert.Equal(t, "2", page.Recipes[0].ID)
+
+	page, err = repo.QueryRecipes(&models.RecipeQueryRequest{Query: "garlic", UserID: "u2", Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, page.Total)
+}
+
+func TestRecipeRepository_QueryRecipesWithFilters(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	for _, r := range []*models.Recipe{
+		{ID: "salad", Title: "Salad", Appliances: []string{"Bowl"}, Allergens: []string{"sesame"},
+			NutritionalInfo: models.NutritionalInfo{Calories: 250, Protein: 5}},
+		{ID: "lasagna", Title: "Lasagna", Appliances: []string{"Oven", "Stove"}, Allergens: []string{"milk", "wheat"},
+			NutritionalInfo: models.NutritionalInfo{Calories: 650, Protein: 30}, PrepMinutes: 30, CookMinutes: 45, TotalMinutes: 75},
+		{ID: "stir-fry", Title: "Stir Fry", Appliances: []string{"stove", "Wok"}, Allergens: []string{"soy"},
+			NutritionalInfo: models.NutritionalInfo{Calories: 450, Protein: 25}, PrepMinutes: 15, CookMinutes: 10, TotalMinutes: 25},
+		{ID: "toast", Title: "Toast"}, // nutrition unknown
+	} {
+		require.NoError(t, repo.CreateRecipe(r, nil))
+	}
+	max500, min20, max10 := 500.0, 20.0, 10.0
+	under30, under40 := 30, 40
+
+	cases := []struct {
+		name    string
+		filters models.RecipeFilters
+		want    []string
+	}{
+		{"include appliance", models.RecipeFilters{Appliances: []string{"STOVE"}}, []string{"lasagna", "stir-fry"}},
+		{"include all appliances", models.RecipeFilters{Appliances: []string{"stove", "oven"}}, []string{"lasagna"}},
+		{"exclude appliance", models.RecipeFilters{ExcludeAppliances: []string{"oven"}}, []string{"salad", "stir-fry", "toast"}},
+		{"calorie ceiling skips unknown nutrition", models.RecipeFilters{MaxCalories: &max500}, []string{"salad", "stir-fry"}},
+		{"protein floor", models.RecipeFilters{MinProtein: &min20}, []string{"lasagna", "stir-fry"}},
+		{"protein ceiling skips unknown nutrition", models.RecipeFilters{MaxProtein: &max10}, []string{"salad"}},
+		{"exclude allergen", models.RecipeFilters{ExcludeAllergens: []string{"Gluten"}}, []string{"salad", "stir-fry", "toast"}},
+		{"exclude detected allergen", models.RecipeFilters{ExcludeAllergens: []string{"Sesame"}}, []string{"lasagna", "stir-fry", "toast"}},
+		{"exclude allergen by synonym", models.RecipeFilters{ExcludeAllergens: []string{"dairy", "soya"}}, []string{"salad", "toast"}},
+		{"total time ceiling skips unknown times", models.RecipeFilters{MaxTotalMinutes: &under30}, []string{"stir-fry"}},
+		{"prep time ceiling", models.RecipeFilters{MaxPrepMinutes: &under30}, []string{"lasagna", "stir-fry"}},
+		{"cook time ceiling", models.RecipeFilters{MaxCookMinutes: &under40}, []string{"stir-fry"}},
+		{"combined", models.RecipeFilters{MaxCalories: &max500, ExcludeAppliances: []string{"oven"}, ExcludeAllergens: []string{"soy"}}, []string{"salad"}},
+	}
+	for _, tc := range cases {
+		page, err := repo.QueryRecipes(&models.RecipeQueryRequest{Filters: tc.filters, Page: 1, Limit: 10})
+		require.NoError(t, err, tc.name)
+		assert.ElementsMatch(t, tc.want, recipeIDs(page.Recipes), tc.name)
+		assert.Equal(t, len(tc.want), page.Total, tc.name)
+	}
+
+	facets, err := repo.FacetRecipes(&models.RecipeQueryRequest{Filters: models.RecipeFilters{ExcludeAllergens: []string{"soy"}}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"bowl": 1, "oven": 1, "stove": 1}, facets.Appliances)
+	require.Len(t, facets.Calories, 4)
+	assert.Equal(t, 1, facets.Calories[0].Count, "under 300, without the recipe of unknown nutrition")
+	assert.Equal(t, 0, facets.Calories[1].Count, "300-500")
+	assert.Equal(t, 1, facets.Calories[2].Count, "500-800")
+	assert.Equal(t, 0, facets.Calories[3].Count, "800 and over")
+
+	facets, err = repo.FacetRecipes(&models.RecipeQueryRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, facets.Appliances["stove"], "names are grouped case-insensitively")
+}
+
+func TestRecipeRepository_ExcludeAllergens(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	for _, r := range []*models.Recipe{
+		{ID: "prawns", Title: "Prawns", Allergens: []string{"shellfish"}, AllergyDisclaimer: "Contains shellfish."},
+		{ID: "satay", Title: "Satay", Allergens: []string{"peanut"}, AllergyDisclaimer: "Contains peanuts."},
+		{ID: "cookies", Title: "Cookies", AllergyDisclaimer: "Made in a nut-free kitchen."},
+		{ID: "pesto", Title: "Pesto", Allergens: []string{"tree_nut"}, AllergyDisclaimer: "Contains pine nuts."},
+		{ID: "chowder", Title: "Chowder", Allergens: []string{"fish"}, AllergyDisclaimer: "Contains fish."},
+		{ID: "legacy", Title: "Legacy", AllergyDisclaimer: "Contains peanuts."}, // stored before allergens were detected
+		{ID: "flatbread", Title: "Flatbread", AllergyDisclaimer: "Made with lupin flour."},
+		{ID: "salad", Title: "Salad", AllergyDisclaimer: "Topped with lupini beans."},
+	} {
+		require.NoError(t, repo.CreateRecipe(r, nil))
+	}
+
+	cases := []struct {
+		name    string
+		exclude string
+		want    []string
+	}{
+		{"fish is not shellfish", "fish", []string{"prawns", "satay", "cookies", "pesto", "legacy", "flatbread", "salad"}},
+		{"nut is not peanut", "nuts", []string{"prawns", "satay", "cookies", "chowder", "legacy", "flatbread", "salad"}},
+		{"group ignores disclaimer", "peanut", []string{"prawns", "cookies", "pesto", "chowder", "legacy", "flatbread", "salad"}},
+		{"unknown allergen by whole word", "lupin", []string{"prawns", "satay", "cookies", "pesto", "chowder", "legacy", "salad"}},
+	}
+	for _, tc := range cases {
+		page, err := repo.QueryRecipes(&models.RecipeQueryRequest{Filters: models.RecipeFilters{ExcludeAllergens: []string{tc.exclude}}, Page: 1, Limit: 10})
+		require.NoError(t, err, tc.name)
+		assert.ElementsMatch(t, tc.want, recipeIDs(page.Recipes), tc.name)
+	}
+}
+
+func TestRecipeRepository_QueryRecipesKeysetPagination(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	titles := []string{"Chili", "Apple Pie", "Borscht", "Dal", "Eggplant Parm"}
+	for i, title := range titles {
+		// Two recipes share a timestamp so the ID tie-breaker is exercised.
+		created := base.Add(time.Duration(i/2) * time.Hour)
+		require.NoError(t, repo.CreateRecipe(&models.Recipe{ID: fmt.Sprintf("r%d", i), Title: title, CreatedAt: created}, nil))
+	}
+
+	// Newest first, two per page, walking forwards to the end.
+	req := &models.RecipeQueryRequest{Sort: models.SortNewest, Page: 1, Limit: 2}
+	first, err := repo.QueryRecipes(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r4", "r3"}, recipeIDs(first.Recipes))
+	assert.Empty(t, first.PrevCursor)
+	require.NotEmpty(t, first.NextCursor)
+
+	// A recipe inserted at the top does not shift the following pages.
+	require.NoError(t, repo.CreateRecipe(&models.Recipe{ID: "r9", Title: "Fresh", CreatedAt: base.Add(24 * time.Hour)}, nil))
+
+	req.Cursor = first.NextCursor
+	second, err := repo.QueryRecipes(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r2", "r1"}, recipeIDs(second.Recipes))
+
+	req.Cursor = second.NextCursor
+	third, err := repo.QueryRecipes(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r0"}, recipeIDs(third.Recipes))
+	assert.Empty(t, third.NextCursor, "last page")
+
+	// Walking backwards returns the same pages in the same order.
+	req.Cursor = third.PrevCursor
+	back, err := repo.QueryRecipes(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r2", "r1"}, recipeIDs(back.Recipes))
+	req.Cursor = back.PrevCursor
+	back, err = repo.QueryRecipes(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r4", "r3"}, recipeIDs(back.Recipes))
+	require.NotEmpty(t, back.PrevCursor, "the inserted recipe is now before the first page")
+	req.Cursor = back.PrevCursor
+	back, err = repo.QueryRecipes(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r9"}, recipeIDs(back.Recipes))
+	assert.Empty(t, back.PrevCursor)
+
+	// Sorting by title, with the count skipped.
+	titleReq := &models.RecipeQueryRequest{Sort: models.SortTitle, TotalMode: models.TotalNone, Limit: 3}
+	page, err := repo.QueryRecipes(titleReq)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Apple Pie", "Borscht", "Chili"}, recipeTitles(page.Recipes))
+	assert.Equal(t, 0, page.Total)
+	titleReq.Cursor = page.NextCursor
+	page, err = repo.QueryRecipes(titleReq)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Dal", "Eggplant Parm", "Fresh"}, recipeTitles(page.Recipes))
+
+	// Offset pages still work and hand out cursors.
+	page, err = repo.QueryRecipes(&models.RecipeQueryRequest{Sort: models.SortTitle, Page: 2, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Chili", "Dal"}, recipeTitles(page.Recipes))
+	assert.Equal(t, 6, page.Total)
+	assert.NotEmpty(t, page.PrevCursor)
+	assert.NotEmpty(t, page.NextCursor)
+
+	// Cursors are tied to their sort order.
+	_, err = repo.QueryRecipes(&models.RecipeQueryRequest{Sort: models.SortNewest, Cursor: page.NextCursor, Limit: 2})
+	assert.ErrorIs(t, err, repository.ErrInvalidCursor)
+	_, err = repo.QueryRecipes(&models.RecipeQueryRequest{Cursor: "not-a-cursor", Limit: 2})
+	assert.ErrorIs(t, err, repository.ErrInvalidCursor)
+}
+
+func TestRecipeRepository_CreateRecipesIsAtomic(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	vegan := models.ParseTag("diet:Vegan")
+	require.NoError(t, repo.CreateRecipe(&models.Recipe{ID: "taken", Title: "Soup", UserID: "u1"}, nil))
+
+	// The second recipe collides with an existing ID, so the first is rolled back too.
+	err := repo.CreateRecipes([]*models.Recipe{
+		{ID: "new", Title: "Salad", UserID: "u1", Tags: []models.Tag{vegan}},
+		{ID: "taken", Title: "Stew", UserID: "u1"},
+	}, []*models.RecipeRevision{{ID: "rev-new", UserID: "u1", Action: models.RevisionCreate}, nil})
+	require.Error(t, err)
+	_, err = repo.GetRecipeByID("new")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	revisions, err := repo.ListRevisions("new")
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	require.NoError(t, repo.CreateRecipes([]*models.Recipe{
+		{ID: "new", Title: "Salad", UserID: "u1", Tags: []models.Tag{vegan}},
+		{ID: "other", Title: "Stew", UserID: "u1"},
+	}, []*models.RecipeRevision{{ID: "rev-new", UserID: "u1", Action: models.RevisionCreate}, nil}))
+	got, err := repo.GetRecipeByID("new")
+	require.NoError(t, err)
+	assert.Equal(t, []models.Tag{vegan}, got.Tags)
+	revisions, err = repo.ListRevisions("new")
+	require.NoError(t, err)
+	assert.Len(t, revisions, 1)
+	assert.Error(t, repo.CreateRecipes([]*models.Recipe{{ID: "x", Title: "X"}}, nil), "one revision slot per recipe")
+}
+
+func TestBackfillRecipeSteps(t *testing.T) {
+	db := newRecipeTestDB(t)
+	repo := repository.NewRecipeRepository(db, nil)
+	stale := []*models.Recipe{
+		{ID: "old", Title: "Old Bread", Steps: []string{"Knead 10 minutes.", "Bake 30 minutes."}},
+		{ID: "trashed", Title: "Old Cake", Steps: []string{"Mix the batter and bake 40 minutes."},
+			StructuredSteps: []models.Step{{Text: "Mix the batter and bake 40 minutes.", PassiveMinutes: 40}}, PrepMinutes: 40, TotalMinutes: 40},
+		{ID: "none", Title: "Salad", Steps: []string{"Toss."}, StructuredSteps: []models.Step{{Text: "Toss."}}},
+	}
+	for _, r := range stale {
+		require.NoError(t, repo.CreateRecipe(r, nil))
+	}
+	require.NoError(t, repo.DeleteRecipe("trashed"))
+
+	updated, err := repository.BackfillRecipeSteps(db)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated, "rows already up to date are left alone")
+	old, err := repo.GetRecipeByID("old")
+	require.NoError(t, err)
+	assert.Len(t, old.StructuredSteps, 2)
+	assert.Equal(t, []int{10, 30, 40}, []int{old.PrepMinutes, old.CookMinutes, old.TotalMinutes})
+	trashed, err := repo.GetDeletedRecipe("trashed")
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 40, 40}, []int{trashed.PrepMinutes, trashed.CookMinutes, trashed.TotalMinutes})
+	assert.True(t, trashed.DeletedAt.Valid, "backfilling does not restore trashed recipes")
+
+	updated, err = repository.BackfillRecipeSteps(db)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
+
+func TestBackfillRecipeAllergens(t *testing.T) {
+	db := newRecipeTestDB(t)
+	repo := repository.NewRecipeRepository(db, nil)
+	for _, r := range []*models.Recipe{
+		{ID: "satay", Title: "Satay", Ingredients: []string{"2 tbsp peanut butter"}},
+		{ID: "pesto", Title: "Pesto", Ingredients: []string{"30 g pine nuts"}, AllergyDisclaimer: "May contain traces of anything."},
+		{ID: "toast", Title: "Toast", Ingredients: []string{"1 slice gluten-free bread"}},
+	} {
+		require.NoError(t, repo.CreateRecipe(r, nil))
+	}
+	require.NoError(t, repo.DeleteRecipe("pesto"))
+
+	updated, err := repository.BackfillRecipeAllergens(db)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated, "rows already up to date are left alone")
+	satay, err := repo.GetRecipeByID("satay")
+	require.NoError(t, err)
+	assert.Equal(t, models.StringArray{"peanut"}, satay.Allergens)
+	assert.Equal(t, "Contains peanuts.", satay.AllergyDisclaimer)
+	pesto, err := repo.GetDeletedRecipe("pesto")
+	require.NoError(t, err)
+	assert.Equal(t, models.StringArray{"tree_nut"}, pesto.Allergens)
+	assert.Equal(t, "May contain traces of anything.", pesto.AllergyDisclaimer, "disclaimers written by hand are kept")
+
+	updated, err = repository.BackfillRecipeAllergens(db)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
+
+func TestRecipeRepository_Revisions(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	recipe := &models.Recipe{ID: "r1", Title: "Soup", UserID: "u1"}
+	require.NoError(t, repo.CreateRecipe(recipe, &models.RecipeRevision{
+		ID: "rev-a", UserID: "u1", Action: models.RevisionCreate, Snapshot: recipe.Snapshot(),
+	}))
+	recipe.Title = "Better Soup"
+	require.NoError(t, repo.UpdateRecipe(recipe, &models.RecipeRevision{
+		ID: "rev-b", UserID: "u1", Action: models.RevisionUpdate, Snapshot: recipe.Snapshot(),
+		ChangedFields: []string{"title"},
+	}))
+
+	revisions, err := repo.ListRevisions("r1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Number)
+	assert.Equal(t, "Better Soup", revisions[0].Snapshot.Title)
+	assert.Equal(t, models.StringArray{"title"}, revisions[0].ChangedFields)
+	assert.Equal(t, 1, revisions[1].Number)
+
+	rev, err := repo.GetRevision("r1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Soup", rev.Snapshot.Title)
+	_, err = repo.GetRevision("r1", 3)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// Moving the recipe to the trash keeps its history; purging removes it.
+	require.NoError(t, repo.DeleteRecipe("r1"))
+	revisions, err = repo.ListRevisions("r1")
+	require.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	_, _, err = repo.PurgeDeletedRecipes(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	revisions, err = repo.ListRevisions("r1")
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestRecipeRepository_Trash(t *testing.T) {
+	media, err := blobstore.NewLocalStore(t.TempDir(), "/media")
+	require.NoError(t, err)
+	repo := repository.NewRecipeRepository(newRecipeTestDB(t), media)
+	vegan := models.ParseTag("diet:Vegan")
+	for _, r := range []*models.Recipe{
+		{ID: "soup", Title: "Soup", UserID: "u1", Tags: []models.Tag{vegan}},
+		{ID: "stew", Title: "Stew", UserID: "u1"},
+		{ID: "salad", Title: "Salad", UserID: "u2"},
+	} {
+		require.NoError(t, repo.CreateRecipe(r, nil))
+	}
+	require.NoError(t, repo.AddImage(&models.RecipeImage{
+		ID: "photo", RecipeID: "salad", BlobKeys: models.StringArray{"salad.jpg", "salad-small.jpg"},
+		Thumbnails: []models.ImageVariant{{Name: "small", Width: 320, Height: 240}},
+	}))
+	require.NoError(t, repo.DeleteRecipe("soup"))
+	require.NoError(t, repo.DeleteRecipe("salad"))
+	assert.ErrorIs(t, repo.DeleteRecipe("soup"), gorm.ErrRecordNotFound, "already in the trash")
+
+	// Trashed recipes are hidden from reads, queries and tag counts.
+	_, err = repo.GetRecipeByID("soup")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	page, err := repo.QueryRecipes(&models.RecipeQueryRequest{UserID: "u1", Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stew"}, recipeIDs(page.Recipes))
+	assert.Equal(t, 1, page.Total)
+	counts, err := repo.ListTags("")
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagCount{{Tag: vegan, RecipeCount: 0}}, counts)
+
+	trash, err := repo.ListDeletedRecipes("u1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"soup"}, recipeIDs(trash))
+	assert.True(t, trash[0].DeletedAt.Valid)
+	assert.Equal(t, []models.Tag{vegan}, trash[0].Tags)
+	deleted, err := repo.GetDeletedRecipe("soup")
+	require.NoError(t, err)
+	assert.Equal(t, "Soup", deleted.Title)
+	_, err = repo.GetDeletedRecipe("stew")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "not in the trash")
+
+	require.NoError(t, repo.RestoreRecipe("soup"))
+	assert.ErrorIs(t, repo.RestoreRecipe("soup"), gorm.ErrRecordNotFound)
+	restored, err := repo.GetRecipeByID("soup")
+	require.NoError(t, err)
+	assert.False(t, restored.DeletedAt.Valid)
+	assert.Equal(t, []models.Tag{vegan}, restored.Tags)
+
+	salad, err := repo.GetDeletedRecipe("salad")
+	require.NoError(t, err)
+	require.Len(t, salad.Images, 1)
+	assert.Equal(t, "/media/salad.jpg", salad.Images[0].URL, "URLs are worked out from the blob keys")
+	assert.Equal(t, "/media/salad-small.jpg", salad.Images[0].Thumbnails[0].URL)
+
+	// Only recipes deleted before the cut-off are purged; the blob keys of
+	// their images are handed back for deletion.
+	purged, blobKeys, err := repo.PurgeDeletedRecipes(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+	assert.Empty(t, blobKeys)
+	purged, blobKeys, err = repo.PurgeDeletedRecipes(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Equal(t, []string{"salad.jpg", "salad-small.jpg"}, blobKeys)
+	_, err = repo.GetDeletedRecipe("salad")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.GetRecipeByID("soup")
+	assert.NoError(t, err, "restored recipes are not purged")
+}
+
+func TestRecipeRepository_Tags(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	italian, main := models.ParseTag("cuisine:Italian"), models.ParseTag("course:Main")
+	vegan := models.ParseTag("diet:Vegan")
+	for _, r := range []*models.Recipe{
+		{ID: "lasagna", Title: "Lasagna", Tags: []models.Tag{italian, main}},
+		{ID: "risotto", Title: "Risotto", Tags: []models.Tag{italian, main, vegan}},
+		{ID: "salad", Title: "Salad", Tags: []models.Tag{vegan}},
+		{ID: "toast", Title: "Toast"},
+	} {
+		require.NoError(t, repo.CreateRecipe(r, nil))
+	}
+
+	got, err := repo.GetRecipeByID("risotto")
+	require.NoError(t, err)
+	assert.Equal(t, []models.Tag{main, italian, vegan}, got.Tags, "ordered by kind and slug")
+
+	cases := []struct {
+		tags []string
+		want []string
+	}{
+		{[]string{"cuisine:italian"}, []string{"lasagna", "risotto"}},
+		{[]string{"cuisine:Italian", "diet:vegan"}, []string{"risotto"}},
+		{[]string{"diet:Keto"}, []string{}},
+	}
+	for _, tc := range cases {
+		page, err := repo.QueryRecipes(&models.RecipeQueryRequest{Filters: models.RecipeFilters{Tags: tc.tags}, Page: 1, Limit: 10})
+		require.NoError(t, err, tc.tags)
+		assert.ElementsMatch(t, tc.want, recipeIDs(page.Recipes), tc.tags)
+	}
+
+	// Replacing a recipe's tags unlinks the old ones.
+	got.Tags = []models.Tag{italian}
+	require.NoError(t, repo.UpdateRecipe(got, nil))
+	counts, err := repo.ListTags("")
+	require.NoError(t, err)
+	require.Len(t, counts, 3)
+	assert.Equal(t, models.TagCount{Tag: italian, RecipeCount: 2}, counts[0])
+	assert.Equal(t, models.TagCount{Tag: main, RecipeCount: 1}, counts[1])
+	assert.Equal(t, models.TagCount{Tag: vegan, RecipeCount: 1}, counts[2])
+
+	counts, err = repo.ListTags(models.TagKindDiet)
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagCount{{Tag: vegan, RecipeCount: 1}}, counts)
+
+	require.NoError(t, repo.DeleteRecipe("lasagna"))
+	counts, err = repo.ListTags(models.TagKindCourse)
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagCount{{Tag: main, RecipeCount: 0}}, counts)
+}
+
+func TestRecipeRepository_Visibility(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	vegan := models.ParseTag("diet:Vegan")
+	weeknight, secret := models.ParseTag("Weeknight"), models.ParseTag("Surprise party")
+	for _, r := range []*models.Recipe{
+		{ID: "public", Title: "Public", UserID: "alice", Tags: []models.Tag{vegan, weeknight}},
+		{ID: "unlisted", Title: "Unlisted", UserID: "alice", Visibility: models.VisibilityUnlisted, Tags: []models.Tag{vegan}},
+		{ID: "private", Title: "Private", UserID: "alice", Visibility: models.VisibilityPrivate, ParentRecipeID: "public", Tags: []models.Tag{secret}},
+		{ID: "bobs", Title: "Bob's", UserID: "bob", Visibility: models.VisibilityPrivate},
+	} {
+		require.NoError(t, repo.CreateRecipe(r, nil))
+	}
+
+	got, err := repo.GetRecipeByID("public")
+	require.NoError(t, err)
+	assert.Equal(t, models.VisibilityPublic, got.Visibility, "the column defaults to public")
+
+	listings := map[string][]string{
+		"":      {"public"},
+		"alice": {"public", "unlisted", "private"},
+		"bob":   {"public", "bobs"},
+	}
+	for viewer, want := range listings {
+		page, err := repo.QueryRecipes(&models.RecipeQueryRequest{ViewerID: viewer, Page: 1, Limit: 10})
+		require.NoError(t, err, viewer)
+		assert.ElementsMatch(t, want, recipeIDs(page.Recipes), "listing for %q", viewer)
+		assert.Equal(t, len(want), page.Total, viewer)
+	}
+
+	_, err = repo.GetVisibleRecipe("unlisted", "")
+	assert.NoError(t, err, "unlisted recipes open by ID")
+	_, err = repo.GetVisibleRecipe("private", "bob")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.GetVisibleRecipe("private", "alice")
+	assert.NoError(t, err)
+
+	forks, err := repo.ListForks("public", "bob")
+	require.NoError(t, err)
+	assert.Empty(t, forks)
+	forks, err = repo.ListForks("public", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"private"}, recipeIDs(forks))
+
+	counts, err := repo.ListTags(models.TagKindDiet)
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagCount{{Tag: vegan, RecipeCount: 1}}, counts, "only public recipes are counted")
+
+	// Free-form tags only private recipes use are not listed at all.
+	counts, err = repo.ListTags(models.TagKindUser)
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagCount{{Tag: weeknight, RecipeCount: 1}}, counts)
+	require.NoError(t, repo.DeleteRecipe("public"))
+	counts, err = repo.ListTags("")
+	require.NoError(t, err)
+	assert.Equal(t, []models.TagCount{{Tag: vegan, RecipeCount: 0}}, counts, "trashed recipes do not keep free-form tags listed")
+}
+
+func TestRecipeRepository_ShareLinks(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	require.NoError(t, repo.CreateRecipe(&models.Recipe{ID: "r1", Title: "Soup", UserID: "alice"}, nil))
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	for _, id := range []string{"l1", "l2"} {
+		require.NoError(t, repo.CreateShareLink(&models.ShareLink{ID: id, RecipeID: "r1", UserID: "alice", ExpiresAt: expires}))
+	}
+
+	revokedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, repo.RevokeShareLink("l1", revokedAt))
+	require.NoError(t, repo.RevokeShareLink("l1", revokedAt.Add(time.Hour)))
+	link, err := repo.GetShareLink("l1")
+	require.NoError(t, err)
+	require.NotNil(t, link.RevokedAt)
+	assert.True(t, link.RevokedAt.Equal(revokedAt), "the first revocation is kept")
+	assert.False(t, link.Active(time.Now()))
+
+	links, err := repo.ListShareLinks("r1")
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+
+	require.NoError(t, repo.DeleteRecipe("r1"))
+	_, _, err = repo.PurgeDeletedRecipes(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	_, err = repo.GetShareLink("l2")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "purging a recipe removes its links")
+}
+
+func TestRecipeRepository_Favorites(t *testing.T) {
+	db := newRecipeTestDB(t)
+	repo := repository.NewRecipeRepository(db, nil)
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, repo.CreateRecipe(&models.Recipe{ID: fmt.Sprintf("r%d", i), Title: "Soup", UserID: "alice"}, nil))
+	}
+	require.NoError(t, repo.CreateRecipe(&models.Recipe{ID: "secret", Title: "Secret", UserID: "alice", Visibility: models.VisibilityPrivate}, nil))
+
+	for _, fav := range [][2]string{{"bob", "r1"}, {"bob", "r2"}, {"carol", "r2"}, {"bob", "secret"}, {"bob", "r2"}} {
+		require.NoError(t, repo.AddFavorite(fav[0], fav[1]))
+	}
+	assert.ErrorIs(t, repo.RemoveFavorite("carol", "r1"), gorm.ErrRecordNotFound)
+
+	recipe, err := repo.GetVisibleRecipe("r2", "bob")
+	require.NoError(t, err)
+	assert.Equal(t, 2, recipe.FavoriteCount)
+	assert.True(t, recipe.IsFavorited)
+	recipe, err = repo.GetVisibleRecipe("r2", "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, recipe.FavoriteCount)
+	assert.False(t, recipe.IsFavorited, "anonymous readers have no favorites")
+
+	// The flags of a page are loaded with a fixed number of queries.
+	queries := 0
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("count_queries", func(*gorm.DB) { queries++ }))
+	countQueries := func(limit int) int {
+		queries = 0
+		page, err := repo.QueryRecipes(&models.RecipeQueryRequest{ViewerID: "bob", Sort: models.SortTitle, TotalMode: models.TotalNone, Limit: limit})
+		require.NoError(t, err)
+		require.Len(t, page.Recipes, limit)
+		return queries
+	}
+	assert.Equal(t, countQueries(1), countQueries(4))
+
+	page, err := repo.QueryRecipes(&models.RecipeQueryRequest{ViewerID: "bob", Sort: models.SortTitle, TotalMode: models.TotalNone, Limit: 10})
+	require.NoError(t, err)
+	flags := make(map[string][2]int)
+	for _, r := range page.Recipes {
+		favorited := 0
+		if r.IsFavorited {
+			favorited = 1
+		}
+		flags[r.ID] = [2]int{r.FavoriteCount, favorited}
+	}
+	assert.Equal(t, map[string][2]int{"r1": {1, 1}, "r2": {2, 1}, "r3": {0, 0}, "r4": {0, 0}}, flags)
+
+	// Favorites hidden from the user are left out of their list.
+	favorites, total, err := repo.ListFavorites("bob", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, favorites, 1)
+	assert.True(t, favorites[0].IsFavorited)
+	favorites, _, err = repo.ListFavorites("bob", 2, 1)
+	require.NoError(t, err)
+	require.Len(t, favorites, 1)
+
+	require.NoError(t, repo.RemoveFavorite("bob", "r1"))
+	require.NoError(t, repo.DeleteRecipe("r2"))
+	favorites, total, err = repo.ListFavorites("bob", 1, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total, "recipes in the trash are left out")
+	assert.Empty(t, favorites)
+	_, _, err = repo.PurgeDeletedRecipes(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	var left int64
+	require.NoError(t, db.Model(&models.Favorite{}).Where("recipe_id = ?", "r2").Count(&left).Error)
+	assert.Zero(t, left, "purging a recipe removes its favorites")
+}
+
+func TestRecipeRepository_Reviews(t *testing.T) {
+	repo := newRecipeTestRepo(t)
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, repo.CreateRecipe(&models.Recipe{ID: id, Title: "Recipe " + id, UserID: "alice"}, nil))
+	}
+	stale, err := repo.GetRecipeByID("a")
+	require.NoError(t, err)
+
+	for _, review := range []*models.Review{
+		{ID: "a1", RecipeID: "a", UserID: "u1", Rating: 5},
+		{ID: "a2", RecipeID: "a", UserID: "u2", Rating: 3},
+		{ID: "b1", RecipeID: "b", UserID: "u1", Rating: 4},
+		{ID: "c1", RecipeID: "c", UserID: "u1", Rating: 2},
+	} {
+		require.NoError(t, repo.CreateReview(review))
+	}
+	err = repo.CreateReview(&models.Review{ID: "a3", RecipeID: "a", UserID: "u1", Rating: 1})
+	assert.ErrorIs(t, err, repository.ErrDuplicateReview, "one review per user")
+
+	// Saving a recipe read before the reviews keeps their summary.
+	stale.Title = "Renamed"
+	require.NoError(t, repo.UpdateRecipe(stale, nil))
+	recipe, err := repo.GetRecipeByID("a")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", recipe.Title)
+	assert.Equal(t, models.RatingSummary{Average: 4, Count: 2, Total: 8, Histogram: models.RatingHistogram{Three: 1, Five: 1}}, recipe.Rating)
+
+	require.NoError(t, repo.UpdateReview(&models.Review{ID: "c1", Rating: 5, Body: "Better the second time."}))
+	require.NoError(t, repo.DeleteReview("a1"))
+	assert.ErrorIs(t, repo.DeleteReview("a1"), gorm.ErrRecordNotFound)
+	recipe, err = repo.GetRecipeByID("a")
+	require.NoError(t, err)
+	assert.Equal(t, models.RatingSummary{Average: 3, Count: 1, Total: 3, Histogram: models.RatingHistogram{Three: 1}}, recipe.Rating)
+	recipe, err = repo.GetRecipeByID("c")
+	require.NoError(t, err)
+	assert.Equal(t, models.RatingSummary{Average: 5, Count: 1, Total: 5, Histogram: models.RatingHistogram{Five: 1}}, recipe.Rating)
+
+	reviews, total, err := repo.ListReviews("c", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, "Better the second time.", reviews[0].Body)
+
+	// Sorting by rating pages through the stored averages.
+	req := &models.RecipeQueryRequest{Sort: models.SortRating, Limit: 2}
+	page, err := repo.QueryRecipes(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b"}, recipeIDs(page.Recipes))
+	req.Cursor = page.NextCursor
+	page, err = repo.QueryRecipes(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, recipeIDs(page.Recipes))
+}
+
+func recipeIDs(recipes []*models.Recipe) []string {
+	ids := make([]string, len(recipes))
+	for i, r := range recipes {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func recipeTitles(recipes []*models.Recipe) []string {
+	titles := make([]string, len(recipes))
+	for i, r := range recipes {
+		titles[i] = r.Title
+	}
+	return titles
+}
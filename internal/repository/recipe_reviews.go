@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ratingBuckets are the recipe columns counting the reviews that gave each
+// number of stars.
+var ratingBuckets = [...]string{1: "rating_stars_one", 2: "rating_stars_two", 3: "rating_stars_three", 4: "rating_stars_four", 5: "rating_stars_five"}
+
+// ratingColumns are the recipe columns holding models.RatingSummary. Only
+// the review methods write them; recipe saves omit them so an update never
+// overwrites ratings that changed since the recipe was read.
+var ratingColumns = append([]string{"rating_average", "rating_count", "rating_total"}, ratingBuckets[1:]...)
+
+// ErrDuplicateReview is returned by CreateReview when the user has already
+// reviewed the recipe.
+var ErrDuplicateReview = errors.New("review already exists")
+
+// CreateReview inserts a review and adds its rating to the recipe's summary
+// in one transaction. The unique index on recipe and user decides between
+// concurrent first reviews: the loser gets ErrDuplicateReview.
+func (r *recipeRepository) CreateReview(review *models.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(review)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDuplicateReview
+		}
+		return adjustRating(tx, review.RecipeID, review.Rating, 1)
+	})
+}
+
+// UpdateReview saves the rating and body of a review, moving the recipe's
+// summary from the previous rating to the new one. The stored review is
+// locked while it is compared, so concurrent edits are counted once each.
+// It returns gorm.ErrRecordNotFound when the review does not exist.
+func (r *recipeRepository) UpdateReview(review *models.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, "id = ?", review.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(review).Select("rating", "body", "updated_at").Updates(review).Error; err != nil {
+			return err
+		}
+		if current.Rating == review.Rating {
+			return nil
+		}
+		if err := adjustRating(tx, current.RecipeID, current.Rating, -1); err != nil {
+			return err
+		}
+		return adjustRating(tx, current.RecipeID, review.Rating, 1)
+	})
+}
+
+// DeleteReview removes a review and its rating from the recipe's summary.
+// It returns gorm.ErrRecordNotFound when the review does not exist.
+func (r *recipeRepository) DeleteReview(reviewID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, "id = ?", reviewID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&current).Error; err != nil {
+			return err
+		}
+		return adjustRating(tx, current.RecipeID, current.Rating, -1)
+	})
+}
+
+// GetReview retrieves a review by its ID.
+func (r *recipeRepository) GetReview(reviewID string) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.First(&review, "id = ?", reviewID).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// GetUserReview retrieves userID's review of a recipe.
+func (r *recipeRepository) GetUserReview(recipeID, userID string) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.First(&review, "recipe_id = ? AND user_id = ?", recipeID, userID).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// ListReviews returns one page of a recipe's reviews, newest first, and the
+// total number of reviews.
+func (r *recipeRepository) ListReviews(recipeID string, page, limit int) ([]*models.Review, int, error) {
+	var total int64
+	if err := r.db.Model(&models.Review{}).Where("recipe_id = ?", recipeID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var reviews []*models.Review
+	err := r.db.Where("recipe_id = ?", recipeID).
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return reviews, int(total), nil
+}
+
+// adjustRating adds (delta 1) or removes (delta -1) a rating of stars from a
+// recipe's summary. The counters are updated in SQL rather than from a read
+// copy, and the average is recomputed from them afterwards. Recipes in the
+// trash are included so their summary stays consistent on restore.
+func adjustRating(tx *gorm.DB, recipeID string, stars, delta int) error {
+	if stars < 1 || stars > 5 {
+		return fmt.Errorf("rating %d out of range", stars)
+	}
+	bucket := ratingBuckets[stars]
+	recipe := tx.Unscoped().Model(&models.Recipe{}).Where("id = ?", recipeID)
+	err := recipe.UpdateColumns(map[string]interface{}{
+		"rating_count": gorm.Expr("rating_count + ?", delta),
+		"rating_total": gorm.Expr("rating_total + ?", stars*delta),
+		bucket:         gorm.Expr(bucket+" + ?", delta),
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Unscoped().Model(&models.Recipe{}).Where("id = ?", recipeID).
+		UpdateColumn("rating_average", gorm.Expr("CASE WHEN rating_count > 0 THEN 1.0 * rating_total / rating_count ELSE 0 END")).Error
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// addRevision stores revision as the next revision of the recipe, numbering
+// it one past the latest. The unique (recipe_id, number) index makes a
+// concurrent writer that picked the same number fail rather than fork the
+// history. A nil revision is ignored.
+func addRevision(tx *gorm.DB, recipeID string, revision *models.RecipeRevision) error {
+	if revision == nil {
+		return nil
+	}
+	var latest int
+	err := tx.Model(&models.RecipeRevision{}).
+		Where("recipe_id = ?", recipeID).
+		Select("coalesce(max(number), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		return err
+	}
+	revision.RecipeID = recipeID
+	revision.Number = latest + 1
+	return tx.Create(revision).Error
+}
+
+// ListRevisions returns every revision of a recipe, newest first.
+func (r *recipeRepository) ListRevisions(recipeID string) ([]*models.RecipeRevision, error) {
+	var revisions []*models.RecipeRevision
+	if err := r.db.Where("recipe_id = ?", recipeID).Order("number DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetRevision retrieves a single revision of a recipe.
+// It returns gorm.ErrRecordNotFound when the recipe has no such revision.
+func (r *recipeRepository) GetRevision(recipeID string, number int) (*models.RecipeRevision, error) {
+	var revision models.RecipeRevision
+	if err := r.db.First(&revision, "recipe_id = ? AND number = ?", recipeID, number).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
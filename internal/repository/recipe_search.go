@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// recipeSearchConfig is the Postgres text search configuration used for
+// stemming and stop words.
+const recipeSearchConfig = "english"
+
+// recipeSearchMigrations add the full-text search column and index to the
+// recipes table. The column is generated from the title (weight A),
+// ingredients (B) and steps (C), so it never needs to be written by the
+// application. array_to_string is only STABLE, so an IMMUTABLE wrapper is
+// required before it can be used in a generated column.
+var recipeSearchMigrations = []string{
+	`CREATE OR REPLACE FUNCTION recipe_search_text(text[]) RETURNS text
+		LANGUAGE sql IMMUTABLE PARALLEL SAFE
+		AS $$ SELECT coalesce(array_to_string($1, ' '), '') $$`,
+	`ALTER TABLE recipes ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('` + recipeSearchConfig + `', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('` + recipeSearchConfig + `', recipe_search_text(ingredients)), 'B') ||
+			setweight(to_tsvector('` + recipeSearchConfig + `', recipe_search_text(steps)), 'C')
+		) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_recipes_search_vector ON recipes USING GIN (search_vector)`,
+}
+
+// MigrateRecipeSearch creates the full-text search column and GIN index on
+// the recipes table. It must run after the recipes table has been migrated and
+// is a no-op on databases other than Postgres.
+func MigrateRecipeSearch(db *gorm.DB) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	for _, stmt := range recipeSearchMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// searchRecipes restricts q to recipes matching the free-text query.
+// On Postgres the query is parsed with websearch_to_tsquery, so it supports
+// quoted phrases, "or" and -exclusions; elsewhere every word must appear in
+// the title, ingredients or steps.
+func searchRecipes(q *gorm.DB, query string) *gorm.DB {
+	if q.Dialector.Name() == "postgres" {
+		return q.Where("search_vector @@ websearch_to_tsquery('"+recipeSearchConfig+"', ?)", query)
+	}
+	for _, term := range strings.Fields(query) {
+		like := "%" + term + "%"
+		q = q.Where("title LIKE ? OR ingredients LIKE ? OR steps LIKE ?", like, like, like)
+	}
+	return q
+}
+
+// rankExpr scores how well a recipe matches query, weighting title matches
+// above ingredients and ingredients above steps. It requires Postgres.
+func rankExpr(query string) clause.Expr {
+	return clause.Expr{
+		SQL:  "ts_rank_cd(search_vector, websearch_to_tsquery('" + recipeSearchConfig + "', ?))",
+		Vars: []interface{}{query},
+	}
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+func TestSearchRecipesOnPostgres(t *testing.T) {
+	// DryRun renders SQL without needing a running server.
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=recipes"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		q := searchRecipes(tx.Model(&models.Recipe{}), "roast chicken")
+		var recipes []*models.Recipe
+		order := orderFor(tx, &models.RecipeQueryRequest{Query: "roast chicken", Sort: models.SortRelevance})
+		q = order.after(q, &recipeCursor{Sort: models.SortRelevance, ID: "r1", Rank: 0.5})
+		return order.apply(q, false).Find(&recipes)
+	})
+	assert.Contains(t, sql, `WHERE search_vector @@ websearch_to_tsquery('english', 'roast chicken')`)
+	assert.Contains(t, sql, `ts_rank_cd(search_vector, websearch_to_tsquery('english', 'roast chicken')) < 0.5 OR `+
+		`(ts_rank_cd(search_vector, websearch_to_tsquery('english', 'roast chicken')) = 0.5 AND id < 'r1')`)
+	assert.Contains(t, sql, `ORDER BY ts_rank_cd(search_vector, websearch_to_tsquery('english', 'roast chicken')) DESC, id DESC`)
+}
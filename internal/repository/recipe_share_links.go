@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// CreateShareLink inserts a share link row.
+func (r *recipeRepository) CreateShareLink(link *models.ShareLink) error {
+	return r.db.Create(link).Error
+}
+
+// GetShareLink retrieves a share link by its ID.
+func (r *recipeRepository) GetShareLink(linkID string) (*models.ShareLink, error) {
+	var link models.ShareLink
+	if err := r.db.First(&link, "id = ?", linkID).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// ListShareLinks returns the share links of a recipe, newest first,
+// including expired and revoked ones.
+func (r *recipeRepository) ListShareLinks(recipeID string) ([]*models.ShareLink, error) {
+	var links []*models.ShareLink
+	err := r.db.Where("recipe_id = ?", recipeID).Order("created_at DESC, id DESC").Find(&links).Error
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// RevokeShareLink stamps the revoked_at column of a share link, keeping the
+// original time if it was already revoked.
+func (r *recipeRepository) RevokeShareLink(linkID string, at time.Time) error {
+	return r.db.Model(&models.ShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", linkID).
+		Update("revoked_at", at).Error
+}
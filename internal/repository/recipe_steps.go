@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"reflect"
+
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/steps"
+	"gorm.io/gorm"
+)
+
+// backfillBatchSize is how many recipes the backfills load at a time.
+const backfillBatchSize = 200
+
+// BackfillRecipeSteps re-parses the steps of every stored recipe, including
+// those in the trash, and writes back the structured steps and prep, cook
+// and total times wherever they differ from what is stored. Recipes saved
+// before steps were parsed, or parsed by an older version of the parser,
+// would otherwise keep a zero total and never match a time filter. It returns
+// how many recipes were updated and is safe to run repeatedly.
+func BackfillRecipeSteps(db *gorm.DB) (int, error) {
+	updated := 0
+	var batch []*models.Recipe
+	result := db.Unscoped().Model(&models.Recipe{}).FindInBatches(&batch, backfillBatchSize, func(_ *gorm.DB, _ int) error {
+		for _, recipe := range batch {
+			before := *recipe
+			if len(recipe.StructuredIngredients) == 0 {
+				recipe.StructuredIngredients = ingredients.ParseAll(recipe.Ingredients)
+			}
+			steps.Annotate(recipe)
+			if recipe.TotalMinutes == before.TotalMinutes && recipe.PrepMinutes == before.PrepMinutes &&
+				recipe.CookMinutes == before.CookMinutes && reflect.DeepEqual(recipe.StructuredSteps, before.StructuredSteps) {
+				continue
+			}
+			err := db.Unscoped().Model(recipe).
+				Select("structured_steps", "prep_minutes", "cook_minutes", "total_minutes").
+				UpdateColumns(recipe).Error
+			if err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	return updated, result.Error
+}
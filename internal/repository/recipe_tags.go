@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// setTags replaces the tags linked to a recipe with recipe.Tags, creating
+// any tag that does not exist yet.
+func setTags(tx *gorm.DB, recipe *models.Recipe) error {
+	if err := tx.Delete(&models.RecipeTag{}, "recipe_id = ?", recipe.ID).Error; err != nil {
+		return err
+	}
+	if len(recipe.Tags) == 0 {
+		return nil
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&recipe.Tags).Error; err != nil {
+		return err
+	}
+	links := make([]models.RecipeTag, len(recipe.Tags))
+	for i, tag := range recipe.Tags {
+		links[i] = models.RecipeTag{RecipeID: recipe.ID, TagID: tag.ID}
+	}
+	return tx.Create(&links).Error
+}
+
+// loadTags fills in the Tags of each recipe with a single query.
+func loadTags(db *gorm.DB, recipes []*models.Recipe) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+	byID := make(map[string]*models.Recipe, len(recipes))
+	ids := make([]string, len(recipes))
+	for i, recipe := range recipes {
+		byID[recipe.ID] = recipe
+		ids[i] = recipe.ID
+	}
+	var rows []struct {
+		RecipeID string
+		models.Tag
+	}
+	err := db.Table("recipe_tags").
+		Select("recipe_tags.recipe_id, tags.*").
+		Joins("JOIN tags ON tags.id = recipe_tags.tag_id").
+		Where("recipe_tags.recipe_id IN ?", ids).
+		Order("tags.kind, tags.slug").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		recipe := byID[row.RecipeID]
+		recipe.Tags = append(recipe.Tags, row.Tag)
+	}
+	return nil
+}
+
+// tagCondition restricts a recipes query to recipes linked to tagID.
+const tagCondition = "EXISTS (SELECT 1 FROM recipe_tags WHERE recipe_tags.recipe_id = recipes.id AND recipe_tags.tag_id = ?)"
+
+// ListTags returns the tags of one kind, or of every kind when kind is empty,
+// with the number of recipes using each; the most used come first. Only public
+// recipes outside the trash are counted. Free-form tags no such recipe uses
+// are left out so names only seen on private or trashed recipes do not leak.
+func (r *recipeRepository) ListTags(kind string) ([]models.TagCount, error) {
+	q := r.db.Model(&models.Tag{}).
+		Select("tags.*, COUNT(recipes.id) AS recipe_count").
+		Joins("LEFT JOIN recipe_tags ON recipe_tags.tag_id = tags.id").
+		Joins("LEFT JOIN recipes ON recipes.id = recipe_tags.recipe_id AND recipes.deleted_at IS NULL AND recipes.visibility = ?", models.VisibilityPublic).
+		Group("tags.id").
+		Having("COUNT(recipes.id) > 0 OR tags.kind <> ?", models.TagKindUser).
+		Order("recipe_count DESC, tags.kind, tags.slug")
+	if kind != "" {
+		q = q.Where("tags.kind = ?", kind)
+	}
+	var counts []models.TagCount
+	if err := q.Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
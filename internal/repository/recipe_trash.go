@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// GetDeletedRecipe retrieves a soft-deleted recipe by its ID.
+func (r *recipeRepository) GetDeletedRecipe(recipeID string) (*models.Recipe, error) {
+	var recipe models.Recipe
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&recipe, "id = ?", recipeID).Error; err != nil {
+		return nil, err
+	}
+	if err := loadDetails(r.db, r.media, []*models.Recipe{&recipe}, ""); err != nil {
+		return nil, err
+	}
+	return &recipe, nil
+}
+
+// ListDeletedRecipes returns a user's soft-deleted recipes, most recently
+// deleted first.
+func (r *recipeRepository) ListDeletedRecipes(userID string) ([]*models.Recipe, error) {
+	var recipes []*models.Recipe
+	err := r.db.Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC, id DESC").
+		Find(&recipes).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := loadDetails(r.db, r.media, recipes, userID); err != nil {
+		return nil, err
+	}
+	return recipes, nil
+}
+
+// RestoreRecipe clears the deleted_at column of a soft-deleted recipe.
+// It returns gorm.ErrRecordNotFound when no recipe in the trash matched.
+func (r *recipeRepository) RestoreRecipe(recipeID string) error {
+	result := r.db.Unscoped().Model(&models.Recipe{}).
+		Where("id = ? AND deleted_at IS NOT NULL", recipeID).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedRecipes hard-deletes the recipes soft-deleted before the given
+// time together with their tag links, revisions, share links, collection
+// entries, favorites, reviews, comments and images, in one transaction,
+// collecting the blob keys of the images.
+func (r *recipeRepository) PurgeDeletedRecipes(before time.Time) (int, []string, error) {
+	var ids, blobKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Recipe{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Delete(&models.RecipeTag{}, "recipe_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.RecipeRevision{}, "recipe_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.ShareLink{}, "recipe_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.CollectionRecipe{}, "recipe_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Favorite{}, "recipe_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Review{}, "recipe_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Comment{}, "recipe_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		var images []models.RecipeImage
+		if err := tx.Where("recipe_id IN ?", ids).Find(&images).Error; err != nil {
+			return err
+		}
+		for _, image := range images {
+			blobKeys = append(blobKeys, image.BlobKeys...)
+		}
+		if err := tx.Delete(&models.RecipeImage{}, "recipe_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.Recipe{}, "id IN ?", ids).Error
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return len(ids), blobKeys, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// listedFor restricts a recipes query to the recipes that appear in
+// viewerID's listings: public recipes and the viewer's own. An empty
+// viewerID stands for an anonymous reader, who sees public recipes only.
+func listedFor(q *gorm.DB, viewerID string) *gorm.DB {
+	if viewerID == "" {
+		return q.Where("recipes.visibility = ?", models.VisibilityPublic)
+	}
+	return q.Where("(recipes.visibility = ? OR recipes.user_id = ?)", models.VisibilityPublic, viewerID)
+}
+
+// readableBy restricts a recipes query to the recipes viewerID may open by
+// ID: anything that is not private, plus the viewer's own.
+func readableBy(q *gorm.DB, viewerID string) *gorm.DB {
+	if viewerID == "" {
+		return q.Where("recipes.visibility <> ?", models.VisibilityPrivate)
+	}
+	return q.Where("(recipes.visibility <> ? OR recipes.user_id = ?)", models.VisibilityPrivate, viewerID)
+}
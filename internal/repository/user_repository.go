@@ -16,6 +16,9 @@ type UserRepository interface {
 	CreateUser(user *models.User) error
 	GetUserByEmail(email string) (*models.User, error)
 	GetUserByID(id string) (*models.User, error)
+	// GetUsersByUsernames returns the users with the given usernames; unknown
+	// names are skipped.
+	GetUsersByUsernames(usernames []string) ([]*models.User, error)
 }
 
 type userRepository struct {
@@ -55,3 +58,14 @@ func (r *userRepository) GetUserByID(id string) (*models.User, error) {
 	}
 	return &user, nil
 }
+
+func (r *userRepository) GetUsersByUsernames(usernames []string) ([]*models.User, error) {
+	var users []*models.User
+	if len(usernames) == 0 {
+		return users, nil
+	}
+	if err := r.db.Where("username IN ?", usernames).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
@@ -0,0 +1,129 @@
+// Package revisions compares snapshots of a recipe's content.
+package revisions
+
+import "github.com/pageza/recipe-book-api-v2/internal/models"
+
+// Diff returns the changes needed to turn revision from into revision to.
+func Diff(from, to *models.RecipeRevision) *models.RecipeDiff {
+	a, b := from.Snapshot, to.Snapshot
+	diff := &models.RecipeDiff{
+		From:        from.Number,
+		To:          to.Number,
+		Ingredients: DiffList(a.Ingredients, b.Ingredients),
+		Steps:       DiffList(a.Steps, b.Steps),
+		Appliances:  DiffList(a.Appliances, b.Appliances),
+		Tags:        DiffList(a.Tags, b.Tags),
+	}
+	if a.Title != b.Title {
+		diff.Title = &models.ValueChange{From: a.Title, To: b.Title}
+	}
+	if a.Servings != b.Servings {
+		diff.Servings = &models.ValueChange{From: a.Servings, To: b.Servings}
+	}
+	if a.NutritionalInfo != b.NutritionalInfo {
+		diff.NutritionalInfo = &models.ValueChange{From: a.NutritionalInfo, To: b.NutritionalInfo}
+	}
+	if a.AllergyDisclaimer != b.AllergyDisclaimer {
+		diff.AllergyDisclaimer = &models.ValueChange{From: a.AllergyDisclaimer, To: b.AllergyDisclaimer}
+	}
+	return diff
+}
+
+// ChangedFields lists the JSON names of the snapshot fields that differ
+// between a and b, in a fixed order.
+func ChangedFields(a, b models.RecipeSnapshot) []string {
+	var fields []string
+	if a.Title != b.Title {
+		fields = append(fields, "title")
+	}
+	if !equalLists(a.Ingredients, b.Ingredients) {
+		fields = append(fields, "ingredients")
+	}
+	if !equalLists(a.Steps, b.Steps) {
+		fields = append(fields, "steps")
+	}
+	if a.Servings != b.Servings {
+		fields = append(fields, "servings")
+	}
+	if a.NutritionalInfo != b.NutritionalInfo {
+		fields = append(fields, "nutritional_info")
+	}
+	if a.AllergyDisclaimer != b.AllergyDisclaimer {
+		fields = append(fields, "allergy_disclaimer")
+	}
+	if !equalLists(a.Appliances, b.Appliances) {
+		fields = append(fields, "appliances")
+	}
+	if !equalLists(a.Tags, b.Tags) {
+		fields = append(fields, "tags")
+	}
+	return fields
+}
+
+// DiffList compares two ordered lists using their longest common subsequence.
+// Within each run of differences, removed and added items are paired up in
+// order and reported as in-place changes; any surplus is reported as removed
+// or added.
+func DiffList(a, b []string) models.ListDiff {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff models.ListDiff
+	var removed, added []models.ListEntry
+	flush := func() {
+		n := min(len(removed), len(added))
+		for k := 0; k < n; k++ {
+			diff.Changed = append(diff.Changed, models.ListChange{
+				Index: added[k].Index,
+				From:  removed[k].Text,
+				To:    added[k].Text,
+			})
+		}
+		diff.Removed = append(diff.Removed, removed[n:]...)
+		diff.Added = append(diff.Added, added[n:]...)
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, models.ListEntry{Index: i, Text: a[i]})
+			i++
+		default:
+			added = append(added, models.ListEntry{Index: j, Text: b[j]})
+			j++
+		}
+	}
+	flush()
+	return diff
+}
+
+// equalLists reports whether a and b hold the same items in the same order.
+func equalLists(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package revisions
+
+import (
+	"testing"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffList(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want models.ListDiff
+	}{
+		{
+			name: "unchanged",
+			a:    []string{"x", "y"},
+			b:    []string{"x", "y"},
+			want: models.ListDiff{},
+		},
+		{
+			name: "added at end",
+			a:    []string{"x"},
+			b:    []string{"x", "y"},
+			want: models.ListDiff{Added: []models.ListEntry{{Index: 1, Text: "y"}}},
+		},
+		{
+			name: "removed from middle",
+			a:    []string{"x", "y", "z"},
+			b:    []string{"x", "z"},
+			want: models.ListDiff{Removed: []models.ListEntry{{Index: 1, Text: "y"}}},
+		},
+		{
+			name: "edited in place",
+			a:    []string{"1 cup flour", "1 egg", "1 cup milk"},
+			b:    []string{"2 cups flour", "1 egg", "1 cup milk"},
+			want: models.ListDiff{Changed: []models.ListChange{{Index: 0, From: "1 cup flour", To: "2 cups flour"}}},
+		},
+		{
+			name: "edit with extra insertion",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "B", "B2", "c"},
+			want: models.ListDiff{
+				Added:   []models.ListEntry{{Index: 2, Text: "B2"}},
+				Changed: []models.ListChange{{Index: 1, From: "b", To: "B"}},
+			},
+		},
+		{
+			name: "from empty",
+			a:    nil,
+			b:    []string{"x"},
+			want: models.ListDiff{Added: []models.ListEntry{{Index: 0, Text: "x"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DiffList(tt.a, tt.b))
+		})
+	}
+}
+
+func TestDiffAndChangedFields(t *testing.T) {
+	from := &models.RecipeRevision{Number: 1, Snapshot: models.RecipeSnapshot{
+		Title:       "Pancakes",
+		Ingredients: []string{"1 cup flour", "1 egg"},
+		Steps:       []string{"Mix.", "Fry."},
+		Servings:    2,
+		Appliances:  []string{"Stove"},
+	}}
+	to := &models.RecipeRevision{Number: 3, Snapshot: models.RecipeSnapshot{
+		Title:       "Fluffy Pancakes",
+		Ingredients: []string{"1 cup flour", "1 egg", "1 tsp baking powder"},
+		Steps:       []string{"Mix.", "Fry."},
+		Servings:    2,
+		Appliances:  []string{"Stove"},
+	}}
+
+	diff := Diff(from, to)
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 3, diff.To)
+	assert.Equal(t, &models.ValueChange{From: "Pancakes", To: "Fluffy Pancakes"}, diff.Title)
+	assert.Nil(t, diff.Servings)
+	assert.Equal(t, []models.ListEntry{{Index: 2, Text: "1 tsp baking powder"}}, diff.Ingredients.Added)
+	assert.True(t, diff.Steps.Empty())
+
+	assert.Equal(t, []string{"title", "ingredients"}, ChangedFields(from.Snapshot, to.Snapshot))
+	assert.Empty(t, ChangedFields(from.Snapshot, from.Snapshot))
+}
@@ -9,8 +9,8 @@ import (
 )
 
 // Register registers protected endpoints.
-// Since the resolver handles recipe generation via the query endpoint,
-// we only expose endpoints to query recipes and retrieve stored recipes.
+// The resolver handles recipe generation via the query endpoint; users may also
+// author their own recipes, which only they can update or delete.
 func Register(router *gin.Engine, cfg *config.Config, h *handlers.Handlers, recipeHandler *recipes.RecipeHandler) {
 	protected := router.Group("/")
 	protected.Use(middleware.JWTAuth(cfg.JWTSecret))
@@ -25,5 +25,68 @@ func Register(router *gin.Engine, cfg *config.Config, h *handlers.Handlers, reci
 		protected.GET("/recipe/:id", h.Recipe.Get)
 		// List all recipes (e.g., those previously generated for the logged-in user).
 		protected.GET("/recipes", recipeHandler.Query)
+		// Create a recipe owned by the logged-in user.
+		protected.POST("/recipes", h.Recipe.Create)
+		// Import schema.org Recipe JSON-LD, or HTML pages embedding it.
+		protected.POST("/recipes/import", h.Recipe.Import)
+		// Replace, partially update or delete a recipe owned by the logged-in user.
+		protected.PUT("/recipe/:id", h.Recipe.Update)
+		protected.PATCH("/recipe/:id", h.Recipe.Patch)
+		protected.DELETE("/recipe/:id", h.Recipe.Delete)
+		// Attach photos to a recipe owned by the logged-in user.
+		protected.POST("/recipe/:id/images", h.Recipe.UploadImage)
+		// Deleted recipes wait in the trash until restored or purged.
+		protected.GET("/trash", h.Recipe.Trash)
+		protected.POST("/trash/:id/restore", h.Recipe.Restore)
+		// Browse a recipe's revision history and restore earlier versions.
+		protected.GET("/recipe/:id/revisions", h.Recipe.Revisions)
+		protected.GET("/recipe/:id/revisions/diff", h.Recipe.DiffRevisions)
+		protected.GET("/recipe/:id/revisions/:number", h.Recipe.Revision)
+		protected.POST("/recipe/:id/revisions/:number/revert", h.Recipe.Revert)
+		// Fork a recipe into the logged-in user's own copy and explore its lineage.
+		protected.POST("/recipe/:id/fork", h.Recipe.Fork)
+		protected.GET("/recipe/:id/forks", h.Recipe.Forks)
+		protected.GET("/recipe/:id/ancestry", h.Recipe.Ancestry)
+		// Calculate a recipe's nutrition from its ingredients.
+		protected.GET("/recipe/:id/nutrition", h.Recipe.Nutrition)
+		// Render a recipe as a printable Markdown, HTML or PDF document.
+		protected.GET("/recipe/:id/export", h.Recipe.Export)
+		// Save recipes to the logged-in user's favorites.
+		protected.POST("/recipe/:id/favorite", h.Recipe.Favorite)
+		protected.DELETE("/recipe/:id/favorite", h.Recipe.Unfavorite)
+		protected.GET("/favorites", h.Recipe.Favorites)
+		// Rate and review recipes; each user keeps one review per recipe.
+		protected.GET("/recipe/:id/reviews", h.Recipe.Reviews)
+		protected.POST("/recipe/:id/reviews", h.Recipe.CreateReview)
+		protected.PUT("/recipe/:id/reviews/:reviewID", h.Recipe.UpdateReview)
+		protected.DELETE("/recipe/:id/reviews/:reviewID", h.Recipe.DeleteReview)
+		// Browse the tag taxonomy with recipe counts.
+		protected.GET("/tags", h.Recipe.Tags)
+		// Share a recipe owned by the logged-in user through expiring links.
+		if h.ShareLink != nil {
+			protected.POST("/recipe/:id/share-links", h.ShareLink.Create)
+			protected.GET("/recipe/:id/share-links", h.ShareLink.List)
+			protected.DELETE("/recipe/:id/share-links/:linkID", h.ShareLink.Revoke)
+		}
+		// Discuss recipes in threaded comments.
+		if h.Comment != nil {
+			protected.GET("/recipe/:id/comments", h.Comment.List)
+			protected.POST("/recipe/:id/comments", h.Comment.Create)
+			protected.PUT("/recipe/:id/comments/:commentID", h.Comment.Update)
+			protected.DELETE("/recipe/:id/comments/:commentID", h.Comment.Delete)
+		}
+		// Group recipes into ordered collections and share them with other users.
+		if h.Collection != nil {
+			protected.POST("/collections", h.Collection.Create)
+			protected.GET("/collections", h.Collection.List)
+			protected.GET("/collection/:id", h.Collection.Get)
+			protected.PUT("/collection/:id", h.Collection.Update)
+			protected.DELETE("/collection/:id", h.Collection.Delete)
+			protected.POST("/collection/:id/recipes", h.Collection.AddRecipe)
+			protected.PUT("/collection/:id/recipes/order", h.Collection.Reorder)
+			protected.DELETE("/collection/:id/recipes/:recipeID", h.Collection.RemoveRecipe)
+			protected.PUT("/collection/:id/members/:userID", h.Collection.Share)
+			protected.DELETE("/collection/:id/members/:userID", h.Collection.Unshare)
+		}
 	}
 }
@@ -1,6 +1,8 @@
 package publicroutes
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/pageza/recipe-book-api-v2/internal/handlers"
 )
@@ -9,4 +11,13 @@ import (
 func Register(router *gin.Engine, h *handlers.Handlers) {
 	router.POST("/register", h.User.Register)
 	router.POST("/login", h.User.Login)
+	// Uploaded recipe images; their keys are unguessable, so image URLs can
+	// be embedded without credentials.
+	if h.Media != nil {
+		router.GET("/media/*key", gin.WrapH(http.StripPrefix("/media", h.Media)))
+	}
+	// Recipes opened through a share link; the signed token is the credential.
+	if h.ShareLink != nil {
+		router.GET("/shared/:token", h.ShareLink.Resolve)
+	}
 }
@@ -64,7 +64,7 @@ func setupRouter() *gin.Engine {
 		JWTSecret: "testsecret",
 	}
 	// Register protected routes using the protectedroutes package.
-	recipeHandler := recipes.NewRecipeHandler(nil)
+	recipeHandler := recipes.NewRecipeHandler(nil, nil)
 	protectedroutes.Register(router, cfg, newDummyHandlers(), recipeHandler)
 
 	return router
@@ -154,7 +154,7 @@ func TestRouterRegistration(t *testing.T) {
 	cfg := &config.Config{}   // Assume a valid Config instance.
 	h := &handlers.Handlers{} // Assume a valid Handlers instance.
 	// Create a dummy RecipeHandler (in real code, pass a proper RecipeService).
-	recipeHandler := recipes.NewRecipeHandler(nil)
+	recipeHandler := recipes.NewRecipeHandler(nil, nil)
 	// Now call Register with the additional RecipeHandler.
 	protectedroutes.Register(r, cfg, h, recipeHandler)
 }
@@ -0,0 +1,279 @@
+package schemaorg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ErrNoRecipe is returned when a document contains no schema.org Recipe.
+var ErrNoRecipe = errors.New("no schema.org Recipe found")
+
+// leadingNumber matches the number at the start of texts like "250 kcal".
+var leadingNumber = regexp.MustCompile(`^\s*(\d+(?:[.,]\d+)?)\s*([a-zA-Z]*)`)
+
+// Parse extracts every Recipe from a JSON-LD document. Recipes may be the
+// top-level item, part of an array or an @graph, or nested inside another
+// item such as a WebPage's mainEntity. Only the fields this service stores
+// are read; ownership and IDs are left for the caller to assign.
+func Parse(data []byte) ([]*models.Recipe, error) {
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON-LD: %w", err)
+	}
+	var recipes []*models.Recipe
+	collectRecipes(doc, &recipes)
+	if len(recipes) == 0 {
+		return nil, ErrNoRecipe
+	}
+	return recipes, nil
+}
+
+// ParseHTML extracts the recipes from every <script type="application/ld+json">
+// block of an HTML page. Blocks that are not valid JSON are skipped, as pages
+// often carry unrelated or broken metadata.
+func ParseHTML(r io.Reader) ([]*models.Recipe, error) {
+	var recipes []*models.Recipe
+	z := html.NewTokenizer(r)
+	inScript := false
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return nil, err
+			}
+			if len(recipes) == 0 {
+				return nil, ErrNoRecipe
+			}
+			return recipes, nil
+		case html.StartTagToken:
+			tok := z.Token()
+			inScript = tok.DataAtom == atom.Script && isJSONLDScript(tok)
+		case html.TextToken:
+			if inScript {
+				found, err := Parse(z.Text())
+				if err == nil {
+					recipes = append(recipes, found...)
+				}
+			}
+		case html.EndTagToken:
+			inScript = false
+		}
+	}
+}
+
+// isJSONLDScript reports whether a script tag holds JSON-LD.
+func isJSONLDScript(tok html.Token) bool {
+	for _, attr := range tok.Attr {
+		if attr.Key == "type" {
+			return strings.EqualFold(strings.TrimSpace(attr.Val), MediaType)
+		}
+	}
+	return false
+}
+
+// collectRecipes walks a decoded JSON-LD value and appends every Recipe item.
+func collectRecipes(v interface{}, out *[]*models.Recipe) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, item := range v {
+			collectRecipes(item, out)
+		}
+	case map[string]interface{}:
+		if hasType(v, "Recipe") {
+			*out = append(*out, toRecipe(v))
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys) // keep the order of nested recipes stable
+		for _, key := range keys {
+			collectRecipes(v[key], out)
+		}
+	}
+}
+
+// hasType reports whether an item's @type includes name. Prefixed and IRI
+// forms such as "schema:Recipe" and "http://schema.org/Recipe" also match.
+func hasType(item map[string]interface{}, name string) bool {
+	for _, t := range texts(item["@type"]) {
+		if i := strings.LastIndexAny(t, "/:#"); i >= 0 {
+			t = t[i+1:]
+		}
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// toRecipe maps a schema.org Recipe item onto a recipe model.
+func toRecipe(item map[string]interface{}) *models.Recipe {
+	recipe := &models.Recipe{
+		Title:       strings.Join(strings.Fields(firstText(item["name"])), " "),
+		Ingredients: cleanTexts(texts(item["recipeIngredient"])),
+		Steps:       instructions(item["recipeInstructions"]),
+		Servings:    servings(item["recipeYield"]),
+		Appliances:  cleanTexts(names(item["tool"])),
+	}
+	if len(recipe.Ingredients) == 0 {
+		// "ingredients" is the superseded name of recipeIngredient.
+		recipe.Ingredients = cleanTexts(texts(item["ingredients"]))
+	}
+	if n, ok := item["nutrition"].(map[string]interface{}); ok {
+		recipe.NutritionalInfo = models.NutritionalInfo{
+			Calories:      energy(n["calories"]),
+			Protein:       mass(n["proteinContent"]),
+			Carbohydrates: mass(n["carbohydrateContent"]),
+			Fat:           mass(n["fatContent"]),
+			Fiber:         mass(n["fiberContent"]),
+		}
+	}
+	return recipe
+}
+
+// instructions flattens recipeInstructions into step texts. It accepts a
+// single text (one step per line), lists of texts, HowToStep items and
+// HowToSection items grouping further steps.
+func instructions(v interface{}) models.StringArray {
+	var steps models.StringArray
+	var walk func(interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case string:
+			for _, line := range strings.Split(v, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					steps = append(steps, line)
+				}
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case map[string]interface{}:
+			if list, ok := v["itemListElement"]; ok {
+				walk(list)
+				return
+			}
+			text := firstText(v["text"])
+			if text == "" {
+				text = firstText(v["name"])
+			}
+			walk(text)
+		}
+	}
+	walk(v)
+	return steps
+}
+
+// servings reads recipeYield, which may be a number, a text like "4 servings"
+// or a list of such values; the first number found wins.
+func servings(v interface{}) int {
+	for _, t := range texts(v) {
+		if m := leadingNumber.FindStringSubmatch(t); m != nil {
+			if n, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64); err == nil && n >= 1 {
+				return int(n)
+			}
+		}
+	}
+	return 0
+}
+
+// energy reads a schema.org Energy in kilocalories; kilojoules are converted.
+func energy(v interface{}) float64 {
+	n, unit := measure(v)
+	if strings.EqualFold(unit, "kj") {
+		return n / 4.184
+	}
+	return n
+}
+
+// mass reads a schema.org Mass in grams; milligrams are converted.
+func mass(v interface{}) float64 {
+	n, unit := measure(v)
+	if strings.EqualFold(unit, "mg") {
+		return n / 1000
+	}
+	return n
+}
+
+// measure splits a value like "12.5 g" into its number and unit. Bare
+// numbers have no unit.
+func measure(v interface{}) (float64, string) {
+	m := leadingNumber.FindStringSubmatch(firstText(v))
+	if m == nil {
+		return 0, ""
+	}
+	n, _ := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", "."), 64)
+	return n, m[2]
+}
+
+// texts returns the text values of a JSON-LD property, which may be a single
+// value or a list. Numbers are formatted and objects contribute their
+// @value, if any.
+func texts(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case json.Number:
+		return []string{v.String()}
+	case []interface{}:
+		var out []string
+		for _, item := range v {
+			out = append(out, texts(item)...)
+		}
+		return out
+	case map[string]interface{}:
+		return texts(v["@value"])
+	}
+	return nil
+}
+
+// names returns the texts of a property whose items may be plain texts or
+// items with a name, such as HowToTool.
+func names(v interface{}) []string {
+	switch v := v.(type) {
+	case []interface{}:
+		var out []string
+		for _, item := range v {
+			out = append(out, names(item)...)
+		}
+		return out
+	case map[string]interface{}:
+		return texts(v["name"])
+	}
+	return texts(v)
+}
+
+// firstText returns the first text value of a property, trimmed.
+func firstText(v interface{}) string {
+	if t := texts(v); len(t) > 0 {
+		return strings.TrimSpace(t[0])
+	}
+	return ""
+}
+
+// cleanTexts trims each text, collapses inner whitespace and drops empty ones.
+func cleanTexts(items []string) models.StringArray {
+	var out models.StringArray
+	for _, item := range items {
+		if item = strings.Join(strings.Fields(item), " "); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+// Package schemaorg converts recipes to and from schema.org Recipe JSON-LD,
+// the format most recipe sites and tools exchange.
+package schemaorg
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// MediaType is the content type of JSON-LD documents.
+const MediaType = "application/ld+json"
+
+// Context is the JSON-LD context of every document this package writes.
+const Context = "https://schema.org"
+
+// Recipe is a schema.org Recipe as written by FromRecipe. The allergy
+// disclaimer has no schema.org equivalent and is not included.
+type Recipe struct {
+	Context            string                `json:"@context"`
+	Type               string                `json:"@type"`
+	Identifier         string                `json:"identifier,omitempty"`
+	Name               string                `json:"name"`
+	Author             *Thing                `json:"author,omitempty"`
+	IsBasedOn          *Thing                `json:"isBasedOn,omitempty"`
+	DateCreated        string                `json:"dateCreated,omitempty"`
+	DateModified       string                `json:"dateModified,omitempty"`
+	RecipeYield        string                `json:"recipeYield,omitempty"`
+	PrepTime           string                `json:"prepTime,omitempty"`  // ISO 8601 duration, e.g. "PT15M"
+	CookTime           string                `json:"cookTime,omitempty"`  // ISO 8601 duration
+	TotalTime          string                `json:"totalTime,omitempty"` // ISO 8601 duration
+	RecipeIngredient   []string              `json:"recipeIngredient"`
+	RecipeInstructions []HowToStep           `json:"recipeInstructions"`
+	Nutrition          *NutritionInformation `json:"nutrition,omitempty"`
+	Tool               []Thing               `json:"tool,omitempty"`
+}
+
+// Thing is a minimal typed reference to another schema.org item.
+type Thing struct {
+	Type       string `json:"@type"`
+	Identifier string `json:"identifier,omitempty"`
+	Name       string `json:"name,omitempty"`
+}
+
+// HowToStep is a single numbered instruction.
+type HowToStep struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+}
+
+// NutritionInformation holds per-serving nutrition as schema.org Energy and
+// Mass texts, e.g. "250 kcal" and "12 g".
+type NutritionInformation struct {
+	Type                string `json:"@type"`
+	ServingSize         string `json:"servingSize,omitempty"`
+	Calories            string `json:"calories,omitempty"`
+	ProteinContent      string `json:"proteinContent,omitempty"`
+	CarbohydrateContent string `json:"carbohydrateContent,omitempty"`
+	FatContent          string `json:"fatContent,omitempty"`
+	FiberContent        string `json:"fiberContent,omitempty"`
+}
+
+// FromRecipe converts a recipe into a schema.org Recipe. Forks reference the
+// recipe they are based on, and the author is the recipe's owner.
+func FromRecipe(recipe *models.Recipe) *Recipe {
+	doc := &Recipe{
+		Context:            Context,
+		Type:               "Recipe",
+		Identifier:         recipe.ID,
+		Name:               recipe.Title,
+		RecipeIngredient:   append([]string{}, recipe.Ingredients...),
+		RecipeInstructions: make([]HowToStep, len(recipe.Steps)),
+	}
+	if recipe.UserID != "" {
+		doc.Author = &Thing{Type: "Person", Identifier: recipe.UserID}
+	}
+	if recipe.ParentRecipeID != "" {
+		doc.IsBasedOn = &Thing{Type: "Recipe", Identifier: recipe.ParentRecipeID}
+	}
+	if !recipe.CreatedAt.IsZero() {
+		doc.DateCreated = recipe.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if !recipe.UpdatedAt.IsZero() {
+		doc.DateModified = recipe.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	if recipe.Servings > 0 {
+		doc.RecipeYield = strconv.Itoa(recipe.Servings) + " servings"
+	}
+	if recipe.TotalMinutes > 0 {
+		doc.PrepTime = duration(recipe.PrepMinutes)
+		doc.CookTime = duration(recipe.CookMinutes)
+		doc.TotalTime = duration(recipe.TotalMinutes)
+	}
+	for i, step := range recipe.Steps {
+		doc.RecipeInstructions[i] = HowToStep{Type: "HowToStep", Position: i + 1, Text: step}
+	}
+	if n := recipe.NutritionalInfo; n != (models.NutritionalInfo{}) {
+		doc.Nutrition = &NutritionInformation{
+			Type:                "NutritionInformation",
+			ServingSize:         "1 serving",
+			Calories:            quantity(n.Calories, "kcal"),
+			ProteinContent:      quantity(n.Protein, "g"),
+			CarbohydrateContent: quantity(n.Carbohydrates, "g"),
+			FatContent:          quantity(n.Fat, "g"),
+			FiberContent:        quantity(n.Fiber, "g"),
+		}
+	}
+	for _, appliance := range recipe.Appliances {
+		doc.Tool = append(doc.Tool, Thing{Type: "HowToTool", Name: appliance})
+	}
+	return doc
+}
+
+// duration formats minutes as an ISO 8601 duration such as "PT1H30M".
+func duration(minutes int) string {
+	d := "PT"
+	if minutes >= 60 {
+		d += strconv.Itoa(minutes/60) + "H"
+	}
+	if minutes%60 != 0 || minutes == 0 {
+		d += strconv.Itoa(minutes%60) + "M"
+	}
+	return d
+}
+
+// quantity formats a value with its unit, or returns "" for zero.
+func quantity(v float64, unit string) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64) + " " + unit
+}
@@ -0,0 +1,126 @@
+package schemaorg_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/schemaorg"
+)
+
+func TestFromRecipeRoundTrip(t *testing.T) {
+	recipe := &models.Recipe{
+		ID:              "r-1",
+		Title:           "Shakshuka",
+		Ingredients:     []string{"2 tbsp olive oil", "1 onion, diced", "4 eggs"},
+		Steps:           []string{"Soften the onion.", "Crack in the eggs."},
+		Servings:        2,
+		NutritionalInfo: models.NutritionalInfo{Calories: 312.5, Protein: 14.2, Fat: 20},
+		Appliances:      []string{"Stove", "Skillet"},
+		UserID:          "user-42",
+		ParentRecipeID:  "r-0",
+		PrepMinutes:     10,
+		CookMinutes:     80,
+		TotalMinutes:    90,
+		CreatedAt:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(schemaorg.FromRecipe(recipe))
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "https://schema.org", doc["@context"])
+	assert.Equal(t, "Recipe", doc["@type"])
+	assert.Equal(t, "2 servings", doc["recipeYield"])
+	assert.Equal(t, "2025-01-02T03:04:05Z", doc["dateCreated"])
+	assert.Equal(t, "PT10M", doc["prepTime"])
+	assert.Equal(t, "PT1H20M", doc["cookTime"])
+	assert.Equal(t, "PT1H30M", doc["totalTime"])
+	steps := doc["recipeInstructions"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"@type": "HowToStep", "position": 2.0, "text": "Crack in the eggs."}, steps[1])
+	nutrition := doc["nutrition"].(map[string]interface{})
+	assert.Equal(t, "312.5 kcal", nutrition["calories"])
+	assert.Equal(t, "14.2 g", nutrition["proteinContent"])
+	assert.NotContains(t, nutrition, "fiberContent")
+
+	parsed, err := schemaorg.Parse(data)
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	got := parsed[0]
+	assert.Equal(t, recipe.Title, got.Title)
+	assert.Equal(t, recipe.Ingredients, got.Ingredients)
+	assert.Equal(t, recipe.Steps, got.Steps)
+	assert.Equal(t, recipe.Servings, got.Servings)
+	assert.Equal(t, recipe.NutritionalInfo, got.NutritionalInfo)
+	assert.Equal(t, recipe.Appliances, got.Appliances)
+}
+
+func TestParseVariants(t *testing.T) {
+	doc := `{
+		"@context": "https://schema.org",
+		"@graph": [
+			{"@type": "WebSite", "name": "Example"},
+			{"@type": ["Recipe", "NewsArticle"],
+			 "name": " Weeknight  Chili ",
+			 "recipeYield": ["6", "6 bowls"],
+			 "recipeIngredient": ["1 lb beef", "  1 can   beans ", ""],
+			 "recipeInstructions": [
+				{"@type": "HowToSection", "name": "Prep", "itemListElement": [
+					{"@type": "HowToStep", "text": "Brown the beef."}
+				]},
+				{"@type": "HowToStep", "name": "Simmer for an hour."},
+				"Serve."
+			 ],
+			 "nutrition": {"@type": "NutritionInformation", "calories": "2000 kJ", "sodiumContent": "900 mg", "fatContent": "500 mg"},
+			 "tool": ["Dutch oven", {"@type": "HowToTool", "name": "Ladle"}]
+			},
+			{"@type": "schema:Recipe", "name": "Toast", "ingredients": ["bread"], "recipeInstructions": "Toast the bread.\nButter it."}
+		]
+	}`
+	recipes, err := schemaorg.Parse([]byte(doc))
+	require.NoError(t, err)
+	require.Len(t, recipes, 2)
+
+	chili := recipes[0]
+	assert.Equal(t, "Weeknight Chili", chili.Title)
+	assert.Equal(t, 6, chili.Servings)
+	assert.Equal(t, models.StringArray{"1 lb beef", "1 can beans"}, chili.Ingredients)
+	assert.Equal(t, models.StringArray{"Brown the beef.", "Simmer for an hour.", "Serve."}, chili.Steps)
+	assert.InDelta(t, 478.0, chili.NutritionalInfo.Calories, 0.1)
+	assert.Equal(t, 0.5, chili.NutritionalInfo.Fat)
+	assert.Equal(t, models.StringArray{"Dutch oven", "Ladle"}, chili.Appliances)
+
+	toast := recipes[1]
+	assert.Equal(t, models.StringArray{"bread"}, toast.Ingredients)
+	assert.Equal(t, models.StringArray{"Toast the bread.", "Butter it."}, toast.Steps)
+
+	_, err = schemaorg.Parse([]byte(`{"@type": "Person", "name": "Ada"}`))
+	assert.ErrorIs(t, err, schemaorg.ErrNoRecipe)
+	_, err = schemaorg.Parse([]byte(`{"@type": `))
+	assert.Error(t, err)
+}
+
+func TestParseHTML(t *testing.T) {
+	page := `<!doctype html><html><head>
+		<script type="application/ld+json">{not json}</script>
+		<script type="text/javascript">var recipe = {"@type": "Recipe"};</script>
+		<script type="application/ld+json">
+			{"@context": "https://schema.org", "@type": "WebPage",
+			 "mainEntity": {"@type": "Recipe", "name": "Pancakes",
+				"recipeIngredient": ["1 cup flour"], "recipeInstructions": [{"@type": "HowToStep", "text": "Fry."}]}}
+		</script>
+	</head><body><h1>Pancakes</h1></body></html>`
+	recipes, err := schemaorg.ParseHTML(strings.NewReader(page))
+	require.NoError(t, err)
+	require.Len(t, recipes, 1)
+	assert.Equal(t, "Pancakes", recipes[0].Title)
+	assert.Equal(t, models.StringArray{"Fry."}, recipes[0].Steps)
+
+	_, err = schemaorg.ParseHTML(strings.NewReader("<html><body>No data</body></html>"))
+	assert.ErrorIs(t, err, schemaorg.ErrNoRecipe)
+}
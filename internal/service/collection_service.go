@@ -0,0 +1,381 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Limits applied to collections.
+const (
+	MaxCollectionNameLength        = 100
+	MaxCollectionDescriptionLength = 1000
+	MaxCollectionRecipes           = 500
+	MaxCollectionMembers           = 50
+)
+
+var (
+	// ErrCollectionNotFound is returned when a collection does not exist or
+	// is not shared with the requesting user.
+	ErrCollectionNotFound = errors.New("collection not found")
+	// ErrCollectionForbidden is returned when a user lacks the role an
+	// operation on a collection requires.
+	ErrCollectionForbidden = errors.New("not allowed to change this collection")
+	// ErrInvalidCollection is returned (wrapped with details) when a
+	// collection or one of its changes fails validation.
+	ErrInvalidCollection = errors.New("invalid collection")
+)
+
+// CollectionService defines the operations on recipe collections. Users
+// reach a collection as its owner or through a member role.
+type CollectionService interface {
+	// CreateCollection validates and stores a new collection owned by userID.
+	CreateCollection(userID string, collection *models.Collection) (*models.Collection, error)
+	// GetCollection retrieves a collection with the recipes userID may read.
+	GetCollection(userID, collectionID string) (*models.Collection, error)
+	// ListCollections returns the collections userID owns or is a member of.
+	ListCollections(userID string) ([]*models.Collection, error)
+	// UpdateCollection replaces the name and description of a collection owned by userID.
+	UpdateCollection(userID, collectionID string, collection *models.Collection) (*models.Collection, error)
+	// DeleteCollection removes a collection owned by userID. Its recipes are kept.
+	DeleteCollection(userID, collectionID string) error
+	// AddRecipe appends a recipe userID may read to a collection.
+	AddRecipe(userID, collectionID, recipeID string) (*models.Collection, error)
+	// RemoveRecipe takes a recipe out of a collection.
+	RemoveRecipe(userID, collectionID, recipeID string) (*models.Collection, error)
+	// ReorderRecipes moves the given recipes to the front of a collection.
+	ReorderRecipes(userID, collectionID string, recipeIDs []string) (*models.Collection, error)
+	// ShareCollection gives memberID a role on a collection owned by userID.
+	ShareCollection(userID, collectionID, memberID, role string) (*models.Collection, error)
+	// UnshareCollection removes memberID from a collection.
+	UnshareCollection(userID, collectionID, memberID string) error
+}
+
+// collectionService implements CollectionService.
+type collectionService struct {
+	repo    repository.CollectionRepository
+	recipes repository.RecipeRepository
+	users   repository.UserRepository
+}
+
+// NewCollectionService creates a new CollectionService. recipes is used to
+// check that added recipes exist and are visible to the user adding them,
+// and users that collections are only shared with existing users.
+func NewCollectionService(repo repository.CollectionRepository, recipes repository.RecipeRepository, users repository.UserRepository) CollectionService {
+	return &collectionService{repo: repo, recipes: recipes, users: users}
+}
+
+// CreateCollection stamps the collection with a fresh ID and its owner,
+// validates it and persists it.
+func (s *collectionService) CreateCollection(userID string, collection *models.Collection) (*models.Collection, error) {
+	if err := validateCollection(collection); err != nil {
+		return nil, err
+	}
+	collection.ID = uuid.New().String()
+	collection.UserID = userID
+	if err := s.repo.CreateCollection(collection); err != nil {
+		log.Printf("CreateCollection: failed to create collection for user %s: %v", userID, err)
+		return nil, err
+	}
+	collection.Role = models.CollectionRoleOwner
+	log.Printf("CreateCollection: user %s created collection %s", userID, collection.ID)
+	return collection, nil
+}
+
+// GetCollection returns the collection with its members and, in order, the
+// recipes userID may read; other users' private recipes are left out.
+func (s *collectionService) GetCollection(userID, collectionID string) (*models.Collection, error) {
+	collection, err := s.access(userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.Recipes, err = s.repo.ListCollectionRecipes(collectionID, userID); err != nil {
+		return nil, fmt.Errorf("repository collection error: %v", err)
+	}
+	if collection.Members, err = s.repo.ListCollectionMembers(collectionID); err != nil {
+		return nil, fmt.Errorf("repository collection error: %v", err)
+	}
+	return collection, nil
+}
+
+// ListCollections returns the collections userID can open, without their
+// recipes, most recently updated first. userID's roles are read with one
+// query for all collections.
+func (s *collectionService) ListCollections(userID string) ([]*models.Collection, error) {
+	collections, err := s.repo.ListCollections(userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository collection error: %v", err)
+	}
+	memberships, err := s.repo.ListMemberships(userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository collection error: %v", err)
+	}
+	roles := make(map[string]string, len(memberships))
+	for _, m := range memberships {
+		roles[m.CollectionID] = m.Role
+	}
+	for _, c := range collections {
+		if c.UserID == userID {
+			c.Role = models.CollectionRoleOwner
+		} else {
+			c.Role = roles[c.ID]
+		}
+	}
+	return collections, nil
+}
+
+// UpdateCollection copies the name and description onto the stored collection.
+func (s *collectionService) UpdateCollection(userID, collectionID string, collection *models.Collection) (*models.Collection, error) {
+	existing, err := s.requireRole(userID, collectionID, models.CollectionRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCollection(collection); err != nil {
+		return nil, err
+	}
+	existing.Name = collection.Name
+	existing.Description = collection.Description
+	if err := s.repo.UpdateCollection(existing); err != nil {
+		log.Printf("UpdateCollection: failed to update collection %s: %v", collectionID, err)
+		return nil, err
+	}
+	return s.GetCollection(userID, collectionID)
+}
+
+// DeleteCollection removes the collection and its memberships.
+func (s *collectionService) DeleteCollection(userID, collectionID string) error {
+	if _, err := s.requireRole(userID, collectionID, models.CollectionRoleOwner); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteCollection(collectionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCollectionNotFound
+		}
+		log.Printf("DeleteCollection: failed to delete collection %s: %v", collectionID, err)
+		return err
+	}
+	log.Printf("DeleteCollection: user %s deleted collection %s", userID, collectionID)
+	return nil
+}
+
+// AddRecipe appends a recipe to the collection. Any recipe userID can open
+// may be added, including other users' public and unlisted recipes. It
+// returns ErrRecipeNotFound if the recipe is missing or hidden from userID.
+func (s *collectionService) AddRecipe(userID, collectionID, recipeID string) (*models.Collection, error) {
+	if _, err := s.requireRole(userID, collectionID, models.CollectionRoleCollaborator); err != nil {
+		return nil, err
+	}
+	if _, err := s.recipes.GetVisibleRecipe(recipeID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, err
+	}
+	ids, err := s.repo.CollectionRecipeIDs(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("repository collection error: %v", err)
+	}
+	if len(ids) >= MaxCollectionRecipes {
+		return nil, fmt.Errorf("%w: a collection holds at most %d recipes", ErrInvalidCollection, MaxCollectionRecipes)
+	}
+	entry := &models.CollectionRecipe{CollectionID: collectionID, RecipeID: recipeID, AddedBy: userID}
+	if err := s.repo.AddCollectionRecipe(entry); err != nil {
+		log.Printf("AddRecipe: failed to add recipe %s to collection %s: %v", recipeID, collectionID, err)
+		return nil, err
+	}
+	return s.GetCollection(userID, collectionID)
+}
+
+// RemoveRecipe takes a recipe out of the collection. It returns
+// ErrRecipeNotFound if the recipe is not in the collection.
+func (s *collectionService) RemoveRecipe(userID, collectionID, recipeID string) (*models.Collection, error) {
+	if _, err := s.requireRole(userID, collectionID, models.CollectionRoleCollaborator); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RemoveCollectionRecipe(collectionID, recipeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		log.Printf("RemoveRecipe: failed to remove recipe %s from collection %s: %v", recipeID, collectionID, err)
+		return nil, err
+	}
+	return s.GetCollection(userID, collectionID)
+}
+
+// ReorderRecipes places recipeIDs first, in the given order, followed by
+// the remaining recipes in their current order. Listing only the recipes a
+// user can see therefore never disturbs ones hidden from them.
+func (s *collectionService) ReorderRecipes(userID, collectionID string, recipeIDs []string) (*models.Collection, error) {
+	if _, err := s.requireRole(userID, collectionID, models.CollectionRoleCollaborator); err != nil {
+		return nil, err
+	}
+	current, err := s.repo.CollectionRecipeIDs(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("repository collection error: %v", err)
+	}
+	remaining := make(map[string]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	order := make([]string, 0, len(current))
+	for _, id := range recipeIDs {
+		if !remaining[id] {
+			return nil, fmt.Errorf("%w: recipe %q is not in the collection or is listed twice", ErrInvalidCollection, id)
+		}
+		delete(remaining, id)
+		order = append(order, id)
+	}
+	for _, id := range current {
+		if remaining[id] {
+			order = append(order, id)
+		}
+	}
+	if err := s.repo.SetCollectionOrder(collectionID, order); err != nil {
+		log.Printf("ReorderRecipes: failed to reorder collection %s: %v", collectionID, err)
+		return nil, err
+	}
+	return s.GetCollection(userID, collectionID)
+}
+
+// ShareCollection adds memberID to the collection with the given role, or
+// changes the role of an existing member. Only the owner can share, and only
+// with existing users; ErrUserNotFound is returned for unknown ones.
+func (s *collectionService) ShareCollection(userID, collectionID, memberID, role string) (*models.Collection, error) {
+	collection, err := s.requireRole(userID, collectionID, models.CollectionRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case role != models.CollectionRoleViewer && role != models.CollectionRoleCollaborator:
+		return nil, fmt.Errorf("%w: role must be %q or %q", ErrInvalidCollection, models.CollectionRoleViewer, models.CollectionRoleCollaborator)
+	case strings.TrimSpace(memberID) == "":
+		return nil, fmt.Errorf("%w: member is required", ErrInvalidCollection)
+	case memberID == collection.UserID:
+		return nil, fmt.Errorf("%w: the owner cannot be added as a member", ErrInvalidCollection)
+	}
+	if _, err := s.users.GetUserByID(memberID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, memberID)
+	} else if err != nil {
+		return nil, fmt.Errorf("repository user error: %v", err)
+	}
+	members, err := s.repo.ListCollectionMembers(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("repository collection error: %v", err)
+	}
+	if len(members) >= MaxCollectionMembers && !hasMember(members, memberID) {
+		return nil, fmt.Errorf("%w: a collection is shared with at most %d users", ErrInvalidCollection, MaxCollectionMembers)
+	}
+	member := &models.CollectionMember{CollectionID: collectionID, UserID: memberID, Role: role}
+	if err := s.repo.SaveCollectionMember(member); err != nil {
+		log.Printf("ShareCollection: failed to share collection %s: %v", collectionID, err)
+		return nil, err
+	}
+	log.Printf("ShareCollection: user %s shared collection %s with %s as %s", userID, collectionID, memberID, role)
+	return s.GetCollection(userID, collectionID)
+}
+
+// UnshareCollection removes memberID from the collection. The owner can
+// remove anyone, and members can remove themselves to leave a collection.
+func (s *collectionService) UnshareCollection(userID, collectionID, memberID string) error {
+	collection, err := s.access(userID, collectionID)
+	if err != nil {
+		return err
+	}
+	if collection.Role != models.CollectionRoleOwner && memberID != userID {
+		return ErrCollectionForbidden
+	}
+	if err := s.repo.RemoveCollectionMember(collectionID, memberID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: %s is not a member", ErrInvalidCollection, memberID)
+		}
+		log.Printf("UnshareCollection: failed to remove %s from collection %s: %v", memberID, collectionID, err)
+		return err
+	}
+	log.Printf("UnshareCollection: user %s removed %s from collection %s", userID, memberID, collectionID)
+	return nil
+}
+
+// access loads a collection and sets the role userID holds on it. Users
+// without a role get ErrCollectionNotFound, so private collections are not
+// revealed.
+func (s *collectionService) access(userID, collectionID string) (*models.Collection, error) {
+	collection, err := s.repo.GetCollection(collectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	if collection.Role, err = s.role(userID, collection); err != nil {
+		return nil, err
+	}
+	if collection.Role == "" {
+		return nil, ErrCollectionNotFound
+	}
+	return collection, nil
+}
+
+// requireRole loads a collection and checks that userID holds at least the
+// given role, where the owner outranks collaborators and collaborators
+// outrank viewers.
+func (s *collectionService) requireRole(userID, collectionID, role string) (*models.Collection, error) {
+	collection, err := s.access(userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := collection.Role == models.CollectionRoleOwner ||
+		(role == models.CollectionRoleCollaborator && models.CanEditCollection(collection.Role)) ||
+		role == models.CollectionRoleViewer
+	if !allowed {
+		log.Printf("requireRole: user %s with role %q attempted a %s change to collection %s", userID, collection.Role, role, collectionID)
+		return nil, ErrCollectionForbidden
+	}
+	return collection, nil
+}
+
+// role returns the role userID holds on collection, or "" for none.
+func (s *collectionService) role(userID string, collection *models.Collection) (string, error) {
+	if collection.UserID == userID {
+		return models.CollectionRoleOwner, nil
+	}
+	member, err := s.repo.GetCollectionMember(collection.ID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// hasMember reports whether userID is among members.
+func hasMember(members []models.CollectionMember, userID string) bool {
+	for _, m := range members {
+		if m.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCollection checks the user-editable fields of a collection and
+// trims its name. Errors wrap ErrInvalidCollection.
+func validateCollection(collection *models.Collection) error {
+	name := strings.TrimSpace(collection.Name)
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidCollection)
+	case len(name) > MaxCollectionNameLength:
+		return fmt.Errorf("%w: name exceeds %d characters", ErrInvalidCollection, MaxCollectionNameLength)
+	case len(collection.Description) > MaxCollectionDescriptionLength:
+		return fmt.Errorf("%w: description exceeds %d characters", ErrInvalidCollection, MaxCollectionDescriptionLength)
+	}
+	collection.Name = name
+	return nil
+}
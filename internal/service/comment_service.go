@@ -0,0 +1,272 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/mentions"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Limits applied to comments.
+const (
+	// MaxCommentDepth is the deepest a reply may be nested; top-level
+	// comments have depth 0.
+	MaxCommentDepth         = 3
+	MaxCommentLength        = 5000
+	DefaultCommentPageLimit = 20
+	MaxCommentPageLimit     = 100
+)
+
+var (
+	// ErrCommentNotFound is returned when a recipe has no comment with the requested ID.
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentForbidden is returned when a user tries to change another user's comment.
+	ErrCommentForbidden = errors.New("comment belongs to another user")
+	// ErrInvalidComment is returned (wrapped with details) when a comment fails validation.
+	ErrInvalidComment = errors.New("invalid comment")
+)
+
+// Notifier delivers a message to a user. *NotificationService implements it.
+type Notifier interface {
+	SendNotification(userID, message string) error
+}
+
+// CommentService defines the operations on recipe comments.
+type CommentService interface {
+	// CreateComment posts a comment under a recipe as userID, or a reply when
+	// comment.ParentID is set.
+	CreateComment(userID, recipeID string, comment *models.Comment) (*models.Comment, error)
+	// UpdateComment replaces the body of a comment written by userID.
+	UpdateComment(userID, recipeID, commentID, body string) (*models.Comment, error)
+	// DeleteComment removes a comment written by userID.
+	DeleteComment(userID, recipeID, commentID string) error
+	// ListComments returns one page of a recipe's top-level comments, or of
+	// the replies to parentID.
+	ListComments(viewerID, recipeID, parentID, cursor string, limit int) (*models.CommentPage, error)
+}
+
+// commentService implements CommentService.
+type commentService struct {
+	repo     repository.CommentRepository
+	recipes  repository.RecipeRepository
+	users    repository.UserRepository
+	notifier Notifier
+}
+
+// NewCommentService creates a new CommentService. Users mentioned as
+// @username in comments are notified through notifier; a nil notifier
+// disables mention notifications.
+func NewCommentService(repo repository.CommentRepository, recipes repository.RecipeRepository, users repository.UserRepository, notifier Notifier) CommentService {
+	return &commentService{repo: repo, recipes: recipes, users: users, notifier: notifier}
+}
+
+// CreateComment validates and stores a comment on a recipe userID can read.
+// Replies are nested under their parent up to MaxCommentDepth; deleted
+// comments cannot be replied to. Mentioned users are notified.
+func (s *commentService) CreateComment(userID, recipeID string, comment *models.Comment) (*models.Comment, error) {
+	if err := validateComment(comment); err != nil {
+		return nil, err
+	}
+	recipe, err := s.readableRecipe(userID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.ID = uuid.New().String()
+	comment.RecipeID = recipeID
+	comment.UserID = userID
+	comment.ThreadID = comment.ID
+	comment.Depth = 0
+	comment.Deleted = false
+	if comment.ParentID != "" {
+		parent, err := s.getComment(recipeID, comment.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Deleted {
+			return nil, fmt.Errorf("%w: cannot reply to a deleted comment", ErrInvalidComment)
+		}
+		if parent.Depth >= MaxCommentDepth {
+			return nil, fmt.Errorf("%w: replies cannot be nested more than %d levels deep", ErrInvalidComment, MaxCommentDepth)
+		}
+		comment.ThreadID = parent.ThreadID
+		comment.Depth = parent.Depth + 1
+	}
+	if err := s.repo.CreateComment(comment); err != nil {
+		log.Printf("CreateComment: failed to comment on recipe %s for user %s: %v", recipeID, userID, err)
+		return nil, err
+	}
+	log.Printf("CreateComment: user %s commented %s on recipe %s", userID, comment.ID, recipeID)
+	s.notifyMentions(userID, recipe, mentions.Parse(comment.Body))
+	return comment, nil
+}
+
+// UpdateComment replaces the body of a comment written by userID. Users
+// mentioned for the first time are notified. It returns ErrCommentNotFound
+// if the recipe has no such comment or it was deleted, and
+// ErrCommentForbidden if someone else wrote it.
+func (s *commentService) UpdateComment(userID, recipeID, commentID, body string) (*models.Comment, error) {
+	comment, err := s.getOwnedComment(userID, recipeID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	previous := comment.Body
+	comment.Body = body
+	if err := validateComment(comment); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateComment(comment); err != nil {
+		log.Printf("UpdateComment: failed to update comment %s: %v", commentID, err)
+		return nil, err
+	}
+	log.Printf("UpdateComment: user %s edited comment %s", userID, commentID)
+	if added := mentions.Added(previous, comment.Body); len(added) > 0 {
+		if recipe, err := s.recipes.GetRecipeByID(recipeID); err == nil {
+			s.notifyMentions(userID, recipe, added)
+		}
+	}
+	return comment, nil
+}
+
+// DeleteComment removes a comment written by userID. A comment with replies
+// is kept as a deleted placeholder so its thread stays intact. It returns
+// ErrCommentNotFound if the recipe has no such comment and
+// ErrCommentForbidden if someone else wrote it.
+func (s *commentService) DeleteComment(userID, recipeID, commentID string) error {
+	if _, err := s.getOwnedComment(userID, recipeID, commentID); err != nil {
+		return err
+	}
+	placeholder, err := s.repo.DeleteComment(commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCommentNotFound
+		}
+		log.Printf("DeleteComment: failed to delete comment %s: %v", commentID, err)
+		return err
+	}
+	log.Printf("DeleteComment: user %s deleted comment %s (placeholder: %t)", userID, commentID, placeholder)
+	return nil
+}
+
+// ListComments returns one page of comments on a recipe viewerID can read,
+// oldest first: the top-level comments when parentID is empty, otherwise the
+// direct replies to parentID. Each comment carries its reply count so
+// clients can fetch deeper levels on demand. Missing or out-of-range limits
+// are replaced with defaults.
+func (s *commentService) ListComments(viewerID, recipeID, parentID, cursor string, limit int) (*models.CommentPage, error) {
+	if _, err := s.readableRecipe(viewerID, recipeID); err != nil {
+		return nil, err
+	}
+	if parentID != "" {
+		if _, err := s.getComment(recipeID, parentID); err != nil {
+			return nil, err
+		}
+	}
+	if limit < 1 {
+		limit = DefaultCommentPageLimit
+	}
+	if limit > MaxCommentPageLimit {
+		limit = MaxCommentPageLimit
+	}
+	page, err := s.repo.ListComments(recipeID, parentID, cursor, limit)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidComment, err)
+		}
+		return nil, fmt.Errorf("repository comments error: %v", err)
+	}
+	return page, nil
+}
+
+// readableRecipe fetches a recipe, treating recipes hidden from viewerID as missing.
+func (s *commentService) readableRecipe(viewerID, recipeID string) (*models.Recipe, error) {
+	recipe, err := s.recipes.GetVisibleRecipe(recipeID, viewerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// getComment fetches a comment and verifies it belongs to recipeID.
+func (s *commentService) getComment(recipeID, commentID string) (*models.Comment, error) {
+	comment, err := s.repo.GetComment(commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	if comment.RecipeID != recipeID {
+		return nil, ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// getOwnedComment fetches a comment that is not deleted and verifies userID wrote it.
+func (s *commentService) getOwnedComment(userID, recipeID, commentID string) (*models.Comment, error) {
+	comment, err := s.getComment(recipeID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, ErrCommentNotFound
+	}
+	if comment.UserID != userID {
+		log.Printf("getOwnedComment: user %s attempted to modify comment %s written by %s", userID, commentID, comment.UserID)
+		return nil, ErrCommentForbidden
+	}
+	return comment, nil
+}
+
+// notifyMentions tells the users named in usernames that authorID mentioned
+// them under recipe. Unknown usernames, the author and users who cannot see
+// the recipe are skipped, and delivery failures are logged rather than
+// failing the comment.
+func (s *commentService) notifyMentions(authorID string, recipe *models.Recipe, usernames []string) {
+	if s.notifier == nil || len(usernames) == 0 {
+		return
+	}
+	users, err := s.users.GetUsersByUsernames(usernames)
+	if err != nil {
+		log.Printf("notifyMentions: failed to look up mentioned users: %v", err)
+		return
+	}
+	author := "Someone"
+	if user, err := s.users.GetUserByID(authorID); err == nil {
+		author = "@" + user.Username
+	}
+	message := fmt.Sprintf("%s mentioned you in a comment on %q", author, recipe.Title)
+	for _, user := range users {
+		if user.ID == authorID {
+			continue
+		}
+		if _, err := s.recipes.GetVisibleRecipe(recipe.ID, user.ID); err != nil {
+			continue
+		}
+		if err := s.notifier.SendNotification(user.ID, message); err != nil {
+			log.Printf("notifyMentions: failed to notify user %s: %v", user.ID, err)
+		}
+	}
+}
+
+// validateComment trims the body of a comment and checks it is present and
+// within MaxCommentLength.
+func validateComment(comment *models.Comment) error {
+	comment.Body = strings.TrimSpace(comment.Body)
+	switch {
+	case comment.Body == "":
+		return fmt.Errorf("%w: body is required", ErrInvalidComment)
+	case len(comment.Body) > MaxCommentLength:
+		return fmt.Errorf("%w: body exceeds %d characters", ErrInvalidComment, MaxCommentLength)
+	}
+	return nil
+}
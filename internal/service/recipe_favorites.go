@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// FavoriteRecipe adds a recipe userID can read to their favorites and
+// returns it with its updated favorite count. Favoriting a recipe again has
+// no effect. It returns ErrRecipeNotFound if the recipe is hidden from userID.
+func (s *recipeService) FavoriteRecipe(userID, recipeID string) (*models.Recipe, error) {
+	if _, err := s.GetRecipe(userID, recipeID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddFavorite(userID, recipeID); err != nil {
+		log.Printf("FavoriteRecipe: failed to favorite recipe %s for user %s: %v", recipeID, userID, err)
+		return nil, err
+	}
+	log.Printf("FavoriteRecipe: user %s favorited recipe %s", userID, recipeID)
+	return s.GetRecipe(userID, recipeID)
+}
+
+// UnfavoriteRecipe removes a recipe from userID's favorites. It succeeds if
+// the recipe was not a favorite, and also works for recipes that have since
+// become hidden from userID.
+func (s *recipeService) UnfavoriteRecipe(userID, recipeID string) error {
+	if err := s.repo.RemoveFavorite(userID, recipeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		log.Printf("UnfavoriteRecipe: failed to unfavorite recipe %s for user %s: %v", recipeID, userID, err)
+		return err
+	}
+	log.Printf("UnfavoriteRecipe: user %s unfavorited recipe %s", userID, recipeID)
+	return nil
+}
+
+// ListFavorites returns one page of the recipes userID favorited, most
+// recently favorited first. Favorites that were deleted or made private by
+// their owners are left out. Pagination defaults match QueryRecipes.
+func (s *recipeService) ListFavorites(userID string, page, limit int) (*models.RecipeQueryResponse, error) {
+	page, limit = pageBounds(page, limit)
+	recipes, total, err := s.repo.ListFavorites(userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository favorites error: %v", err)
+	}
+	return &models.RecipeQueryResponse{
+		Recipes:   recipes,
+		Page:      page,
+		Limit:     limit,
+		Total:     total,
+		TotalMode: models.TotalExact,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/allergens"
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/nutrition"
+	"github.com/pageza/recipe-book-api-v2/internal/steps"
+)
+
+// ForkRecipe copies a recipe into a new recipe owned by userID. The copy
+// records its parent and keeps crediting the author of the first recipe in
+// the chain and the source's visibility; from then on it is edited
+// independently of the original.
+func (s *recipeService) ForkRecipe(userID, recipeID string) (*models.Recipe, error) {
+	source, err := s.GetRecipe(userID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	fork := &models.Recipe{
+		ID:                uuid.New().String(),
+		Title:             source.Title,
+		Ingredients:       append(models.StringArray(nil), source.Ingredients...),
+		Steps:             append(models.StringArray(nil), source.Steps...),
+		Servings:          source.Servings,
+		NutritionalInfo:   source.NutritionalInfo,
+		AllergyDisclaimer: source.AllergyDisclaimer,
+		Appliances:        append(models.StringArray(nil), source.Appliances...),
+		Tags:              append([]models.Tag(nil), source.Tags...),
+		UserID:            userID,
+		ParentRecipeID:    source.ID,
+		OriginalUserID:    source.OriginalUserID,
+		Visibility:        source.Visibility,
+	}
+	if fork.OriginalUserID == "" {
+		fork.OriginalUserID = source.UserID
+	}
+	fork.StructuredIngredients = ingredients.ParseAll(fork.Ingredients)
+	steps.Annotate(fork)
+	allergens.Annotate(fork, source.Allergens)
+	nutrition.Fill(fork, models.NutritionalInfo{})
+
+	revision := newRevision(userID, models.RevisionFork, fork.Snapshot(), nil)
+	if err := s.repo.CreateRecipe(fork, revision); err != nil {
+		log.Printf("ForkRecipe: failed to fork recipe %s for user %s: %v", recipeID, userID, err)
+		return nil, err
+	}
+	log.Printf("ForkRecipe: user %s forked recipe %s into %s", userID, recipeID, fork.ID)
+	return fork, nil
+}
+
+// ListForks returns the public recipes, and those owned by viewerID, forked
+// directly from recipeID, newest first. It returns ErrRecipeNotFound if the
+// recipe does not exist or is hidden from viewerID.
+func (s *recipeService) ListForks(viewerID, recipeID string) ([]*models.Recipe, error) {
+	if _, err := s.GetRecipe(viewerID, recipeID); err != nil {
+		return nil, err
+	}
+	forks, err := s.repo.ListForks(recipeID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("repository fork error: %v", err)
+	}
+	return forks, nil
+}
+
+// RecipeAncestry returns the chain of recipes recipeID was forked from,
+// starting with its parent and ending with the original. The walk stops early
+// at a deleted ancestor, one hidden from viewerID, or after MaxForkDepth steps.
+func (s *recipeService) RecipeAncestry(viewerID, recipeID string) ([]*models.Recipe, error) {
+	recipe, err := s.GetRecipe(viewerID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	ancestry := []*models.Recipe{}
+	seen := map[string]bool{recipe.ID: true}
+	for recipe.IsFork() && len(ancestry) < MaxForkDepth && !seen[recipe.ParentRecipeID] {
+		seen[recipe.ParentRecipeID] = true
+		parent, err := s.GetRecipe(viewerID, recipe.ParentRecipeID)
+		if errors.Is(err, ErrRecipeNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ancestry = append(ancestry, parent)
+		recipe = parent
+	}
+	return ancestry, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/images"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// AddRecipeImage stores a photo uploaded for a recipe owned by userID. The
+// upload is validated and re-encoded without metadata, and its thumbnails
+// are stored next to it under "recipes/<recipe>/<image>/". Only the blob keys
+// are recorded; URLs are worked out from them whenever images are loaded.
+// Uploads that are not a supported image wrap ErrInvalidImage, oversized ones
+// ErrImageTooLarge.
+func (s *recipeService) AddRecipeImage(userID, recipeID string, data []byte) (*models.RecipeImage, error) {
+	if s.blobs == nil {
+		return nil, ErrImagesUnavailable
+	}
+	recipe, err := s.getOwnedRecipe(userID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipe.Images) >= MaxRecipeImages {
+		return nil, fmt.Errorf("%w: recipe already has %d images", ErrInvalidImage, MaxRecipeImages)
+	}
+	processed, err := images.Process(data)
+	if err != nil {
+		if errors.Is(err, images.ErrTooLarge) {
+			return nil, fmt.Errorf("%w: %v", ErrImageTooLarge, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	image := &models.RecipeImage{
+		ID:          uuid.New().String(),
+		RecipeID:    recipeID,
+		UserID:      userID,
+		ContentType: processed.ContentType,
+		Width:       processed.Original.Width,
+		Height:      processed.Original.Height,
+	}
+	prefix := path.Join("recipes", recipeID, image.ID)
+	put := func(v images.Variant) error {
+		key := path.Join(prefix, v.Name+processed.Extension)
+		if err := s.blobs.Put(context.Background(), key, v.Data, processed.ContentType); err != nil {
+			return err
+		}
+		image.BlobKeys = append(image.BlobKeys, key)
+		return nil
+	}
+	if err = put(processed.Original); err == nil {
+		for _, thumb := range processed.Thumbnails {
+			if err = put(thumb); err != nil {
+				break
+			}
+			image.Thumbnails = append(image.Thumbnails, models.ImageVariant{
+				Name: thumb.Name, Width: thumb.Width, Height: thumb.Height,
+			})
+		}
+	}
+	if err == nil {
+		err = s.repo.AddImage(image)
+	}
+	if err != nil {
+		log.Printf("AddRecipeImage: failed to store image for recipe %s: %v", recipeID, err)
+		s.deleteBlobs(image.BlobKeys)
+		return nil, err
+	}
+	log.Printf("AddRecipeImage: user %s added image %s to recipe %s", userID, image.ID, recipeID)
+	image.SetURLs(s.blobs.URL)
+	return image, nil
+}
+
+// deleteBlobs removes stored objects, logging the ones that could not be
+// deleted rather than failing: a leftover file is harmless.
+func (s *recipeService) deleteBlobs(keys []string) {
+	if s.blobs == nil {
+		return
+	}
+	for _, key := range keys {
+		if err := s.blobs.Delete(context.Background(), key); err != nil {
+			log.Printf("deleteBlobs: failed to delete %s: %v", key, err)
+		}
+	}
+}
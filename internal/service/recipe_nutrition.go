@@ -0,0 +1,18 @@
+package service
+
+import (
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/nutrition"
+)
+
+// EstimateNutrition calculates the nutrition of a recipe from its structured
+// ingredients and servings, listing the ingredients it could not count. It
+// returns ErrRecipeNotFound if the recipe does not exist or is hidden from
+// viewerID.
+func (s *recipeService) EstimateNutrition(viewerID, recipeID string) (*models.NutritionEstimate, error) {
+	recipe, err := s.GetRecipe(viewerID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	return nutrition.Calculate(structuredIngredients(recipe), recipe.Servings), nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CreateReview stores userID's rating and review of a recipe they can read
+// and updates the recipe's rating summary. Users review each recipe once and
+// cannot review their own. It returns ErrRecipeNotFound if the recipe is
+// hidden from userID and ErrReviewExists if they reviewed it before.
+func (s *recipeService) CreateReview(userID, recipeID string, review *models.Review) (*models.Review, error) {
+	if err := validateReview(review); err != nil {
+		return nil, err
+	}
+	recipe, err := s.GetRecipe(userID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	if recipe.UserID == userID {
+		return nil, fmt.Errorf("%w: authors cannot review their own recipes", ErrInvalidReview)
+	}
+	if _, err := s.repo.GetUserReview(recipeID, userID); err == nil {
+		return nil, ErrReviewExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	review.ID = uuid.New().String()
+	review.RecipeID = recipeID
+	review.UserID = userID
+	if err := s.repo.CreateReview(review); err != nil {
+		if errors.Is(err, repository.ErrDuplicateReview) {
+			// A concurrent request stored the user's review first.
+			return nil, ErrReviewExists
+		}
+		log.Printf("CreateReview: failed to review recipe %s for user %s: %v", recipeID, userID, err)
+		return nil, err
+	}
+	log.Printf("CreateReview: user %s rated recipe %s %d stars", userID, recipeID, review.Rating)
+	return review, nil
+}
+
+// UpdateReview replaces the rating and text of a review written by userID
+// and moves the recipe's rating summary accordingly. It returns
+// ErrReviewNotFound if the recipe has no such review and ErrReviewForbidden
+// if someone else wrote it.
+func (s *recipeService) UpdateReview(userID, recipeID, reviewID string, review *models.Review) (*models.Review, error) {
+	if err := validateReview(review); err != nil {
+		return nil, err
+	}
+	existing, err := s.getOwnedReview(userID, recipeID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	existing.Rating = review.Rating
+	existing.Body = review.Body
+	if err := s.repo.UpdateReview(existing); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		log.Printf("UpdateReview: failed to update review %s: %v", reviewID, err)
+		return nil, err
+	}
+	log.Printf("UpdateReview: user %s updated review %s of recipe %s", userID, reviewID, recipeID)
+	return existing, nil
+}
+
+// DeleteReview removes a review written by userID and its rating from the
+// recipe's summary. It returns ErrReviewNotFound if the recipe has no such
+// review and ErrReviewForbidden if someone else wrote it.
+func (s *recipeService) DeleteReview(userID, recipeID, reviewID string) error {
+	if _, err := s.getOwnedReview(userID, recipeID, reviewID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteReview(reviewID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReviewNotFound
+		}
+		log.Printf("DeleteReview: failed to delete review %s: %v", reviewID, err)
+		return err
+	}
+	log.Printf("DeleteReview: user %s deleted review %s of recipe %s", userID, reviewID, recipeID)
+	return nil
+}
+
+// ListReviews returns one page of the reviews of a recipe viewerID can read,
+// newest first, together with its rating summary. Pagination defaults match
+// QueryRecipes.
+func (s *recipeService) ListReviews(viewerID, recipeID string, page, limit int) (*models.ReviewListResponse, error) {
+	recipe, err := s.GetRecipe(viewerID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	page, limit = pageBounds(page, limit)
+	reviews, total, err := s.repo.ListReviews(recipeID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository reviews error: %v", err)
+	}
+	return &models.ReviewListResponse{
+		Reviews: reviews,
+		Summary: recipe.Rating,
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+	}, nil
+}
+
+// getOwnedReview fetches a review of recipeID and verifies userID wrote it.
+func (s *recipeService) getOwnedReview(userID, recipeID, reviewID string) (*models.Review, error) {
+	review, err := s.repo.GetReview(reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, err
+	}
+	if review.RecipeID != recipeID {
+		return nil, ErrReviewNotFound
+	}
+	if review.UserID != userID {
+		log.Printf("getOwnedReview: user %s attempted to modify review %s written by %s", userID, reviewID, review.UserID)
+		return nil, ErrReviewForbidden
+	}
+	return review, nil
+}
+
+// validateReview checks the rating is between 1 and 5 stars and trims the
+// review text, which is optional.
+func validateReview(review *models.Review) error {
+	if review.Rating < 1 || review.Rating > 5 {
+		return fmt.Errorf("%w: rating must be between 1 and 5 stars", ErrInvalidReview)
+	}
+	review.Body = strings.TrimSpace(review.Body)
+	if len(review.Body) > MaxReviewLength {
+		return fmt.Errorf("%w: review exceeds %d characters", ErrInvalidReview, MaxReviewLength)
+	}
+	return nil
+}
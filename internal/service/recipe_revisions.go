@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/allergens"
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/nutrition"
+	"github.com/pageza/recipe-book-api-v2/internal/revisions"
+	"github.com/pageza/recipe-book-api-v2/internal/steps"
+	"gorm.io/gorm"
+)
+
+// ListRevisions returns the revisions of a recipe, newest first.
+// It returns ErrRecipeNotFound if the recipe does not exist or is hidden
+// from viewerID.
+func (s *recipeService) ListRevisions(viewerID, recipeID string) ([]*models.RecipeRevision, error) {
+	if _, err := s.GetRecipe(viewerID, recipeID); err != nil {
+		return nil, err
+	}
+	revs, err := s.repo.ListRevisions(recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("repository revision error: %v", err)
+	}
+	return revs, nil
+}
+
+// GetRevision retrieves a single revision of a recipe.
+// It returns ErrRecipeNotFound if the recipe is hidden from viewerID and
+// ErrRevisionNotFound if the recipe has no such revision.
+func (s *recipeService) GetRevision(viewerID, recipeID string, number int) (*models.RecipeRevision, error) {
+	if _, err := s.GetRecipe(viewerID, recipeID); err != nil {
+		return nil, err
+	}
+	rev, err := s.repo.GetRevision(recipeID, number)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: recipe %s has no revision %d", ErrRevisionNotFound, recipeID, number)
+		}
+		return nil, err
+	}
+	return rev, nil
+}
+
+// DiffRevisions compares revision from with revision to. A zero to selects
+// the latest revision and a zero from the one before to, so with neither set
+// the diff shows the most recent change.
+func (s *recipeService) DiffRevisions(viewerID, recipeID string, from, to int) (*models.RecipeDiff, error) {
+	if from < 0 || to < 0 {
+		return nil, fmt.Errorf("%w: revision numbers must be positive", ErrInvalidQuery)
+	}
+	if to == 0 {
+		revs, err := s.ListRevisions(viewerID, recipeID)
+		if err != nil {
+			return nil, err
+		}
+		if len(revs) == 0 {
+			return nil, fmt.Errorf("%w: recipe %s has no revisions", ErrRevisionNotFound, recipeID)
+		}
+		to = revs[0].Number
+	}
+	if from == 0 {
+		from = max(to-1, 1)
+	}
+
+	fromRev, err := s.GetRevision(viewerID, recipeID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRev, err := s.GetRevision(viewerID, recipeID, to)
+	if err != nil {
+		return nil, err
+	}
+	return revisions.Diff(fromRev, toRev), nil
+}
+
+// RevertRecipe restores the editable fields of a recipe owned by userID to
+// the content of an earlier revision. The history is never rewritten: the
+// revert is recorded as a new revision that points back at number.
+func (s *recipeService) RevertRecipe(userID, recipeID string, number int) (*models.Recipe, error) {
+	existing, err := s.getOwnedRecipe(userID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.GetRevision(userID, recipeID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	before := existing.Snapshot()
+	snap := target.Snapshot
+	existing.Title = snap.Title
+	existing.Ingredients = snap.Ingredients
+	existing.StructuredIngredients = ingredients.ParseAll(snap.Ingredients)
+	existing.Steps = snap.Steps
+	steps.Annotate(existing)
+	existing.Servings = snap.Servings
+	existing.NutritionalInfo = snap.NutritionalInfo
+	existing.AllergyDisclaimer = snap.AllergyDisclaimer
+	existing.Appliances = snap.Appliances
+	// The snapshot's disclaimer and nutrition were written for its
+	// ingredients, so only blank ones are regenerated.
+	allergens.Annotate(existing, nil)
+	nutrition.Fill(existing, models.NutritionalInfo{})
+	existing.Tags = models.ParseTags(snap.Tags)
+	if err := normalizeTags(existing); err != nil {
+		return nil, err
+	}
+
+	after := existing.Snapshot()
+	revision := newRevision(userID, models.RevisionRevert, after, revisions.ChangedFields(before, after))
+	revision.RevertedFrom = number
+	if err := s.repo.UpdateRecipe(existing, revision); err != nil {
+		log.Printf("RevertRecipe: failed to revert recipe %s: %v", recipeID, err)
+		return nil, err
+	}
+	log.Printf("RevertRecipe: user %s reverted recipe %s to revision %d", userID, recipeID, number)
+	return existing, nil
+}
+
+// newRevision builds an unsaved revision; the repository assigns its number.
+func newRevision(userID, action string, snapshot models.RecipeSnapshot, changed []string) *models.RecipeRevision {
+	return &models.RecipeRevision{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		Action:        action,
+		ChangedFields: changed,
+		Snapshot:      snapshot,
+	}
+}
@@ -2,51 +2,497 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/allergens"
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
 	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/nutrition"
 	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"github.com/pageza/recipe-book-api-v2/internal/revisions"
+	"github.com/pageza/recipe-book-api-v2/internal/steps"
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
+	"gorm.io/gorm"
+)
+
+// Limits applied when validating user-submitted recipes.
+const (
+	MaxRecipeTitleLength = 200
+	MaxRecipeItemLength  = 1000
+	MaxRecipeIngredients = 100
+	MaxRecipeSteps       = 100
+	MaxRecipeAppliances  = 20
+	MaxRecipeServings    = 100
+	MaxRecipeTags        = 20
+	MaxRecipeImages      = 20
+	MaxReviewLength      = 5000
+)
+
+// MaxForkDepth bounds how many ancestors RecipeAncestry walks through.
+const MaxForkDepth = 50
+
+// Default pagination values applied to recipe queries.
+const (
+	DefaultRecipePageLimit = 10
+	MaxRecipePageLimit     = 100
+)
+
+var (
+	// ErrRecipeNotFound is returned when a recipe does not exist.
+	ErrRecipeNotFound = errors.New("recipe not found")
+	// ErrRecipeForbidden is returned when a user tries to modify a recipe they do not own.
+	ErrRecipeForbidden = errors.New("recipe belongs to another user")
+	// ErrInvalidRecipe is returned (wrapped with details) when a recipe fails validation.
+	ErrInvalidRecipe = errors.New("invalid recipe")
+	// ErrInvalidServings is returned (wrapped with details) when a recipe cannot be
+	// scaled to the requested number of servings.
+	ErrInvalidServings = errors.New("invalid servings")
+	// ErrInvalidQuery is returned (wrapped with details) when query filters are inconsistent.
+	ErrInvalidQuery = errors.New("invalid recipe query")
+	// ErrRevisionNotFound is returned when a recipe has no revision with the requested number.
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrInvalidImage is returned (wrapped with details) when an upload is not an accepted image.
+	ErrInvalidImage = errors.New("invalid image")
+	// ErrImageTooLarge is returned (wrapped with details) when an upload exceeds the size limits.
+	ErrImageTooLarge = errors.New("image too large")
+	// ErrImagesUnavailable is returned when the service has no blob store for uploads.
+	ErrImagesUnavailable = errors.New("image storage is not configured")
+	// ErrReviewNotFound is returned when a recipe has no review with the requested ID.
+	ErrReviewNotFound = errors.New("review not found")
+	// ErrReviewForbidden is returned when a user tries to change another user's review.
+	ErrReviewForbidden = errors.New("review belongs to another user")
+	// ErrReviewExists is returned when a user reviews a recipe they already reviewed.
+	ErrReviewExists = errors.New("recipe already reviewed")
+	// ErrInvalidReview is returned (wrapped with details) when a review fails validation.
+	ErrInvalidReview = errors.New("invalid review")
 )
 
 // RecipeService defines the interface for recipe operations.
 type RecipeService interface {
-	// GetRecipe retrieves a recipe by its ID.
-	GetRecipe(recipeID string) (*models.Recipe, error)
-	// QueryRecipes processes query requests and returns matching recipes.
+	// GetRecipe retrieves a recipe by its ID if viewerID may read it.
+	GetRecipe(viewerID, recipeID string) (*models.Recipe, error)
+	// QueryRecipes processes query requests and returns matching recipes
+	// visible to req.ViewerID.
 	QueryRecipes(req *models.RecipeQueryRequest) (*models.RecipeQueryResponse, error)
+	// ScaleRecipe retrieves a recipe scaled to the given number of servings.
+	ScaleRecipe(viewerID, recipeID string, servings int) (*models.Recipe, error)
+	// CreateRecipe validates and stores a new recipe owned by userID.
+	CreateRecipe(userID string, recipe *models.Recipe) (*models.Recipe, error)
+	// CreateRecipes validates and stores several new recipes owned by userID
+	// together: if any of them is invalid or fails to store, none are stored.
+	CreateRecipes(userID string, recipes []*models.Recipe) ([]*models.Recipe, error)
+	// UpdateRecipe replaces the editable fields of a recipe owned by userID.
+	UpdateRecipe(userID, recipeID string, recipe *models.Recipe) (*models.Recipe, error)
+	// DeleteRecipe moves a recipe owned by userID to the trash.
+	DeleteRecipe(userID, recipeID string) error
+	// ListTrash returns the recipes in userID's trash.
+	ListTrash(userID string) ([]*models.Recipe, error)
+	// RestoreRecipe takes a recipe owned by userID out of the trash.
+	RestoreRecipe(userID, recipeID string) (*models.Recipe, error)
+	// PurgeTrash permanently removes recipes deleted more than retention ago.
+	PurgeTrash(retention time.Duration) (int, error)
+	// ListRevisions returns the change history of a recipe, newest first.
+	ListRevisions(viewerID, recipeID string) ([]*models.RecipeRevision, error)
+	// GetRevision retrieves one revision of a recipe.
+	GetRevision(viewerID, recipeID string, number int) (*models.RecipeRevision, error)
+	// DiffRevisions compares two revisions of a recipe.
+	DiffRevisions(viewerID, recipeID string, from, to int) (*models.RecipeDiff, error)
+	// RevertRecipe restores a recipe owned by userID to an earlier revision.
+	RevertRecipe(userID, recipeID string, number int) (*models.Recipe, error)
+	// ForkRecipe copies a recipe into a new recipe owned by userID.
+	ForkRecipe(userID, recipeID string) (*models.Recipe, error)
+	// ListForks returns the direct forks of a recipe listed for viewerID.
+	ListForks(viewerID, recipeID string) ([]*models.Recipe, error)
+	// RecipeAncestry returns the recipes a recipe descends from, parent first.
+	RecipeAncestry(viewerID, recipeID string) ([]*models.Recipe, error)
+	// ListTags returns tags with their recipe counts, optionally of one kind.
+	ListTags(kind string) ([]models.TagCount, error)
+	// EstimateNutrition calculates a recipe's nutrition from its ingredients.
+	EstimateNutrition(viewerID, recipeID string) (*models.NutritionEstimate, error)
+	// AddRecipeImage stores a photo uploaded for a recipe owned by userID.
+	AddRecipeImage(userID, recipeID string, data []byte) (*models.RecipeImage, error)
+	// FavoriteRecipe saves a recipe to userID's favorites.
+	FavoriteRecipe(userID, recipeID string) (*models.Recipe, error)
+	// UnfavoriteRecipe removes a recipe from userID's favorites.
+	UnfavoriteRecipe(userID, recipeID string) error
+	// ListFavorites returns one page of userID's favorite recipes.
+	ListFavorites(userID string, page, limit int) (*models.RecipeQueryResponse, error)
+	// CreateReview rates and reviews a recipe as userID.
+	CreateReview(userID, recipeID string, review *models.Review) (*models.Review, error)
+	// UpdateReview changes a review written by userID.
+	UpdateReview(userID, recipeID, reviewID string, review *models.Review) (*models.Review, error)
+	// DeleteReview removes a review written by userID.
+	DeleteReview(userID, recipeID, reviewID string) error
+	// ListReviews returns one page of a recipe's reviews with its rating summary.
+	ListReviews(viewerID, recipeID string, page, limit int) (*models.ReviewListResponse, error)
 }
 
 // recipeService implements RecipeService.
 type recipeService struct {
-	repo repository.RecipeRepository
+	repo  repository.RecipeRepository
+	blobs blobstore.BlobStore
+}
+
+// NewRecipeService creates a new RecipeService instance. Recipe images are
+// kept in blobs; with a nil store, uploads fail with ErrImagesUnavailable.
+func NewRecipeService(repo repository.RecipeRepository, blobs blobstore.BlobStore) RecipeService {
+	return &recipeService{repo: repo, blobs: blobs}
+}
+
+// GetRecipe retrieves a recipe by its ID via the repository. Private
+// recipes can only be read by their owner; viewerID is empty for anonymous
+// readers. It returns ErrRecipeNotFound if no recipe with the given ID is
+// visible to viewerID.
+func (s *recipeService) GetRecipe(viewerID, recipeID string) (*models.Recipe, error) {
+	recipe, err := s.repo.GetVisibleRecipe(recipeID, viewerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// ScaleRecipe returns a copy of the recipe adjusted to yield the requested
+// number of servings. Ingredient quantities are multiplied and their lines
+// re-rendered; NutritionalInfo stays per serving, while TotalNutritionalInfo
+// reports the totals for the scaled yield.
+func (s *recipeService) ScaleRecipe(viewerID, recipeID string, servings int) (*models.Recipe, error) {
+	if servings < 1 || servings > MaxRecipeServings {
+		return nil, fmt.Errorf("%w: servings must be between 1 and %d", ErrInvalidServings, MaxRecipeServings)
+	}
+	recipe, err := s.GetRecipe(viewerID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	if recipe.Servings < 1 {
+		return nil, fmt.Errorf("%w: recipe %s does not define its servings", ErrInvalidServings, recipeID)
+	}
+
+	factor := float64(servings) / float64(recipe.Servings)
+	scaled := mapIngredients(recipe, func(ing models.Ingredient) models.Ingredient {
+		return ingredients.Scale(ing, factor)
+	})
+	scaled.Servings = servings
+	totals := recipe.NutritionalInfo.Scaled(float64(servings))
+	scaled.TotalNutritionalInfo = &totals
+	return scaled, nil
+}
+
+// ConvertRecipeUnits returns a copy of recipe with its ingredient quantities
+// expressed in the given measurement system. Lines whose units cannot be
+// converted are kept as written, and units.Original returns recipe unchanged.
+func ConvertRecipeUnits(recipe *models.Recipe, system units.System) *models.Recipe {
+	if system == units.Original {
+		return recipe
+	}
+	return mapIngredients(recipe, func(ing models.Ingredient) models.Ingredient {
+		return ingredients.Convert(ing, system)
+	})
 }
 
-// NewRecipeService creates a new RecipeService instance.
-func NewRecipeService(repo repository.RecipeRepository) RecipeService {
-	return &recipeService{repo: repo}
+// structuredIngredients returns the recipe's parsed ingredients. Recipes
+// stored before ingredients were parsed are parsed on the fly.
+func structuredIngredients(recipe *models.Recipe) []models.Ingredient {
+	if len(recipe.StructuredIngredients) == 0 {
+		return ingredients.ParseAll(recipe.Ingredients)
+	}
+	return recipe.StructuredIngredients
 }
 
-// GetRecipe retrieves a recipe by its ID via the repository.
-func (s *recipeService) GetRecipe(recipeID string) (*models.Recipe, error) {
-	return s.repo.GetRecipeByID(recipeID)
+// mapIngredients returns a copy of recipe with fn applied to each structured
+// ingredient and the ingredient lines re-rendered from the results.
+func mapIngredients(recipe *models.Recipe, fn func(models.Ingredient) models.Ingredient) *models.Recipe {
+	structured := structuredIngredients(recipe)
+
+	mapped := *recipe
+	mapped.StructuredIngredients = make([]models.Ingredient, len(structured))
+	mapped.Ingredients = make(models.StringArray, len(structured))
+	for i, ing := range structured {
+		mapped.StructuredIngredients[i] = fn(ing)
+		mapped.Ingredients[i] = mapped.StructuredIngredients[i].Raw
+	}
+	return &mapped
 }
 
 // QueryRecipes processes the unified query request by delegating to the repository.
+// Missing or out-of-range pagination values are replaced with defaults, and
+// facet counts are added when requested.
 func (s *recipeService) QueryRecipes(req *models.RecipeQueryRequest) (*models.RecipeQueryResponse, error) {
-	recipes, total, err := s.repo.QueryRecipes(req.Query, req.UserID, req.Filter, req.Page, req.Limit)
+	if err := validateFilters(&req.Filters); err != nil {
+		return nil, err
+	}
+	switch req.Sort {
+	case "":
+		req.Sort = models.SortNewest
+		if req.Query != "" {
+			req.Sort = models.SortRelevance
+		}
+	case models.SortNewest, models.SortTitle, models.SortRelevance, models.SortRating:
+	default:
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidQuery, req.Sort)
+	}
+	switch req.TotalMode {
+	case "":
+		req.TotalMode = models.TotalExact
+	case models.TotalExact, models.TotalEstimate, models.TotalNone:
+	default:
+		return nil, fmt.Errorf("%w: unknown total mode %q", ErrInvalidQuery, req.TotalMode)
+	}
+	req.Page, req.Limit = pageBounds(req.Page, req.Limit)
+
+	page, err := s.repo.QueryRecipes(req)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+		}
 		return nil, fmt.Errorf("repository query error: %v", err)
 	}
-	return &models.RecipeQueryResponse{
-		Recipes: recipes,
-		Page:    req.Page,
-		Limit:   req.Limit,
-		Total:   total,
-	}, nil
+	resp := &models.RecipeQueryResponse{
+		Recipes:    page.Recipes,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Total:      page.Total,
+		TotalMode:  req.TotalMode,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+	if req.IncludeFacets {
+		if resp.Facets, err = s.repo.FacetRecipes(req); err != nil {
+			return nil, fmt.Errorf("repository facet error: %v", err)
+		}
+	}
+	return resp, nil
 }
 
-// // Dummy helper to generate an ID.
-// func generateID() string {
-// 	// TODO: Use a proper UUID generator in production code.
-// 	return "some-generated-id"
-// }
+// pageBounds replaces missing or out-of-range pagination values with the
+// recipe listing defaults.
+func pageBounds(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultRecipePageLimit
+	}
+	if limit > MaxRecipePageLimit {
+		limit = MaxRecipePageLimit
+	}
+	return page, limit
+}
+
+// CreateRecipe stamps the recipe with a fresh ID and the creating user,
+// validates it, parses its ingredient lines and steps, detects its allergens,
+// calculates its nutrition when none was given and persists it. Recipes
+// without a visibility are public.
+func (s *recipeService) CreateRecipe(userID string, recipe *models.Recipe) (*models.Recipe, error) {
+	if err := ValidateRecipe(recipe); err != nil {
+		return nil, err
+	}
+	revision := prepareNewRecipe(userID, recipe)
+	if err := s.repo.CreateRecipe(recipe, revision); err != nil {
+		log.Printf("CreateRecipe: failed to create recipe for user %s: %v", userID, err)
+		return nil, err
+	}
+	log.Printf("CreateRecipe: user %s created recipe %s", userID, recipe.ID)
+	return recipe, nil
+}
+
+// CreateRecipes validates every recipe before storing any, then prepares and
+// persists them like CreateRecipe in a single transaction. Validation errors
+// name the position of the offending recipe, starting at 1.
+func (s *recipeService) CreateRecipes(userID string, recipes []*models.Recipe) ([]*models.Recipe, error) {
+	for i, recipe := range recipes {
+		if err := ValidateRecipe(recipe); err != nil {
+			return nil, fmt.Errorf("recipe %d: %w", i+1, err)
+		}
+	}
+	revisions := make([]*models.RecipeRevision, len(recipes))
+	for i, recipe := range recipes {
+		revisions[i] = prepareNewRecipe(userID, recipe)
+	}
+	if err := s.repo.CreateRecipes(recipes, revisions); err != nil {
+		log.Printf("CreateRecipes: failed to create %d recipes for user %s: %v", len(recipes), userID, err)
+		return nil, err
+	}
+	log.Printf("CreateRecipes: user %s created %d recipes", userID, len(recipes))
+	return recipes, nil
+}
+
+// prepareNewRecipe stamps a validated recipe with a fresh ID and its owner,
+// derives its structured fields, allergens and nutrition, and returns its
+// first revision. Recipes without a visibility are public.
+func prepareNewRecipe(userID string, recipe *models.Recipe) *models.RecipeRevision {
+	if recipe.Visibility == "" {
+		recipe.Visibility = models.VisibilityPublic
+	}
+	recipe.ID = uuid.New().String()
+	recipe.UserID = userID
+	recipe.StructuredIngredients = ingredients.ParseAll(recipe.Ingredients)
+	steps.Annotate(recipe)
+	allergens.Annotate(recipe, nil)
+	nutrition.Fill(recipe, models.NutritionalInfo{})
+	return newRevision(userID, models.RevisionCreate, recipe.Snapshot(), nil)
+}
+
+// UpdateRecipe copies the editable fields from recipe onto the stored recipe
+// and records a revision listing the fields that changed. An empty
+// visibility keeps the current one. It returns ErrRecipeNotFound if the
+// recipe does not exist and ErrRecipeForbidden if it is owned by someone
+// other than userID.
+func (s *recipeService) UpdateRecipe(userID, recipeID string, recipe *models.Recipe) (*models.Recipe, error) {
+	existing, err := s.getOwnedRecipe(userID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateRecipe(recipe); err != nil {
+		return nil, err
+	}
+
+	before, previousAllergens := existing.Snapshot(), existing.Allergens
+	previousNutrition := nutrition.Calculate(structuredIngredients(existing), existing.Servings).PerServing
+	existing.Title = recipe.Title
+	existing.Ingredients = recipe.Ingredients
+	existing.StructuredIngredients = ingredients.ParseAll(recipe.Ingredients)
+	existing.Steps = recipe.Steps
+	steps.Annotate(existing)
+	existing.Servings = recipe.Servings
+	existing.NutritionalInfo = recipe.NutritionalInfo
+	existing.AllergyDisclaimer = recipe.AllergyDisclaimer
+	existing.Appliances = recipe.Appliances
+	existing.Tags = recipe.Tags
+	if recipe.Visibility != "" {
+		existing.Visibility = recipe.Visibility
+	}
+	allergens.Annotate(existing, previousAllergens)
+	nutrition.Fill(existing, previousNutrition)
+
+	// Saves that change nothing are not worth a revision.
+	var revision *models.RecipeRevision
+	after := existing.Snapshot()
+	if changed := revisions.ChangedFields(before, after); len(changed) > 0 {
+		revision = newRevision(userID, models.RevisionUpdate, after, changed)
+	}
+	if err := s.repo.UpdateRecipe(existing, revision); err != nil {
+		log.Printf("UpdateRecipe: failed to update recipe %s: %v", recipeID, err)
+		return nil, err
+	}
+	log.Printf("UpdateRecipe: user %s updated recipe %s", userID, recipeID)
+	return existing, nil
+}
+
+// DeleteRecipe moves the recipe to the trash if it is owned by userID. It
+// can be restored until PurgeTrash removes it for good.
+func (s *recipeService) DeleteRecipe(userID, recipeID string) error {
+	if _, err := s.getOwnedRecipe(userID, recipeID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteRecipe(recipeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRecipeNotFound
+		}
+		log.Printf("DeleteRecipe: failed to delete recipe %s: %v", recipeID, err)
+		return err
+	}
+	log.Printf("DeleteRecipe: user %s deleted recipe %s", userID, recipeID)
+	return nil
+}
+
+// getOwnedRecipe loads a recipe and checks that it belongs to userID.
+// Other users' private recipes are reported as not found.
+func (s *recipeService) getOwnedRecipe(userID, recipeID string) (*models.Recipe, error) {
+	recipe, err := s.GetRecipe(userID, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	if recipe.UserID != userID {
+		log.Printf("getOwnedRecipe: user %s attempted to modify recipe %s owned by %s", userID, recipeID, recipe.UserID)
+		return nil, ErrRecipeForbidden
+	}
+	return recipe, nil
+}
+
+// ValidateRecipe checks the user-editable fields of a recipe and trims its title.
+// Errors wrap ErrInvalidRecipe so callers can map them to a client error.
+func ValidateRecipe(recipe *models.Recipe) error {
+	title := strings.TrimSpace(recipe.Title)
+	switch {
+	case title == "":
+		return fmt.Errorf("%w: title is required", ErrInvalidRecipe)
+	case len(title) > MaxRecipeTitleLength:
+		return fmt.Errorf("%w: title exceeds %d characters", ErrInvalidRecipe, MaxRecipeTitleLength)
+	case len(recipe.Ingredients) == 0:
+		return fmt.Errorf("%w: at least one ingredient is required", ErrInvalidRecipe)
+	case len(recipe.Ingredients) > MaxRecipeIngredients:
+		return fmt.Errorf("%w: more than %d ingredients", ErrInvalidRecipe, MaxRecipeIngredients)
+	case len(recipe.Steps) == 0:
+		return fmt.Errorf("%w: at least one step is required", ErrInvalidRecipe)
+	case len(recipe.Steps) > MaxRecipeSteps:
+		return fmt.Errorf("%w: more than %d steps", ErrInvalidRecipe, MaxRecipeSteps)
+	case len(recipe.Appliances) > MaxRecipeAppliances:
+		return fmt.Errorf("%w: more than %d appliances", ErrInvalidRecipe, MaxRecipeAppliances)
+	case recipe.Servings < 0 || recipe.Servings > MaxRecipeServings:
+		return fmt.Errorf("%w: servings must be between 0 and %d", ErrInvalidRecipe, MaxRecipeServings)
+	case recipe.Visibility != "" && !models.ValidVisibility(recipe.Visibility):
+		return fmt.Errorf("%w: unknown visibility %q", ErrInvalidRecipe, recipe.Visibility)
+	}
+	recipe.Title = title
+
+	if err := validateItems("ingredient", recipe.Ingredients); err != nil {
+		return err
+	}
+	if err := validateItems("step", recipe.Steps); err != nil {
+		return err
+	}
+	if err := validateItems("appliance", recipe.Appliances); err != nil {
+		return err
+	}
+	if len(recipe.AllergyDisclaimer) > MaxRecipeItemLength {
+		return fmt.Errorf("%w: allergy disclaimer exceeds %d characters", ErrInvalidRecipe, MaxRecipeItemLength)
+	}
+	return normalizeTags(recipe)
+}
+
+// validateFilters rejects nutrition ranges whose minimum exceeds their maximum
+// and negative time ceilings.
+func validateFilters(f *models.RecipeFilters) error {
+	for _, max := range []*int{f.MaxPrepMinutes, f.MaxCookMinutes, f.MaxTotalMinutes} {
+		if max != nil && *max < 0 {
+			return fmt.Errorf("%w: time limits must not be negative", ErrInvalidQuery)
+		}
+	}
+	ranges := []struct {
+		name     string
+		min, max *float64
+	}{
+		{"calories", f.MinCalories, f.MaxCalories},
+		{"protein", f.MinProtein, f.MaxProtein},
+		{"carbohydrates", f.MinCarbohydrates, f.MaxCarbohydrates},
+		{"fat", f.MinFat, f.MaxFat},
+	}
+	for _, r := range ranges {
+		if r.min != nil && r.max != nil && *r.min > *r.max {
+			return fmt.Errorf("%w: minimum %s exceeds maximum", ErrInvalidQuery, r.name)
+		}
+	}
+	return nil
+}
+
+// validateItems rejects blank or oversized entries in a recipe list field.
+func validateItems(kind string, items []string) error {
+	for i, item := range items {
+		if strings.TrimSpace(item) == "" {
+			return fmt.Errorf("%w: %s %d is empty", ErrInvalidRecipe, kind, i+1)
+		}
+		if len(item) > MaxRecipeItemLength {
+			return fmt.Errorf("%w: %s %d exceeds %d characters", ErrInvalidRecipe, kind, i+1, MaxRecipeItemLength)
+		}
+	}
+	return nil
+}
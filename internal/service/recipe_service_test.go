@@ -0,0 +1,911 @@
+package service_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/pageza/recipe-book-api-v2/internal/blobstore"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+)
+
+// fakeRecipeRepository implements repository.RecipeRepository in memory.
+type fakeRecipeRepository struct {
+	recipes   map[string]*models.Recipe
+	trash     map[string]*models.Recipe
+	revisions map[string][]*models.RecipeRevision // oldest first
+	links     map[string]*models.ShareLink
+	favorites []*models.Favorite // oldest first
+	reviews   []*models.Review   // oldest first
+}
+
+func newFakeRecipeRepository() *fakeRecipeRepository {
+	return &fakeRecipeRepository{
+		recipes:   make(map[string]*models.Recipe),
+		trash:     make(map[string]*models.Recipe),
+		revisions: make(map[string][]*models.RecipeRevision),
+		links:     make(map[string]*models.ShareLink),
+	}
+}
+
+func (f *fakeRecipeRepository) CreateRecipe(recipe *models.Recipe, revision *models.RecipeRevision) error {
+	f.recipes[recipe.ID] = recipe
+	f.addRevision(recipe.ID, revision)
+	return nil
+}
+
+func (f *fakeRecipeRepository) CreateRecipes(recipes []*models.Recipe, revisions []*models.RecipeRevision) error {
+	for i, recipe := range recipes {
+		_ = f.CreateRecipe(recipe, revisions[i])
+	}
+	return nil
+}
+
+func (f *fakeRecipeRepository) UpdateRecipe(recipe *models.Recipe, revision *models.RecipeRevision) error {
+	f.recipes[recipe.ID] = recipe
+	f.addRevision(recipe.ID, revision)
+	return nil
+}
+
+func (f *fakeRecipeRepository) addRevision(recipeID string, revision *models.RecipeRevision) {
+	if revision != nil {
+		revision.RecipeID = recipeID
+		revision.Number = len(f.revisions[recipeID]) + 1
+		f.revisions[recipeID] = append(f.revisions[recipeID], revision)
+	}
+}
+
+func (f *fakeRecipeRepository) ListRevisions(recipeID string) ([]*models.RecipeRevision, error) {
+	revs := f.revisions[recipeID]
+	newest := make([]*models.RecipeRevision, len(revs))
+	for i, rev := range revs {
+		newest[len(revs)-1-i] = rev
+	}
+	return newest, nil
+}
+
+func (f *fakeRecipeRepository) GetRevision(recipeID string, number int) (*models.RecipeRevision, error) {
+	revs := f.revisions[recipeID]
+	if number < 1 || number > len(revs) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return revs[number-1], nil
+}
+
+func (f *fakeRecipeRepository) DeleteRecipe(recipeID string) error {
+	recipe, ok := f.recipes[recipeID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	recipe.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	f.trash[recipeID] = recipe
+	delete(f.recipes, recipeID)
+	return nil
+}
+
+func (f *fakeRecipeRepository) GetDeletedRecipe(recipeID string) (*models.Recipe, error) {
+	if recipe, ok := f.trash[recipeID]; ok {
+		copied := *recipe
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRecipeRepository) ListDeletedRecipes(userID string) ([]*models.Recipe, error) {
+	var result []*models.Recipe
+	for _, r := range f.trash {
+		if r.UserID == userID {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRecipeRepository) RestoreRecipe(recipeID string) error {
+	recipe, ok := f.trash[recipeID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	recipe.DeletedAt = gorm.DeletedAt{}
+	f.recipes[recipeID] = recipe
+	delete(f.trash, recipeID)
+	return nil
+}
+
+func (f *fakeRecipeRepository) PurgeDeletedRecipes(before time.Time) (int, []string, error) {
+	purged := 0
+	var blobKeys []string
+	for id, r := range f.trash {
+		if r.DeletedAt.Time.Before(before) {
+			for _, image := range r.Images {
+				blobKeys = append(blobKeys, image.BlobKeys...)
+			}
+			delete(f.trash, id)
+			delete(f.revisions, id)
+			purged++
+		}
+	}
+	return purged, blobKeys, nil
+}
+
+func (f *fakeRecipeRepository) AddImage(image *models.RecipeImage) error {
+	recipe := f.recipes[image.RecipeID]
+	recipe.Images = append(recipe.Images, *image)
+	return nil
+}
+
+func (f *fakeRecipeRepository) CreateShareLink(link *models.ShareLink) error {
+	copied := *link
+	f.links[link.ID] = &copied
+	return nil
+}
+
+func (f *fakeRecipeRepository) GetShareLink(linkID string) (*models.ShareLink, error) {
+	if link, ok := f.links[linkID]; ok {
+		copied := *link
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRecipeRepository) ListShareLinks(recipeID string) ([]*models.ShareLink, error) {
+	var links []*models.ShareLink
+	for _, link := range f.links {
+		if link.RecipeID == recipeID {
+			copied := *link
+			links = append(links, &copied)
+		}
+	}
+	return links, nil
+}
+
+func (f *fakeRecipeRepository) RevokeShareLink(linkID string, at time.Time) error {
+	if link, ok := f.links[linkID]; ok && link.RevokedAt == nil {
+		link.RevokedAt = &at
+	}
+	return nil
+}
+
+func (f *fakeRecipeRepository) AddFavorite(userID, recipeID string) error {
+	if f.favoriteIndex(userID, recipeID) < 0 {
+		f.favorites = append(f.favorites, &models.Favorite{UserID: userID, RecipeID: recipeID})
+	}
+	return nil
+}
+
+func (f *fakeRecipeRepository) RemoveFavorite(userID, recipeID string) error {
+	i := f.favoriteIndex(userID, recipeID)
+	if i < 0 {
+		return gorm.ErrRecordNotFound
+	}
+	f.favorites = append(f.favorites[:i], f.favorites[i+1:]...)
+	return nil
+}
+
+func (f *fakeRecipeRepository) ListFavorites(userID string, page, limit int) ([]*models.Recipe, int, error) {
+	var recipes []*models.Recipe
+	for i := len(f.favorites) - 1; i >= 0; i-- {
+		if f.favorites[i].UserID != userID {
+			continue
+		}
+		if recipe, err := f.GetVisibleRecipe(f.favorites[i].RecipeID, userID); err == nil {
+			recipes = append(recipes, recipe)
+		}
+	}
+	total := len(recipes)
+	start := min((page-1)*limit, total)
+	return recipes[start:min(start+limit, total)], total, nil
+}
+
+func (f *fakeRecipeRepository) CreateReview(review *models.Review) error {
+	if _, err := f.GetUserReview(review.RecipeID, review.UserID); err == nil {
+		return repository.ErrDuplicateReview
+	}
+	copied := *review
+	f.reviews = append(f.reviews, &copied)
+	f.rate(review.RecipeID, review.Rating, 1)
+	return nil
+}
+
+func (f *fakeRecipeRepository) UpdateReview(review *models.Review) error {
+	for _, stored := range f.reviews {
+		if stored.ID == review.ID {
+			f.rate(stored.RecipeID, stored.Rating, -1)
+			f.rate(stored.RecipeID, review.Rating, 1)
+			stored.Rating, stored.Body = review.Rating, review.Body
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (f *fakeRecipeRepository) DeleteReview(reviewID string) error {
+	for i, stored := range f.reviews {
+		if stored.ID == reviewID {
+			f.rate(stored.RecipeID, stored.Rating, -1)
+			f.reviews = append(f.reviews[:i], f.reviews[i+1:]...)
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (f *fakeRecipeRepository) GetReview(reviewID string) (*models.Review, error) {
+	for _, stored := range f.reviews {
+		if stored.ID == reviewID {
+			copied := *stored
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRecipeRepository) GetUserReview(recipeID, userID string) (*models.Review, error) {
+	for _, stored := range f.reviews {
+		if stored.RecipeID == recipeID && stored.UserID == userID {
+			copied := *stored
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRecipeRepository) ListReviews(recipeID string, page, limit int) ([]*models.Review, int, error) {
+	var reviews []*models.Review
+	for i := len(f.reviews) - 1; i >= 0; i-- {
+		if f.reviews[i].RecipeID == recipeID {
+			reviews = append(reviews, f.reviews[i])
+		}
+	}
+	total := len(reviews)
+	start := min((page-1)*limit, total)
+	return reviews[start:min(start+limit, total)], total, nil
+}
+
+// rate mirrors the repository's incremental rating summary.
+func (f *fakeRecipeRepository) rate(recipeID string, stars, delta int) {
+	summary := &f.recipes[recipeID].Rating
+	buckets := []*int{nil, &summary.Histogram.One, &summary.Histogram.Two, &summary.Histogram.Three, &summary.Histogram.Four, &summary.Histogram.Five}
+	*buckets[stars] += delta
+	summary.Count += delta
+	summary.Total += stars * delta
+	summary.Average = 0
+	if summary.Count > 0 {
+		summary.Average = float64(summary.Total) / float64(summary.Count)
+	}
+}
+
+func (f *fakeRecipeRepository) favoriteIndex(userID, recipeID string) int {
+	for i, fav := range f.favorites {
+		if fav.UserID == userID && fav.RecipeID == recipeID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeRecipeRepository) GetRecipeByID(recipeID string) (*models.Recipe, error) {
+	if recipe, ok := f.recipes[recipeID]; ok {
+		copied := *recipe
+		for _, fav := range f.favorites {
+			if fav.RecipeID == recipeID {
+				copied.FavoriteCount++
+			}
+		}
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeRecipeRepository) GetVisibleRecipe(recipeID, viewerID string) (*models.Recipe, error) {
+	recipe, err := f.GetRecipeByID(recipeID)
+	if err != nil || recipe.Visibility != models.VisibilityPrivate || recipe.UserID == viewerID {
+		if recipe != nil {
+			recipe.IsFavorited = f.favoriteIndex(viewerID, recipeID) >= 0
+		}
+		return recipe, err
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// listed mirrors the repository's listing rule: public recipes plus the
+// viewer's own. Recipes stored without a visibility take the public default.
+func listed(r *models.Recipe, viewerID string) bool {
+	return r.Visibility == models.VisibilityPublic || r.Visibility == "" || (viewerID != "" && r.UserID == viewerID)
+}
+
+func (f *fakeRecipeRepository) QueryRecipes(req *models.RecipeQueryRequest) (*repository.RecipePage, error) {
+	var result []*models.Recipe
+	for _, r := range f.recipes {
+		if !listed(r, req.ViewerID) {
+			continue
+		}
+		if req.UserID == "" || r.UserID == req.UserID {
+			result = append(result, r)
+		}
+	}
+	return &repository.RecipePage{Recipes: result, Total: len(result)}, nil
+}
+
+func (f *fakeRecipeRepository) ListForks(recipeID, viewerID string) ([]*models.Recipe, error) {
+	var forks []*models.Recipe
+	for _, r := range f.recipes {
+		if r.ParentRecipeID == recipeID && listed(r, viewerID) {
+			forks = append(forks, r)
+		}
+	}
+	return forks, nil
+}
+
+func (f *fakeRecipeRepository) ListTags(kind string) ([]models.TagCount, error) {
+	var counts []models.TagCount
+	index := make(map[string]int)
+	for _, r := range f.recipes {
+		for _, tag := range r.Tags {
+			if kind != "" && tag.Kind != kind {
+				continue
+			}
+			if i, ok := index[tag.ID]; ok {
+				counts[i].RecipeCount++
+				continue
+			}
+			index[tag.ID] = len(counts)
+			counts = append(counts, models.TagCount{Tag: tag, RecipeCount: 1})
+		}
+	}
+	return counts, nil
+}
+
+func (f *fakeRecipeRepository) FacetRecipes(req *models.RecipeQueryRequest) (*models.RecipeFacets, error) {
+	page, _ := f.QueryRecipes(req)
+	facets := &models.RecipeFacets{Appliances: make(map[string]int)}
+	for _, r := range page.Recipes {
+		for _, a := range r.Appliances {
+			facets.Appliances[a]++
+		}
+	}
+	return facets, nil
+}
+
+func newTestRecipe() *models.Recipe {
+	return &models.Recipe{
+		Title:       "Tomato Soup",
+		Ingredients: []string{"4 tomatoes", "1 onion"},
+		Steps:       []string{"Chop", "Simmer"},
+	}
+}
+
+func TestRecipeService_CreateRecipe(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+
+	input := newTestRecipe()
+	input.ID = "client-supplied"
+	input.UserID = "someone-else"
+	created, err := svc.CreateRecipe("user-1", input)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "client-supplied", created.ID, "ID must be generated server-side")
+	assert.Equal(t, "user-1", created.UserID, "owner must come from the authenticated user")
+
+	invalid := newTestRecipe()
+	invalid.Steps = nil
+	_, err = svc.CreateRecipe("user-1", invalid)
+	assert.ErrorIs(t, err, service.ErrInvalidRecipe)
+}
+
+func TestRecipeService_CreateRecipes(t *testing.T) {
+	repo := newFakeRecipeRepository()
+	svc := service.NewRecipeService(repo, nil)
+
+	invalid := newTestRecipe()
+	invalid.Steps = nil
+	_, err := svc.CreateRecipes("user-1", []*models.Recipe{newTestRecipe(), invalid})
+	assert.ErrorIs(t, err, service.ErrInvalidRecipe)
+	assert.ErrorContains(t, err, "recipe 2")
+	assert.Empty(t, repo.recipes, "nothing is stored when any recipe is invalid")
+
+	created, err := svc.CreateRecipes("user-1", []*models.Recipe{newTestRecipe(), newTestRecipe()})
+	assert.NoError(t, err)
+	if assert.Len(t, created, 2) {
+		assert.NotEqual(t, created[0].ID, created[1].ID)
+		assert.Equal(t, "user-1", created[1].UserID)
+		assert.Len(t, repo.revisions[created[1].ID], 1)
+	}
+}
+
+func TestRecipeService_UpdateRecipe_Ownership(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+	created, err := svc.CreateRecipe("user-1", newTestRecipe())
+	assert.NoError(t, err)
+
+	update := newTestRecipe()
+	update.Title = "Roasted Tomato Soup"
+
+	_, err = svc.UpdateRecipe("user-2", created.ID, update)
+	assert.ErrorIs(t, err, service.ErrRecipeForbidden)
+
+	_, err = svc.UpdateRecipe("user-1", "missing", update)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+
+	updated, err := svc.UpdateRecipe("user-1", created.ID, update)
+	assert.NoError(t, err)
+	assert.Equal(t, "Roasted Tomato Soup", updated.Title)
+	assert.Equal(t, "user-1", updated.UserID)
+}
+
+func TestRecipeService_DeleteRecipe_Ownership(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+	created, err := svc.CreateRecipe("user-1", newTestRecipe())
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, svc.DeleteRecipe("user-2", created.ID), service.ErrRecipeForbidden)
+	assert.NoError(t, svc.DeleteRecipe("user-1", created.ID))
+	assert.ErrorIs(t, svc.DeleteRecipe("user-1", created.ID), service.ErrRecipeNotFound)
+}
+
+func TestRecipeService_TrashRestoreAndPurge(t *testing.T) {
+	repo := newFakeRecipeRepository()
+	svc := service.NewRecipeService(repo, nil)
+	created, err := svc.CreateRecipe("user-1", newTestRecipe())
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DeleteRecipe("user-1", created.ID))
+
+	_, err = svc.GetRecipe("", created.ID)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+	trash, err := svc.ListTrash("user-1")
+	assert.NoError(t, err)
+	assert.Len(t, trash, 1)
+	trash, err = svc.ListTrash("user-2")
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+
+	_, err = svc.RestoreRecipe("user-2", created.ID)
+	assert.ErrorIs(t, err, service.ErrRecipeForbidden)
+	restored, err := svc.RestoreRecipe("user-1", created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Tomato Soup", restored.Title)
+	_, err = svc.RestoreRecipe("user-1", created.ID)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound, "no longer in the trash")
+
+	// Recipes are purged once they have been in the trash for the retention period.
+	assert.NoError(t, svc.DeleteRecipe("user-1", created.ID))
+	purged, err := svc.PurgeTrash(time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, purged)
+	repo.trash[created.ID].DeletedAt.Time = time.Now().Add(-2 * time.Hour)
+	purged, err = svc.PurgeTrash(time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	_, err = svc.RestoreRecipe("user-1", created.ID)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+}
+
+func TestRecipeService_ImagesAreStoredAndPurged(t *testing.T) {
+	repo := newFakeRecipeRepository()
+	mediaDir := t.TempDir()
+	store, err := blobstore.NewLocalStore(mediaDir, "/media")
+	require.NoError(t, err)
+	svc := service.NewRecipeService(repo, store)
+	created, err := svc.CreateRecipe("user-1", newTestRecipe())
+	require.NoError(t, err)
+
+	var photo bytes.Buffer
+	require.NoError(t, png.Encode(&photo, image.NewRGBA(image.Rect(0, 0, 64, 48))))
+	_, err = svc.AddRecipeImage("user-2", created.ID, photo.Bytes())
+	assert.ErrorIs(t, err, service.ErrRecipeForbidden)
+	_, err = svc.AddRecipeImage("user-1", created.ID, []byte("plain text"))
+	assert.ErrorIs(t, err, service.ErrInvalidImage)
+	_, err = service.NewRecipeService(repo, nil).AddRecipeImage("user-1", created.ID, photo.Bytes())
+	assert.ErrorIs(t, err, service.ErrImagesUnavailable)
+
+	stored, err := svc.AddRecipeImage("user-1", created.ID, photo.Bytes())
+	require.NoError(t, err)
+	assert.Len(t, stored.BlobKeys, 3, "original and two thumbnails")
+	for _, key := range stored.BlobKeys {
+		assert.FileExists(t, filepath.Join(mediaDir, filepath.FromSlash(key)))
+	}
+
+	// Purging the recipe from the trash deletes its files.
+	require.NoError(t, svc.DeleteRecipe("user-1", created.ID))
+	repo.trash[created.ID].DeletedAt.Time = time.Now().Add(-2 * time.Hour)
+	_, err = svc.PurgeTrash(time.Hour)
+	require.NoError(t, err)
+	for _, key := range stored.BlobKeys {
+		assert.NoFileExists(t, filepath.Join(mediaDir, filepath.FromSlash(key)))
+	}
+}
+
+func TestRecipeService_Visibility(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+
+	public, err := svc.CreateRecipe("alice", newTestRecipe())
+	require.NoError(t, err)
+	assert.Equal(t, models.VisibilityPublic, public.Visibility, "recipes are public by default")
+
+	hidden := newTestRecipe()
+	hidden.Visibility = models.VisibilityPrivate
+	private, err := svc.CreateRecipe("alice", hidden)
+	require.NoError(t, err)
+
+	_, err = svc.GetRecipe("alice", private.ID)
+	assert.NoError(t, err, "owners read their private recipes")
+	for _, viewer := range []string{"bob", ""} {
+		_, err = svc.GetRecipe(viewer, private.ID)
+		assert.ErrorIs(t, err, service.ErrRecipeNotFound, viewer)
+		_, err = svc.ListRevisions(viewer, private.ID)
+		assert.ErrorIs(t, err, service.ErrRecipeNotFound, viewer)
+	}
+	_, err = svc.ForkRecipe("bob", private.ID)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+	_, err = svc.UpdateRecipe("bob", private.ID, newTestRecipe())
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound, "private recipes are not revealed to other users")
+
+	resp, err := svc.QueryRecipes(&models.RecipeQueryRequest{ViewerID: "bob"})
+	require.NoError(t, err)
+	assert.Len(t, resp.Recipes, 1)
+
+	// An update without a visibility keeps the current one.
+	updated, err := svc.UpdateRecipe("alice", private.ID, newTestRecipe())
+	require.NoError(t, err)
+	assert.Equal(t, models.VisibilityPrivate, updated.Visibility)
+
+	invalid := newTestRecipe()
+	invalid.Visibility = "friends"
+	_, err = svc.CreateRecipe("alice", invalid)
+	assert.ErrorIs(t, err, service.ErrInvalidRecipe)
+}
+
+func TestRecipeService_Favorites(t *testing.T) {
+	repo := newFakeRecipeRepository()
+	svc := service.NewRecipeService(repo, nil)
+
+	soup, err := svc.CreateRecipe("alice", newTestRecipe())
+	require.NoError(t, err)
+	stew, err := svc.CreateRecipe("alice", newTestRecipe())
+	require.NoError(t, err)
+	hidden := newTestRecipe()
+	hidden.Visibility = models.VisibilityPrivate
+	private, err := svc.CreateRecipe("alice", hidden)
+	require.NoError(t, err)
+
+	favorited, err := svc.FavoriteRecipe("bob", soup.ID)
+	require.NoError(t, err)
+	assert.True(t, favorited.IsFavorited)
+	assert.Equal(t, 1, favorited.FavoriteCount)
+	favorited, err = svc.FavoriteRecipe("bob", soup.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, favorited.FavoriteCount, "favoriting twice counts once")
+	_, err = svc.FavoriteRecipe("bob", private.ID)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+	_, err = svc.FavoriteRecipe("bob", stew.ID)
+	require.NoError(t, err)
+
+	resp, err := svc.ListFavorites("bob", 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Page)
+	assert.Equal(t, 2, resp.Total)
+	require.Len(t, resp.Recipes, 1)
+	assert.Equal(t, stew.ID, resp.Recipes[0].ID, "most recent favorite first")
+
+	require.NoError(t, svc.UnfavoriteRecipe("bob", stew.ID))
+	require.NoError(t, svc.UnfavoriteRecipe("bob", stew.ID), "unfavoriting is idempotent")
+	resp, err = svc.ListFavorites("bob", 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, service.DefaultRecipePageLimit, resp.Limit)
+	require.Len(t, resp.Recipes, 1)
+	assert.Equal(t, soup.ID, resp.Recipes[0].ID)
+}
+
+// racingReviewRepository misses existing reviews on lookup, as when another
+// request stores the user's review between the check and the insert.
+type racingReviewRepository struct {
+	*fakeRecipeRepository
+}
+
+func (racingReviewRepository) GetUserReview(recipeID, userID string) (*models.Review, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestRecipeService_ConcurrentReviewIsReportedAsExisting(t *testing.T) {
+	repo := racingReviewRepository{newFakeRecipeRepository()}
+	svc := service.NewRecipeService(repo, nil)
+	recipe, err := svc.CreateRecipe("alice", newTestRecipe())
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateReview(&models.Review{ID: "first", RecipeID: recipe.ID, UserID: "bob", Rating: 4}))
+
+	_, err = svc.CreateReview("bob", recipe.ID, &models.Review{Rating: 2})
+	assert.ErrorIs(t, err, service.ErrReviewExists)
+}
+
+func TestRecipeService_Reviews(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+	recipe, err := svc.CreateRecipe("alice", newTestRecipe())
+	require.NoError(t, err)
+
+	for _, rating := range []int{0, 6} {
+		_, err = svc.CreateReview("bob", recipe.ID, &models.Review{Rating: rating})
+		assert.ErrorIs(t, err, service.ErrInvalidReview, rating)
+	}
+	_, err = svc.CreateReview("alice", recipe.ID, &models.Review{Rating: 5})
+	assert.ErrorIs(t, err, service.ErrInvalidReview, "authors cannot rate their own recipes")
+	_, err = svc.CreateReview("bob", "missing", &models.Review{Rating: 5})
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+
+	review, err := svc.CreateReview("bob", recipe.ID, &models.Review{Rating: 4, Body: "  Lovely.  "})
+	require.NoError(t, err)
+	assert.Equal(t, "Lovely.", review.Body)
+	_, err = svc.CreateReview("bob", recipe.ID, &models.Review{Rating: 2})
+	assert.ErrorIs(t, err, service.ErrReviewExists)
+	_, err = svc.CreateReview("carol", recipe.ID, &models.Review{Rating: 1})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateReview("carol", recipe.ID, review.ID, &models.Review{Rating: 1})
+	assert.ErrorIs(t, err, service.ErrReviewForbidden)
+	_, err = svc.UpdateReview("bob", "other-recipe", review.ID, &models.Review{Rating: 1})
+	assert.ErrorIs(t, err, service.ErrReviewNotFound)
+	updated, err := svc.UpdateReview("bob", recipe.ID, review.ID, &models.Review{Rating: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 5, updated.Rating)
+	assert.Empty(t, updated.Body)
+
+	resp, err := svc.ListReviews("dave", recipe.ID, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, service.DefaultRecipePageLimit, resp.Limit)
+	assert.Equal(t, 2, resp.Summary.Count)
+	assert.Equal(t, 3.0, resp.Summary.Average)
+	assert.Equal(t, models.RatingHistogram{One: 1, Five: 1}, resp.Summary.Histogram)
+
+	assert.ErrorIs(t, svc.DeleteReview("carol", recipe.ID, review.ID), service.ErrReviewForbidden)
+	require.NoError(t, svc.DeleteReview("bob", recipe.ID, review.ID))
+	assert.ErrorIs(t, svc.DeleteReview("bob", recipe.ID, review.ID), service.ErrReviewNotFound)
+	rated, err := svc.GetRecipe("dave", recipe.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.RatingSummary{Average: 1, Count: 1, Total: 1, Histogram: models.RatingHistogram{One: 1}}, rated.Rating)
+}
+
+func TestRecipeService_QueryRecipes_DefaultsPagination(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+
+	resp, err := svc.QueryRecipes(&models.RecipeQueryRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Page)
+	assert.Equal(t, service.DefaultRecipePageLimit, resp.Limit)
+
+	resp, err = svc.QueryRecipes(&models.RecipeQueryRequest{Page: 3, Limit: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, resp.Page)
+	assert.Equal(t, service.MaxRecipePageLimit, resp.Limit)
+}
+
+func TestRecipeService_QueryRecipes_SortAndTotalMode(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+
+	req := &models.RecipeQueryRequest{}
+	resp, err := svc.QueryRecipes(req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SortNewest, req.Sort)
+	assert.Equal(t, models.TotalExact, resp.TotalMode)
+
+	req = &models.RecipeQueryRequest{Query: "soup", TotalMode: models.TotalNone}
+	resp, err = svc.QueryRecipes(req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SortRelevance, req.Sort, "text queries default to relevance")
+	assert.Equal(t, models.TotalNone, resp.TotalMode)
+
+	_, err = svc.QueryRecipes(&models.RecipeQueryRequest{Sort: "oldest"})
+	assert.ErrorIs(t, err, service.ErrInvalidQuery)
+	_, err = svc.QueryRecipes(&models.RecipeQueryRequest{TotalMode: "approximately"})
+	assert.ErrorIs(t, err, service.ErrInvalidQuery)
+	negative := -5
+	_, err = svc.QueryRecipes(&models.RecipeQueryRequest{Filters: models.RecipeFilters{MaxTotalMinutes: &negative}})
+	assert.ErrorIs(t, err, service.ErrInvalidQuery)
+}
+
+func TestRecipeService_ScaleRecipe(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+
+	input := newTestRecipe()
+	input.Ingredients = []string{"4 tomatoes", "1 cup stock", "salt, to taste"}
+	input.Servings = 4
+	input.NutritionalInfo = models.NutritionalInfo{Calories: 150, Protein: 4}
+	created, err := svc.CreateRecipe("user-1", input)
+	assert.NoError(t, err)
+
+	scaled, err := svc.ScaleRecipe("", created.ID, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, scaled.Servings)
+	assert.Equal(t, []string{"6 tomatoes", "1 1/2 cups stock", "salt, to taste"}, []string(scaled.Ingredients))
+	assert.Equal(t, 1.5, scaled.StructuredIngredients[1].Quantity)
+	// Per-serving values are unchanged; totals cover all six servings.
+	assert.Equal(t, 150.0, scaled.NutritionalInfo.Calories)
+	if assert.NotNil(t, scaled.TotalNutritionalInfo) {
+		assert.Equal(t, 900.0, scaled.TotalNutritionalInfo.Calories)
+		assert.Equal(t, 24.0, scaled.TotalNutritionalInfo.Protein)
+	}
+
+	// The stored recipe is not modified.
+	stored, err := svc.GetRecipe("", created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, stored.Servings)
+	assert.Equal(t, "4 tomatoes", stored.Ingredients[0])
+
+	_, err = svc.ScaleRecipe("", created.ID, 0)
+	assert.ErrorIs(t, err, service.ErrInvalidServings)
+	_, err = svc.ScaleRecipe("", created.ID, service.MaxRecipeServings+1)
+	assert.ErrorIs(t, err, service.ErrInvalidServings)
+	_, err = svc.ScaleRecipe("", "missing", 2)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+
+	unknown, err := svc.CreateRecipe("user-1", newTestRecipe())
+	assert.NoError(t, err)
+	_, err = svc.ScaleRecipe("", unknown.ID, 2)
+	assert.ErrorIs(t, err, service.ErrInvalidServings, "recipes without servings cannot be scaled")
+}
+
+func TestRecipeService_RevisionsDiffAndRevert(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+	created, err := svc.CreateRecipe("owner", newTestRecipe())
+	assert.NoError(t, err)
+
+	edit := newTestRecipe()
+	edit.Title = "Roasted Tomato Soup"
+	edit.Ingredients = []string{"4 tomatoes", "1 onion", "2 cloves garlic"}
+	_, err = svc.UpdateRecipe("owner", created.ID, edit)
+	assert.NoError(t, err)
+	// An identical save records nothing.
+	_, err = svc.UpdateRecipe("owner", created.ID, edit)
+	assert.NoError(t, err)
+
+	revs, err := svc.ListRevisions("", created.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, revs, 2) {
+		assert.Equal(t, models.RevisionUpdate, revs[0].Action)
+		// The calculated nutrition follows the new ingredient.
+		assert.Equal(t, models.StringArray{"title", "ingredients", "nutritional_info"}, revs[0].ChangedFields)
+		assert.Equal(t, models.RevisionCreate, revs[1].Action)
+	}
+
+	diff, err := svc.DiffRevisions("", created.ID, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 2, diff.To)
+	assert.Equal(t, []models.ListEntry{{Index: 2, Text: "2 cloves garlic"}}, diff.Ingredients.Added)
+
+	_, err = svc.RevertRecipe("intruder", created.ID, 1)
+	assert.ErrorIs(t, err, service.ErrRecipeForbidden)
+	_, err = svc.RevertRecipe("owner", created.ID, 9)
+	assert.ErrorIs(t, err, service.ErrRevisionNotFound)
+
+	reverted, err := svc.RevertRecipe("owner", created.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Tomato Soup", reverted.Title)
+	assert.Len(t, reverted.StructuredIngredients, 2)
+	revs, _ = svc.ListRevisions("", created.ID)
+	if assert.Len(t, revs, 3) {
+		assert.Equal(t, models.RevisionRevert, revs[0].Action)
+		assert.Equal(t, 1, revs[0].RevertedFrom)
+	}
+}
+
+func TestRecipeService_ForkAndAncestry(t *testing.T) {
+	repo := newFakeRecipeRepository()
+	svc := service.NewRecipeService(repo, nil)
+	original, err := svc.CreateRecipe("alice", newTestRecipe())
+	assert.NoError(t, err)
+
+	fork, err := svc.ForkRecipe("bob", original.ID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, original.ID, fork.ID)
+	assert.Equal(t, "bob", fork.UserID)
+	assert.Equal(t, original.ID, fork.ParentRecipeID)
+	assert.Equal(t, "alice", fork.OriginalUserID)
+	assert.Equal(t, original.Ingredients, fork.Ingredients)
+
+	// Editing the fork leaves the original alone.
+	edit := newTestRecipe()
+	edit.Title = "Bob's Soup"
+	_, err = svc.UpdateRecipe("bob", fork.ID, edit)
+	assert.NoError(t, err)
+	stored, _ := svc.GetRecipe("", original.ID)
+	assert.Equal(t, "Tomato Soup", stored.Title)
+
+	// A fork of a fork still credits the first author.
+	grandchild, err := svc.ForkRecipe("carol", fork.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", grandchild.OriginalUserID)
+
+	ancestry, err := svc.RecipeAncestry("", grandchild.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, ancestry, 2) {
+		assert.Equal(t, fork.ID, ancestry[0].ID)
+		assert.Equal(t, original.ID, ancestry[1].ID)
+	}
+	forks, err := svc.ListForks("", original.ID)
+	assert.NoError(t, err)
+	assert.Len(t, forks, 1)
+
+	revs, _ := svc.ListRevisions("", fork.ID)
+	assert.Equal(t, models.RevisionFork, revs[len(revs)-1].Action)
+
+	_, err = svc.ForkRecipe("bob", "missing")
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+}
+
+func TestRecipeService_Tags(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+
+	input := newTestRecipe()
+	input.Tags = models.ParseTags([]string{"diet:vegan", "Cuisine:italian", "weeknight favourite", "diet:Vegan"})
+	created, err := svc.CreateRecipe("user-1", input)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cuisine:Italian", "diet:Vegan", "tag:weeknight favourite"}, models.TagRefs(created.Tags),
+		"canonical names, duplicates dropped, sorted by kind")
+
+	for _, refs := range [][]string{{"cuisine:Atlantean"}, {"tag:   "}} {
+		invalid := newTestRecipe()
+		invalid.Tags = models.ParseTags(refs)
+		_, err = svc.CreateRecipe("user-1", invalid)
+		assert.ErrorIs(t, err, service.ErrInvalidRecipe, refs)
+	}
+
+	counts, err := svc.ListTags(models.TagKindDiet)
+	assert.NoError(t, err)
+	assert.Len(t, counts, len(models.TagVocabularies[models.TagKindDiet]), "unused vocabulary is listed too")
+	assert.Equal(t, "diet:vegan", counts[0].ID)
+	assert.Equal(t, 1, counts[0].RecipeCount)
+
+	_, err = svc.ListTags("colour")
+	assert.ErrorIs(t, err, service.ErrInvalidQuery)
+}
+
+func TestRecipeService_DetectsAllergens(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+	input := newTestRecipe()
+	input.Ingredients = []string{"2 cups flour", "1 cup milk"}
+	created, err := svc.CreateRecipe("user-1", input)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StringArray{"milk", "wheat"}, created.Allergens)
+	assert.Equal(t, "Contains milk and wheat (gluten).", created.AllergyDisclaimer)
+
+	// Sending the generated disclaimer back lets it follow the new ingredients.
+	edit := newTestRecipe()
+	edit.Ingredients = []string{"2 cups flour", "1 cup oat milk"}
+	edit.AllergyDisclaimer = created.AllergyDisclaimer
+	updated, err := svc.UpdateRecipe("user-1", created.ID, edit)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StringArray{"wheat"}, updated.Allergens)
+	assert.Equal(t, "Contains wheat (gluten).", updated.AllergyDisclaimer)
+
+	edit.AllergyDisclaimer = "May contain traces of nuts."
+	updated, err = svc.UpdateRecipe("user-1", created.ID, edit)
+	assert.NoError(t, err)
+	assert.Equal(t, "May contain traces of nuts.", updated.AllergyDisclaimer)
+}
+
+func TestRecipeService_DerivesTimesFromSteps(t *testing.T) {
+	svc := service.NewRecipeService(newFakeRecipeRepository(), nil)
+	input := newTestRecipe()
+	input.Ingredients = []string{"2 cups flour", "1 cup milk"}
+	input.Steps = []string{"Whisk the flour and milk for 2 minutes.", "Rest the batter 30 minutes.", "Bake at 220°C for 20-25 minutes."}
+	created, err := svc.CreateRecipe("user-1", input)
+	assert.NoError(t, err)
+	assert.Len(t, created.StructuredSteps, 3)
+	assert.Equal(t, []string{"flour", "milk"}, created.StructuredSteps[0].Ingredients)
+	assert.Equal(t, &models.Temperature{Value: 220, Unit: models.TemperatureCelsius}, created.StructuredSteps[2].Temperature)
+	assert.Equal(t, []int{32, 25, 57}, []int{created.PrepMinutes, created.CookMinutes, created.TotalMinutes})
+
+	edit := newTestRecipe()
+	edit.Steps = []string{"Mix everything.", "Bake 1 hour."}
+	updated, err := svc.UpdateRecipe("user-1", created.ID, edit)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 60, 60}, []int{updated.PrepMinutes, updated.CookMinutes, updated.TotalMinutes})
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// MaxTagNameLength limits the display name of free-form tags.
+const MaxTagNameLength = 50
+
+// ListTags returns the tags of one kind, or of all kinds when kind is empty,
+// with the number of recipes using each. Controlled vocabulary entries that
+// no recipe uses yet are included with a count of zero.
+func (s *recipeService) ListTags(kind string) ([]models.TagCount, error) {
+	if _, controlled := models.TagVocabularies[kind]; kind != "" && kind != models.TagKindUser && !controlled {
+		return nil, fmt.Errorf("%w: unknown tag kind %q", ErrInvalidQuery, kind)
+	}
+	counts, err := s.repo.ListTags(kind)
+	if err != nil {
+		return nil, fmt.Errorf("repository tag error: %v", err)
+	}
+
+	seen := make(map[string]bool, len(counts))
+	for _, c := range counts {
+		seen[c.ID] = true
+	}
+	for _, k := range []string{models.TagKindCuisine, models.TagKindCourse, models.TagKindDiet, models.TagKindOccasion} {
+		if kind != "" && kind != k {
+			continue
+		}
+		for _, name := range models.TagVocabularies[k] {
+			if tag := models.ParseTag(k + ":" + name); !seen[tag.ID] {
+				counts = append(counts, models.TagCount{Tag: tag})
+			}
+		}
+	}
+	return counts, nil
+}
+
+// normalizeTags checks the recipe's tags against the vocabularies, gives
+// controlled tags their canonical names, drops duplicates and sorts them by
+// kind and slug. Errors wrap ErrInvalidRecipe.
+func normalizeTags(recipe *models.Recipe) error {
+	if len(recipe.Tags) > MaxRecipeTags {
+		return fmt.Errorf("%w: more than %d tags", ErrInvalidRecipe, MaxRecipeTags)
+	}
+	seen := make(map[string]bool, len(recipe.Tags))
+	tags := make([]models.Tag, 0, len(recipe.Tags))
+	for _, tag := range recipe.Tags {
+		tag = models.ParseTag(tag.Ref())
+		if tag.Slug == "" {
+			return fmt.Errorf("%w: tags must not be blank", ErrInvalidRecipe)
+		}
+		if vocabulary, controlled := models.TagVocabularies[tag.Kind]; controlled {
+			name, ok := vocabularyName(vocabulary, tag.Slug)
+			if !ok {
+				return fmt.Errorf("%w: unknown %s %q", ErrInvalidRecipe, tag.Kind, tag.Name)
+			}
+			tag.Name = name
+		} else if len(tag.Name) > MaxTagNameLength {
+			return fmt.Errorf("%w: tag %q exceeds %d characters", ErrInvalidRecipe, tag.Name, MaxTagNameLength)
+		}
+		if !seen[tag.ID] {
+			seen[tag.ID] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Kind != tags[j].Kind {
+			return tags[i].Kind < tags[j].Kind
+		}
+		return tags[i].Slug < tags[j].Slug
+	})
+	recipe.Tags = tags
+	return nil
+}
+
+// vocabularyName finds the vocabulary entry with the given slug.
+func vocabularyName(vocabulary []string, slug string) (string, bool) {
+	for _, name := range vocabulary {
+		if models.TagSlug(name) == slug {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"gorm.io/gorm"
+)
+
+// ListTrash returns the recipes userID has deleted and not yet restored or
+// had purged, most recently deleted first.
+func (s *recipeService) ListTrash(userID string) ([]*models.Recipe, error) {
+	recipes, err := s.repo.ListDeletedRecipes(userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository trash error: %v", err)
+	}
+	return recipes, nil
+}
+
+// RestoreRecipe takes a recipe owned by userID out of the trash. It returns
+// ErrRecipeNotFound if the recipe is not in the trash and ErrRecipeForbidden
+// if it is owned by someone else.
+func (s *recipeService) RestoreRecipe(userID, recipeID string) (*models.Recipe, error) {
+	recipe, err := s.repo.GetDeletedRecipe(recipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, err
+	}
+	if recipe.UserID != userID {
+		log.Printf("RestoreRecipe: user %s attempted to restore recipe %s owned by %s", userID, recipeID, recipe.UserID)
+		return nil, ErrRecipeForbidden
+	}
+	if err := s.repo.RestoreRecipe(recipeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		log.Printf("RestoreRecipe: failed to restore recipe %s: %v", recipeID, err)
+		return nil, err
+	}
+	log.Printf("RestoreRecipe: user %s restored recipe %s", userID, recipeID)
+	return s.GetRecipe(userID, recipeID)
+}
+
+// PurgeTrash permanently removes the recipes that have been in the trash for
+// longer than retention, along with their stored images, and returns how
+// many were removed.
+func (s *recipeService) PurgeTrash(retention time.Duration) (int, error) {
+	purged, blobKeys, err := s.repo.PurgeDeletedRecipes(time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("repository purge error: %v", err)
+	}
+	s.deleteBlobs(blobKeys)
+	if purged > 0 {
+		log.Printf("PurgeTrash: purged %d recipes deleted more than %v ago", purged, retention)
+	}
+	return purged, nil
+}
+
+// StartTrashPurger purges expired recipes from the trash once at start-up and
+// then every interval until ctx is cancelled. It does nothing when retention
+// or interval is zero.
+func StartTrashPurger(ctx context.Context, svc RecipeService, retention, interval time.Duration) {
+	if retention <= 0 || interval <= 0 {
+		log.Println("StartTrashPurger: trash purging is disabled")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := svc.PurgeTrash(retention); err != nil {
+				log.Printf("StartTrashPurger: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
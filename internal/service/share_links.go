@@ -0,0 +1,217 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Lifetimes accepted for share links.
+const (
+	DefaultShareLinkTTL = 7 * 24 * time.Hour
+	MaxShareLinkTTL     = 90 * 24 * time.Hour
+)
+
+var (
+	// ErrInvalidShareLink is returned (wrapped with details) when a share link
+	// cannot be created with the requested settings.
+	ErrInvalidShareLink = errors.New("invalid share link")
+	// ErrShareLinkNotFound is returned when a share token is malformed, forged
+	// or names a link that does not exist.
+	ErrShareLinkNotFound = errors.New("share link not found")
+	// ErrShareLinkExpired is returned when a share link has expired or was revoked.
+	ErrShareLinkExpired = errors.New("share link has expired or was revoked")
+)
+
+// ShareLinkService manages signed links that let anyone read one recipe.
+type ShareLinkService interface {
+	// CreateShareLink creates a link to a recipe owned by userID that stays
+	// valid for ttl; zero selects DefaultShareLinkTTL.
+	CreateShareLink(userID, recipeID string, ttl time.Duration) (*models.ShareLink, error)
+	// ListShareLinks returns the links created for a recipe owned by userID, newest first.
+	ListShareLinks(userID, recipeID string) ([]*models.ShareLink, error)
+	// RevokeShareLink disables a link to a recipe owned by userID.
+	RevokeShareLink(userID, recipeID, linkID string) error
+	// ResolveShareLink returns the recipe a share token grants access to.
+	ResolveShareLink(token string) (*models.Recipe, error)
+}
+
+// shareLinkService implements ShareLinkService.
+type shareLinkService struct {
+	repo   repository.RecipeRepository
+	secret []byte
+}
+
+// NewShareLinkService creates a ShareLinkService whose tokens are signed with
+// secret. Changing the secret invalidates every link issued before.
+func NewShareLinkService(repo repository.RecipeRepository, secret []byte) ShareLinkService {
+	return &shareLinkService{repo: repo, secret: secret}
+}
+
+// CreateShareLink records a new link and returns it with its token. Links to
+// private recipes are allowed; that is what they are for.
+func (s *shareLinkService) CreateShareLink(userID, recipeID string, ttl time.Duration) (*models.ShareLink, error) {
+	if ttl == 0 {
+		ttl = DefaultShareLinkTTL
+	}
+	if ttl < time.Minute || ttl > MaxShareLinkTTL {
+		return nil, fmt.Errorf("%w: lifetime must be between 1m and %s", ErrInvalidShareLink, MaxShareLinkTTL)
+	}
+	if _, err := s.ownedRecipe(userID, recipeID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	link := &models.ShareLink{
+		ID:       uuid.New().String(),
+		RecipeID: recipeID,
+		UserID:   userID,
+		// Tokens carry whole seconds, so the stored expiry does too.
+		ExpiresAt: now.Add(ttl).Truncate(time.Second),
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateShareLink(link); err != nil {
+		log.Printf("CreateShareLink: failed to create link for recipe %s: %v", recipeID, err)
+		return nil, err
+	}
+	link.Token = s.sign(link)
+	log.Printf("CreateShareLink: user %s shared recipe %s until %s", userID, recipeID, link.ExpiresAt.Format(time.RFC3339))
+	return link, nil
+}
+
+// ListShareLinks returns every link of the recipe, including expired and
+// revoked ones; tokens are filled in for the active links.
+func (s *shareLinkService) ListShareLinks(userID, recipeID string) ([]*models.ShareLink, error) {
+	if _, err := s.ownedRecipe(userID, recipeID); err != nil {
+		return nil, err
+	}
+	links, err := s.repo.ListShareLinks(recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("repository share link error: %v", err)
+	}
+	now := time.Now()
+	for _, link := range links {
+		if link.Active(now) {
+			link.Token = s.sign(link)
+		}
+	}
+	return links, nil
+}
+
+// RevokeShareLink disables a link immediately. Revoking a link twice is not
+// an error. It returns ErrShareLinkNotFound if the recipe has no such link.
+func (s *shareLinkService) RevokeShareLink(userID, recipeID, linkID string) error {
+	if _, err := s.ownedRecipe(userID, recipeID); err != nil {
+		return err
+	}
+	link, err := s.repo.GetShareLink(linkID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && link.RecipeID != recipeID) {
+		return ErrShareLinkNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RevokeShareLink(linkID, time.Now()); err != nil {
+		log.Printf("RevokeShareLink: failed to revoke link %s: %v", linkID, err)
+		return err
+	}
+	log.Printf("RevokeShareLink: user %s revoked link %s to recipe %s", userID, linkID, recipeID)
+	return nil
+}
+
+// ResolveShareLink verifies a token and returns the recipe it links to,
+// whatever the recipe's visibility. The signature and expiry are checked
+// before the database is consulted for revocation. Recipes in the trash
+// cannot be opened through their links.
+func (s *shareLinkService) ResolveShareLink(token string) (*models.Recipe, error) {
+	linkID, expires, ok := s.verify(token)
+	if !ok {
+		return nil, ErrShareLinkNotFound
+	}
+	now := time.Now()
+	if !now.Before(expires) {
+		return nil, ErrShareLinkExpired
+	}
+	link, err := s.repo.GetShareLink(linkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+	if !link.Active(now) {
+		return nil, ErrShareLinkExpired
+	}
+	recipe, err := s.repo.GetRecipeByID(link.RecipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// ownedRecipe loads a recipe and checks that it belongs to userID. Other
+// users' private recipes are reported as not found.
+func (s *shareLinkService) ownedRecipe(userID, recipeID string) (*models.Recipe, error) {
+	recipe, err := s.repo.GetVisibleRecipe(recipeID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRecipeNotFound
+		}
+		return nil, err
+	}
+	if recipe.UserID != userID {
+		return nil, ErrRecipeForbidden
+	}
+	return recipe, nil
+}
+
+// sign returns the token of a link: "<link id>.<expiry>.<signature>", where
+// the expiry is in Unix seconds and the signature is an HMAC-SHA256 of the
+// first two parts.
+func (s *shareLinkService) sign(link *models.ShareLink) string {
+	payload := link.ID + "." + strconv.FormatInt(link.ExpiresAt.Unix(), 10)
+	return payload + "." + s.mac(payload)
+}
+
+// verify checks the signature of a token and returns the link ID and expiry
+// it carries.
+func (s *shareLinkService) verify(token string) (linkID string, expires time.Time, ok bool) {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 {
+		return "", time.Time{}, false
+	}
+	payload, sig := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(s.mac(payload))) {
+		return "", time.Time{}, false
+	}
+	linkID, rawExpiry, found := strings.Cut(payload, ".")
+	if !found {
+		return "", time.Time{}, false
+	}
+	unix, err := strconv.ParseInt(rawExpiry, 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return linkID, time.Unix(unix, 0), true
+}
+
+// mac signs payload with the service secret.
+func (s *shareLinkService) mac(payload string) string {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/service"
+)
+
+func TestShareLinkService(t *testing.T) {
+	repo := newFakeRecipeRepository()
+	recipes := service.NewRecipeService(repo, nil)
+	links := service.NewShareLinkService(repo, []byte("secret"))
+
+	input := newTestRecipe()
+	input.Visibility = models.VisibilityPrivate
+	recipe, err := recipes.CreateRecipe("alice", input)
+	require.NoError(t, err)
+
+	link, err := links.CreateShareLink("alice", recipe.ID, 0)
+	require.NoError(t, err)
+	assert.NotEmpty(t, link.Token)
+	assert.WithinDuration(t, time.Now().Add(service.DefaultShareLinkTTL), link.ExpiresAt, time.Minute)
+
+	shared, err := links.ResolveShareLink(link.Token)
+	require.NoError(t, err, "links open private recipes")
+	assert.Equal(t, recipe.ID, shared.ID)
+
+	// A token signed with another secret, or tampered with, is rejected.
+	forged, err := service.NewShareLinkService(repo, []byte("other")).CreateShareLink("alice", recipe.ID, time.Hour)
+	require.NoError(t, err)
+	_, err = links.ResolveShareLink(forged.Token)
+	assert.ErrorIs(t, err, service.ErrShareLinkNotFound)
+	_, err = links.ResolveShareLink(link.Token[:len(link.Token)-2] + "xx")
+	assert.ErrorIs(t, err, service.ErrShareLinkNotFound)
+	_, err = links.ResolveShareLink("garbage")
+	assert.ErrorIs(t, err, service.ErrShareLinkNotFound)
+
+	_, err = links.CreateShareLink("bob", recipe.ID, time.Hour)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound, "only the owner can share")
+	_, err = links.CreateShareLink("alice", recipe.ID, service.MaxShareLinkTTL+time.Hour)
+	assert.ErrorIs(t, err, service.ErrInvalidShareLink)
+
+	listed, err := links.ListShareLinks("alice", recipe.ID)
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+
+	require.NoError(t, links.RevokeShareLink("alice", recipe.ID, link.ID))
+	require.NoError(t, links.RevokeShareLink("alice", recipe.ID, link.ID), "revoking twice is harmless")
+	_, err = links.ResolveShareLink(link.Token)
+	assert.ErrorIs(t, err, service.ErrShareLinkExpired)
+	assert.ErrorIs(t, links.RevokeShareLink("alice", recipe.ID, "missing"), service.ErrShareLinkNotFound)
+
+	// Recipes in the trash cannot be opened through their links.
+	other, err := links.CreateShareLink("alice", recipe.ID, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, recipes.DeleteRecipe("alice", recipe.ID))
+	_, err = links.ResolveShareLink(other.Token)
+	assert.ErrorIs(t, err, service.ErrRecipeNotFound)
+}
@@ -44,6 +44,18 @@ func (f *fakeUserRepository) GetUserByID(id string) (*models.User, error) {
 	return nil, errors.New("user not found")
 }
 
+func (f *fakeUserRepository) GetUsersByUsernames(usernames []string) ([]*models.User, error) {
+	var users []*models.User
+	for _, name := range usernames {
+		for _, u := range f.users {
+			if u.Username == name {
+				users = append(users, u)
+			}
+		}
+	}
+	return users, nil
+}
+
 func TestUserService_Register(t *testing.T) {
 	// Set up the fake repository
 	repo := &fakeUserRepository{}
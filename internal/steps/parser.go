@@ -0,0 +1,319 @@
+// Package steps turns free-text preparation steps into structured
+// models.Step values and derives a recipe's prep, cook and total times.
+package steps
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+)
+
+// number matches integers, decimals, fractions, mixed numbers and unicode
+// fractions, e.g. "25", "1.5", "1/2", "1 1/2", "1½" or "½".
+const number = `(?:\d+(?:\.\d+)?(?:\s+\d+/\d+|\s*[½⅓⅔¼¾⅛])?|\d+/\d+|[½⅓⅔¼¾⅛])`
+
+// durationPattern matches a duration or range of durations such as
+// "25 minutes", "1-2 hrs", "10 to 15 mins" or "30s".
+var durationPattern = regexp.MustCompile(`(?i)(` + number + `)(?:\s*(?:-|–|to)\s*(` + number + `))?\s*` +
+	`(hours?|hrs?|h|minutes?|mins?|m|seconds?|secs?|s)\b`)
+
+// phraseDurations are durations written out in words, in minutes.
+var phraseDurations = []struct {
+	pattern *regexp.Regexp
+	minutes float64
+}{
+	{regexp.MustCompile(`(?i)\bhalf an? hour\b`), 30},
+	{regexp.MustCompile(`(?i)\b(?:an|one) hour and a half\b`), 90},
+	{regexp.MustCompile(`(?i)\ban hour\b`), 60},
+	{regexp.MustCompile(`(?i)\ba minute\b`), 1},
+	{regexp.MustCompile(`(?i)\bovernight\b`), 8 * 60},
+}
+
+// unitMinutes maps the first letter of a duration unit to its length in minutes.
+var unitMinutes = map[byte]float64{'h': 60, 'm': 1, 's': 1.0 / 60}
+
+// temperaturePattern matches temperatures such as "180°C", "350 °F",
+// "400 degrees F", "200 degrees Celsius" or a bare "350°".
+var temperaturePattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(?:°|º|degrees?\b|deg\b)\s*(c\b|f\b|celsius|centigrade|fahrenheit)?`)
+
+// gasMarkPattern matches British oven settings such as "gas mark 4".
+var gasMarkPattern = regexp.MustCompile(`(?i)\bgas(?: mark)? (\d)\b`)
+
+// gasMarks maps gas marks to degrees Celsius.
+var gasMarks = map[string]float64{"1": 140, "2": 150, "3": 170, "4": 180, "5": 190, "6": 200, "7": 220, "8": 230, "9": 240}
+
+// clauseBreak splits a step into clauses, each of which is classified by its
+// own verb: "Simmer 20 minutes, then stir in the cream and rest 5 minutes".
+var clauseBreak = regexp.MustCompile(`(?i)[.;!]\s+|,?\s+then\s+|,\s+and\s+|\s+and\s+(?:then\s+)?`)
+
+// timeVerb classifies the time a verb spends: passive time is unattended,
+// and heat marks cooking rather than preparation.
+type timeVerb struct {
+	word          string
+	passive, heat bool
+}
+
+// timeVerbs lists the verbs that classify a step's durations.
+var timeVerbs = []timeVerb{
+	{"bake", true, true}, {"roast", true, true}, {"simmer", true, true}, {"braise", true, true},
+	{"boil", true, true}, {"steam", true, true}, {"poach", true, true}, {"slow cook", true, true},
+	{"pressure cook", true, true},
+	{"fry", false, true}, {"saute", false, true}, {"sauté", false, true}, {"sear", false, true},
+	{"grill", false, true}, {"broil", false, true}, {"toast", false, true}, {"cook", false, true},
+	{"brown", false, true}, {"caramelize", false, true}, {"caramelise", false, true},
+	{"reduce", false, true}, {"heat", false, true}, {"microwave", false, true}, {"blanch", false, true},
+	{"melt", false, true}, {"char", false, true},
+	{"rest", true, false}, {"chill", true, false}, {"refrigerate", true, false}, {"freeze", true, false},
+	{"marinate", true, false}, {"rise", true, false}, {"proof", true, false}, {"prove", true, false},
+	{"ferment", true, false}, {"cool", true, false}, {"soak", true, false}, {"steep", true, false},
+	{"stand", true, false}, {"sit", true, false}, {"set aside", true, false}, {"infuse", true, false},
+	{"knead", false, false}, {"whisk", false, false}, {"stir", false, false}, {"mix", false, false},
+	{"beat", false, false}, {"blend", false, false}, {"chop", false, false},
+}
+
+// fahrenheitFloor is the lowest bare temperature ("350°") read as
+// Fahrenheit; cooking temperatures in Celsius rarely exceed it.
+const fahrenheitFloor = 275
+
+// Parse extracts durations, the cooking temperature and the ingredients used
+// from a step. Durations are classified as passive or active by the first
+// verb of the clause they appear in, falling back to the step's first verb;
+// ranges count at their upper bound. A step cooks when it gives a
+// temperature or any of its verbs applies heat, so "Mix the batter and bake
+// 30 minutes" cooks. known lists the recipe's ingredients; those named in the
+// text are recorded by name.
+func Parse(text string, known []models.Ingredient) models.Step {
+	step := models.Step{Text: text}
+	lower := strings.ToLower(text)
+	stepVerb, _ := classify(lower)
+	for _, clause := range clauseBreak.Split(lower, -1) {
+		minutes := clauseMinutes(clause)
+		if minutes == 0 {
+			continue
+		}
+		verb, found := classify(clause)
+		if !found {
+			verb = stepVerb
+		}
+		if verb.passive {
+			step.PassiveMinutes += minutes
+		} else {
+			step.ActiveMinutes += minutes
+		}
+	}
+	step.ActiveMinutes = round(step.ActiveMinutes)
+	step.PassiveMinutes = round(step.PassiveMinutes)
+	step.Temperature = temperature(text)
+	step.Cooking = step.Temperature != nil || appliesHeat(lower)
+	step.Ingredients = usedIngredients(lower, known)
+	return step
+}
+
+// ParseAll parses each non-blank step.
+func ParseAll(lines []string, known []models.Ingredient) []models.Step {
+	out := make([]models.Step, 0, len(lines))
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		out = append(out, Parse(line, known))
+	}
+	return out
+}
+
+// Times sums the time of steps into prep and cook minutes, rounded up to
+// whole minutes. Steps that apply heat count as cooking; all others as prep.
+func Times(steps []models.Step) (prep, cook, total int) {
+	var prepMinutes, cookMinutes float64
+	for _, s := range steps {
+		if s.Cooking {
+			cookMinutes += s.Minutes()
+		} else {
+			prepMinutes += s.Minutes()
+		}
+	}
+	prep, cook = int(math.Ceil(prepMinutes)), int(math.Ceil(cookMinutes))
+	return prep, cook, prep + cook
+}
+
+// Annotate stores the structured form of recipe's steps, parsed against its
+// structured ingredients, along with the prep, cook and total times.
+func Annotate(recipe *models.Recipe) {
+	recipe.StructuredSteps = ParseAll(recipe.Steps, recipe.StructuredIngredients)
+	recipe.PrepMinutes, recipe.CookMinutes, recipe.TotalMinutes = Times(recipe.StructuredSteps)
+}
+
+// clauseMinutes sums the durations mentioned in a clause.
+func clauseMinutes(clause string) float64 {
+	var minutes float64
+	for _, m := range durationPattern.FindAllStringSubmatch(clause, -1) {
+		value := parseNumber(m[1])
+		if m[2] != "" {
+			value = math.Max(value, parseNumber(m[2]))
+		}
+		minutes += value * unitMinutes[strings.ToLower(m[3])[0]]
+	}
+	for _, phrase := range phraseDurations {
+		if phrase.pattern.MatchString(clause) {
+			minutes += phrase.minutes
+			clause = phrase.pattern.ReplaceAllString(clause, "")
+		}
+	}
+	return minutes
+}
+
+// classify returns the first verb of timeVerbs in text and whether one was
+// found; texts without one count as active preparation.
+func classify(text string) (timeVerb, bool) {
+	first, found := -1, timeVerb{}
+	for _, verb := range timeVerbs {
+		if i := verbIndex(text, verb.word); i >= 0 && (first < 0 || i < first) {
+			first, found = i, verb
+		}
+	}
+	return found, first >= 0
+}
+
+// appliesHeat reports whether any verb of timeVerbs in text applies heat.
+func appliesHeat(text string) bool {
+	for _, verb := range timeVerbs {
+		if verb.heat && verbIndex(text, verb.word) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// verbIndex returns the index of verb in text like wordIndex, skipping the
+// noun in "the rest of the sugar".
+func verbIndex(text, verb string) int {
+	i := wordIndex(text, verb)
+	if i >= 0 && verb == "rest" && strings.HasPrefix(text[i+len("rest"):], " of") {
+		return -1
+	}
+	return i
+}
+
+// temperature returns the first temperature in text, or nil.
+func temperature(text string) *models.Temperature {
+	if m := temperaturePattern.FindStringSubmatch(text); m != nil {
+		value, err := strconv.ParseFloat(m[1], 64)
+		if err == nil {
+			unit := models.TemperatureCelsius
+			switch u := strings.ToLower(m[2]); {
+			case u == "f" || u == "fahrenheit":
+				unit = models.TemperatureFahrenheit
+			case u == "" && value >= fahrenheitFloor:
+				unit = models.TemperatureFahrenheit
+			}
+			return &models.Temperature{Value: value, Unit: unit}
+		}
+	}
+	if m := gasMarkPattern.FindStringSubmatch(text); m != nil {
+		return &models.Temperature{Value: gasMarks[m[1]], Unit: models.TemperatureCelsius}
+	}
+	return nil
+}
+
+// usedIngredients returns the names of the known ingredients mentioned in
+// text. A multi-word name also matches by its last word, so "flour" finds
+// "all-purpose flour"; plural and singular forms match each other.
+func usedIngredients(text string, known []models.Ingredient) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, ing := range known {
+		name := strings.TrimSpace(ing.Name)
+		if name == "" || seen[name] {
+			continue
+		}
+		words := strings.FieldsFunc(name, func(r rune) bool { return r == ' ' || r == '-' })
+		if mentions(text, name) || (len(words) > 1 && len(words[len(words)-1]) > 3 && mentions(text, words[len(words)-1])) {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// mentions reports whether text contains name as whole words, allowing a
+// plural or singular form of the final word.
+func mentions(text, name string) bool {
+	if wordIndex(text, name) >= 0 {
+		return true
+	}
+	if strings.HasSuffix(name, "s") && wordIndex(text, strings.TrimSuffix(name, "s")) >= 0 {
+		return true
+	}
+	return wordIndex(text, name+"s") >= 0 || wordIndex(text, name+"es") >= 0
+}
+
+// wordIndex returns the index of the first occurrence of word in text that
+// starts and ends on word boundaries, or -1. Verbs also match their common
+// inflections ("bakes", "baked", "baking").
+func wordIndex(text, word string) int {
+	for from := 0; ; {
+		i := strings.Index(text[from:], word)
+		if i < 0 {
+			return -1
+		}
+		start, end := from+i, from+i+len(word)
+		if (start == 0 || !isWordByte(text[start-1])) && boundaryAfter(text[end:]) {
+			return start
+		}
+		from = start + 1
+	}
+}
+
+// boundaryAfter reports whether rest begins at a word boundary, after an
+// optional inflection suffix.
+func boundaryAfter(rest string) bool {
+	for _, suffix := range []string{"ing", "ed", "es", "s", "d", ""} {
+		if strings.HasPrefix(rest, suffix) && (len(rest) == len(suffix) || !isWordByte(rest[len(suffix)])) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordByte reports whether b can be part of a word. Bytes of multi-byte
+// runes count as word bytes, so "sauté" is matched whole.
+func isWordByte(b byte) bool {
+	return b == '_' || b >= 0x80 || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
+
+// unicodeFractions maps vulgar fraction characters to their value.
+var unicodeFractions = map[string]float64{"½": 0.5, "⅓": 1.0 / 3, "⅔": 2.0 / 3, "¼": 0.25, "¾": 0.75, "⅛": 0.125}
+
+// parseNumber parses a number matched by the number pattern.
+func parseNumber(s string) float64 {
+	var total float64
+	for frac, v := range unicodeFractions {
+		if strings.HasSuffix(s, frac) {
+			total += v
+			s = strings.TrimSpace(strings.TrimSuffix(s, frac))
+			break
+		}
+	}
+	for _, field := range strings.Fields(s) {
+		if num, den, ok := strings.Cut(field, "/"); ok {
+			n, err1 := strconv.ParseFloat(num, 64)
+			d, err2 := strconv.ParseFloat(den, 64)
+			if err1 == nil && err2 == nil && d != 0 {
+				total += n / d
+			}
+			continue
+		}
+		if v, err := strconv.ParseFloat(field, 64); err == nil {
+			total += v
+		}
+	}
+	return total
+}
+
+// round rounds minutes to two decimal places, enough for second timers.
+func round(minutes float64) float64 {
+	return math.Round(minutes*100) / 100
+}
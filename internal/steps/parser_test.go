@@ -0,0 +1,81 @@
+package steps_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pageza/recipe-book-api-v2/internal/ingredients"
+	"github.com/pageza/recipe-book-api-v2/internal/models"
+	"github.com/pageza/recipe-book-api-v2/internal/steps"
+)
+
+// stepCases is a corpus of step texts with the times and temperature
+// expected from them.
+var stepCases = []struct {
+	text            string
+	active, passive float64
+	cooking         bool
+	temperature     *models.Temperature
+}{
+	{"Bake 25 minutes at 180°C until golden.", 0, 25, true, &models.Temperature{Value: 180, Unit: "C"}},
+	{"Preheat the oven to 350°F.", 0, 0, true, &models.Temperature{Value: 350, Unit: "F"}},
+	{"Roast at 400 degrees F for 1 hour.", 0, 60, true, &models.Temperature{Value: 400, Unit: "F"}},
+	{"Bake at 200 degrees Celsius for 1 1/2 hours", 0, 90, true, &models.Temperature{Value: 200, Unit: "C"}},
+	{"Bake at 375° for 30-35 minutes", 0, 35, true, &models.Temperature{Value: 375, Unit: "F"}},
+	{"Cook at gas mark 4 for 40 mins", 40, 0, true, &models.Temperature{Value: 180, Unit: "C"}},
+	{"Knead the dough for 10 minutes, then let rise 1-2 hrs.", 10, 120, false, nil},
+	{"Sauté the onions for 5 minutes.", 5, 0, true, nil},
+	{"Simmer 20 minutes, then stir in the cream and rest 5 minutes", 0, 25, true, nil},
+	{"Whisk for 30 seconds", 0.5, 0, false, nil},
+	{"Marinate overnight in the fridge.", 0, 480, false, nil},
+	{"Chill for half an hour.", 0, 30, false, nil},
+	{"Fry the eggs for 2 to 3 minutes per side.", 3, 0, true, nil},
+	{"Add the rest of the sugar and whisk 2 minutes.", 2, 0, false, nil},
+	{"Mix the batter and bake 30 min.", 0, 30, true, nil},
+	{"Whisk the eggs, then fry until set, about 4 minutes.", 4, 0, true, nil},
+	{"Cut into 2 cm pieces and season with salt.", 0, 0, false, nil},
+	{"Serve immediately.", 0, 0, false, nil},
+}
+
+func TestParseCorpus(t *testing.T) {
+	for _, tc := range stepCases {
+		t.Run(tc.text, func(t *testing.T) {
+			got := steps.Parse(tc.text, nil)
+			assert.Equal(t, tc.text, got.Text)
+			assert.InDelta(t, tc.active, got.ActiveMinutes, 0.01, "active minutes")
+			assert.InDelta(t, tc.passive, got.PassiveMinutes, 0.01, "passive minutes")
+			assert.Equal(t, tc.cooking, got.Cooking, "cooking")
+			assert.Equal(t, tc.temperature, got.Temperature)
+		})
+	}
+}
+
+func TestParseFindsIngredients(t *testing.T) {
+	known := ingredients.ParseAll([]string{"2 cups all-purpose flour", "3 eggs", "1 cup whole milk", "1 tsp salt"})
+	got := steps.Parse("Whisk the egg into the milk, then fold in the flour.", known)
+	assert.ElementsMatch(t, []string{"all-purpose flour", "eggs", "whole milk"}, got.Ingredients)
+	assert.Empty(t, steps.Parse("Stir to combine.", known).Ingredients)
+}
+
+func TestTimesSplitsPrepAndCook(t *testing.T) {
+	parsed := steps.ParseAll([]string{
+		"Chop the vegetables, about 10 minutes.",
+		"",
+		"Sauté for 5 minutes.",
+		"Bake at 180°C for 20 minutes.",
+		"Cool for 30 seconds before serving.",
+	}, nil)
+	assert.Len(t, parsed, 4)
+	prep, cook, total := steps.Times(parsed)
+	assert.Equal(t, 11, prep, "partial minutes round up")
+	assert.Equal(t, 25, cook)
+	assert.Equal(t, 36, total)
+
+	recipe := &models.Recipe{Steps: []string{"Mix well.", "Bake 45 minutes."}}
+	steps.Annotate(recipe)
+	assert.Len(t, recipe.StructuredSteps, 2)
+	assert.Equal(t, 0, recipe.PrepMinutes)
+	assert.Equal(t, 45, recipe.CookMinutes)
+	assert.Equal(t, 45, recipe.TotalMinutes)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2025 Your Company
+All Rights Reserved.
+*/
+
+package units
+
+import "strings"
+
+// Density describes how much an ingredient weighs per unit of volume.
+type Density struct {
+	GramsPerMilliliter float64
+	// Liquid ingredients are measured by volume even in metric recipes.
+	Liquid bool
+}
+
+// densities lists common ingredients, keyed by lower-case name. Values are
+// derived from standard US cup weights (e.g. 125 g per cup of flour).
+var densities = map[string]Density{
+	"water":             {1.00, true},
+	"milk":              {1.03, true},
+	"buttermilk":        {1.03, true},
+	"cream":             {1.01, true},
+	"heavy cream":       {0.99, true},
+	"oil":               {0.92, true},
+	"olive oil":         {0.92, true},
+	"vegetable oil":     {0.92, true},
+	"vinegar":           {1.01, true},
+	"stock":             {1.00, true},
+	"broth":             {1.00, true},
+	"wine":              {0.99, true},
+	"juice":             {1.04, true},
+	"lemon juice":       {1.03, true},
+	"soy sauce":         {1.15, true},
+	"maple syrup":       {1.32, true},
+	"honey":             {1.42, false},
+	"flour":             {0.53, false},
+	"all-purpose flour": {0.53, false},
+	"bread flour":       {0.54, false},
+	"whole wheat flour": {0.51, false},
+	"sugar":             {0.85, false},
+	"granulated sugar":  {0.85, false},
+	"brown sugar":       {0.93, false},
+	"powdered sugar":    {0.51, false},
+	"icing sugar":       {0.51, false},
+	"butter":            {0.96, false},
+	"salt":              {1.22, false},
+	"kosher salt":       {0.57, false},
+	"baking soda":       {0.97, false},
+	"baking powder":     {0.81, false},
+	"cocoa":             {0.36, false},
+	"cocoa powder":      {0.36, false},
+	"cornstarch":        {0.54, false},
+	"cornmeal":          {0.58, false},
+	"oats":              {0.38, false},
+	"rolled oats":       {0.38, false},
+	"rice":              {0.78, false},
+	"yogurt":            {1.04, false},
+	"sour cream":        {0.97, false},
+	"cream cheese":      {0.98, false},
+	"cheese":            {0.48, false},
+	"peanut butter":     {1.09, false},
+	"chocolate chips":   {0.72, false},
+	"breadcrumbs":       {0.46, false},
+	"raisins":           {0.63, false},
+}
+
+// DensityOf looks up the density of an ingredient by name. The longest known
+// ingredient whose words appear in name wins, so "packed brown sugar" uses
+// brown sugar rather than sugar and "buttermilk" is not mistaken for butter.
+func DensityOf(ingredient string) (Density, bool) {
+	name := " " + strings.Join(strings.Fields(strings.ToLower(ingredient)), " ") + " "
+	var best string
+	for key := range densities {
+		if len(key) > len(best) && strings.Contains(name, " "+key+" ") {
+			best = key
+		}
+	}
+	if best == "" {
+		return Density{}, false
+	}
+	return densities[best], true
+}
@@ -0,0 +1,273 @@
+/*
+Copyright (C) 2025 Your Company
+All Rights Reserved.
+*/
+
+// Package units normalizes cooking units and converts quantities between the
+// metric and imperial (US customary) systems. Conversions between volume and
+// weight use the per-ingredient densities in density.go.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// System identifies a system of measurement.
+type System string
+
+const (
+	// Original leaves quantities in the units the recipe was written in.
+	Original System = ""
+	// Metric uses grams, kilograms, milliliters and liters.
+	Metric System = "metric"
+	// Imperial uses US customary cups, spoons, ounces and pounds.
+	Imperial System = "imperial"
+)
+
+// Dimension is the physical quantity a unit measures.
+type Dimension int
+
+const (
+	// Count units (cloves, cans, pinches) cannot be converted.
+	Count Dimension = iota
+	// Volume units are measured in milliliters.
+	Volume
+	// Mass units are measured in grams.
+	Mass
+)
+
+// Unit describes a canonical unit name.
+type Unit struct {
+	Name      string
+	Dimension Dimension
+	// Base is the size of one unit in milliliters (Volume) or grams (Mass).
+	Base float64
+	// System is the system the unit belongs to. Spoon measures are used
+	// everywhere and belong to neither.
+	System System
+}
+
+var (
+	// ErrUnknownUnit is returned when a unit name is not recognised.
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrIncompatibleUnits is returned when two units cannot be converted into
+	// each other, e.g. grams to cups without a known density.
+	ErrIncompatibleUnits = errors.New("incompatible units")
+	// ErrUnknownSystem is returned by ParseSystem for unrecognised names.
+	ErrUnknownSystem = errors.New("unknown unit system")
+)
+
+// unitTable holds every unit that can take part in a conversion, keyed by
+// canonical name. The names match those produced by the ingredient parser.
+var unitTable = map[string]Unit{
+	"teaspoon":    {"teaspoon", Volume, 4.92892, Original},
+	"tablespoon":  {"tablespoon", Volume, 14.7868, Original},
+	"fluid ounce": {"fluid ounce", Volume, 29.5735, Imperial},
+	"cup":         {"cup", Volume, 236.588, Imperial},
+	"pint":        {"pint", Volume, 473.176, Imperial},
+	"quart":       {"quart", Volume, 946.353, Imperial},
+	"gallon":      {"gallon", Volume, 3785.41, Imperial},
+	"milliliter":  {"milliliter", Volume, 1, Metric},
+	"centiliter":  {"centiliter", Volume, 10, Metric},
+	"deciliter":   {"deciliter", Volume, 100, Metric},
+	"liter":       {"liter", Volume, 1000, Metric},
+	"ounce":       {"ounce", Mass, 28.3495, Imperial},
+	"pound":       {"pound", Mass, 453.592, Imperial},
+	"milligram":   {"milligram", Mass, 0.001, Metric},
+	"gram":        {"gram", Mass, 1, Metric},
+	"kilogram":    {"kilogram", Mass, 1000, Metric},
+}
+
+// aliases maps common abbreviations and plurals to canonical unit names.
+var aliases = map[string]string{
+	"tsp": "teaspoon", "tbsp": "tablespoon", "tbs": "tablespoon",
+	"fl oz": "fluid ounce", "floz": "fluid ounce", "c": "cup",
+	"pt": "pint", "qt": "quart", "gal": "gallon",
+	"ml": "milliliter", "millilitre": "milliliter", "cl": "centiliter", "dl": "deciliter",
+	"l": "liter", "litre": "liter",
+	"oz": "ounce", "lb": "pound", "lbs": "pound",
+	"mg": "milligram", "g": "gram", "gr": "gram", "kg": "kilogram",
+}
+
+// Normalize returns the canonical name for a unit spelling ("Tbsp", "grams",
+// "fl oz"). Unknown units are returned lower-cased and trimmed.
+func Normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(name), ".")))
+	if _, ok := unitTable[name]; ok {
+		return name
+	}
+	if canonical, ok := aliases[name]; ok {
+		return canonical
+	}
+	for _, suffix := range []string{"es", "s"} {
+		singular := strings.TrimSuffix(name, suffix)
+		if _, ok := unitTable[singular]; ok && singular != name {
+			return singular
+		}
+		if canonical, ok := aliases[singular]; ok && singular != name {
+			return canonical
+		}
+	}
+	return name
+}
+
+// Lookup returns the definition of a unit, accepting any spelling Normalize does.
+func Lookup(name string) (Unit, bool) {
+	u, ok := unitTable[Normalize(name)]
+	return u, ok
+}
+
+// ParseSystem parses a unit system name as stored in user preferences.
+// An empty string or "original" selects Original.
+func ParseSystem(name string) (System, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "original":
+		return Original, nil
+	case "metric", "si":
+		return Metric, nil
+	case "imperial", "us", "us customary":
+		return Imperial, nil
+	}
+	return Original, fmt.Errorf("%w: %q", ErrUnknownSystem, name)
+}
+
+// Convert converts qty between two units of the same dimension.
+func Convert(qty float64, from, to string) (float64, error) {
+	return ConvertFor(qty, from, to, "")
+}
+
+// ConvertFor converts qty between two units. Volume and mass units can be
+// converted into each other when the density of ingredient is known.
+func ConvertFor(qty float64, from, to, ingredient string) (float64, error) {
+	src, ok := Lookup(from)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, from)
+	}
+	dst, ok := Lookup(to)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownUnit, to)
+	}
+	base := qty * src.Base
+	switch {
+	case src.Dimension == dst.Dimension:
+	case src.Dimension == Volume && dst.Dimension == Mass:
+		d, ok := DensityOf(ingredient)
+		if !ok {
+			return 0, fmt.Errorf("%w: %s to %s for %q", ErrIncompatibleUnits, src.Name, dst.Name, ingredient)
+		}
+		base *= d.GramsPerMilliliter
+	case src.Dimension == Mass && dst.Dimension == Volume:
+		d, ok := DensityOf(ingredient)
+		if !ok {
+			return 0, fmt.Errorf("%w: %s to %s for %q", ErrIncompatibleUnits, src.Name, dst.Name, ingredient)
+		}
+		base /= d.GramsPerMilliliter
+	default:
+		return 0, fmt.Errorf("%w: %s to %s", ErrIncompatibleUnits, src.Name, dst.Name)
+	}
+	return base / dst.Base, nil
+}
+
+// ToSystem expresses qty of unit in the given system and returns the new
+// quantity and unit. The target unit is chosen so the number stays readable
+// (grams become kilograms past 1000, small volumes become spoons), and
+// imperial results are rounded to kitchen fractions.
+//
+// In the metric system, dry ingredients measured by volume are converted to
+// grams when their density is known; liquids stay in milliliters or liters.
+// In the imperial system, weights under an ounce are converted to spoon
+// measures when the density is known.
+//
+// Quantities are returned unchanged when system is Original, when the unit
+// already belongs to the system (or to no system, like spoons), or when it
+// cannot be converted.
+func ToSystem(qty float64, unit, ingredient string, system System) (float64, string) {
+	u, ok := Lookup(unit)
+	if !ok || system == Original || u.System == system || u.System == Original {
+		return qty, unit
+	}
+	base := qty * u.Base
+	density, hasDensity := DensityOf(ingredient)
+
+	var target string
+	switch {
+	case system == Metric && u.Dimension == Volume && hasDensity && !density.Liquid:
+		base *= density.GramsPerMilliliter
+		target = metricUnit(Mass, base)
+	case system == Metric:
+		target = metricUnit(u.Dimension, base)
+	case u.Dimension == Mass && hasDensity && base < unitTable["ounce"].Base:
+		base /= density.GramsPerMilliliter
+		target = imperialUnit(Volume, base)
+	default:
+		target = imperialUnit(u.Dimension, base)
+	}
+	return Round(base/unitTable[target].Base, target), target
+}
+
+// Round rounds a quantity for display in the given unit: metric quantities to
+// whole numbers (or one decimal below 10), everything else to the nearest
+// kitchen fraction.
+func Round(qty float64, unit string) float64 {
+	if u, ok := Lookup(unit); ok && u.System == Metric {
+		if qty >= 10 {
+			return math.Round(qty)
+		}
+		return math.Round(qty*10) / 10
+	}
+	return roundKitchen(qty)
+}
+
+// kitchenFractions are the fractional parts cooks measure with.
+var kitchenFractions = []float64{0, 1.0 / 8, 1.0 / 4, 1.0 / 3, 3.0 / 8, 1.0 / 2, 5.0 / 8, 2.0 / 3, 3.0 / 4, 7.0 / 8, 1}
+
+// roundKitchen rounds qty to the nearest kitchen fraction, never below 1/8.
+func roundKitchen(qty float64) float64 {
+	whole := math.Floor(qty)
+	frac := qty - whole
+	best := kitchenFractions[0]
+	for _, f := range kitchenFractions[1:] {
+		if math.Abs(frac-f) < math.Abs(frac-best) {
+			best = f
+		}
+	}
+	if whole+best == 0 {
+		return kitchenFractions[1]
+	}
+	return whole + best
+}
+
+// metricUnit picks the metric unit for an amount in milliliters or grams.
+func metricUnit(dim Dimension, base float64) string {
+	if dim == Mass {
+		if base >= 1000 {
+			return "kilogram"
+		}
+		return "gram"
+	}
+	if base >= 1000 {
+		return "liter"
+	}
+	return "milliliter"
+}
+
+// imperialUnit picks the imperial unit for an amount in milliliters or grams.
+func imperialUnit(dim Dimension, base float64) string {
+	if dim == Mass {
+		if base >= unitTable["pound"].Base {
+			return "pound"
+		}
+		return "ounce"
+	}
+	switch {
+	case base < unitTable["tablespoon"].Base:
+		return "teaspoon"
+	case base < unitTable["cup"].Base/4:
+		return "tablespoon"
+	default:
+		return "cup"
+	}
+}
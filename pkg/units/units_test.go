@@ -0,0 +1,113 @@
+package units_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pageza/recipe-book-api-v2/pkg/units"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"Tbsp":        "tablespoon",
+		"tablespoons": "tablespoon",
+		"fl oz":       "fluid ounce",
+		"grams":       "gram",
+		"lbs.":        "pound",
+		"Litres":      "liter",
+		"inches":      "inches",
+		"Pinch":       "pinch",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, units.Normalize(in), in)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	got, err := units.Convert(1, "cup", "ml")
+	assert.NoError(t, err)
+	assert.InDelta(t, 236.588, got, 1e-3)
+
+	got, err = units.Convert(3, "teaspoons", "tablespoon")
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, got, 1e-3)
+
+	got, err = units.Convert(2, "lb", "kg")
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.907, got, 1e-3)
+
+	_, err = units.Convert(1, "cup", "gram")
+	assert.ErrorIs(t, err, units.ErrIncompatibleUnits)
+	_, err = units.Convert(1, "clove", "gram")
+	assert.ErrorIs(t, err, units.ErrUnknownUnit)
+}
+
+func TestConvertForUsesDensity(t *testing.T) {
+	got, err := units.ConvertFor(1, "cup", "gram", "all-purpose flour")
+	assert.NoError(t, err)
+	assert.InDelta(t, 125, got, 1)
+
+	got, err = units.ConvertFor(227, "gram", "cup", "unsalted butter")
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, got, 0.01)
+
+	_, err = units.ConvertFor(1, "cup", "gram", "mystery powder")
+	assert.ErrorIs(t, err, units.ErrIncompatibleUnits)
+}
+
+func TestDensityOfPrefersLongestMatch(t *testing.T) {
+	brown, ok := units.DensityOf("packed light brown sugar")
+	assert.True(t, ok)
+	assert.Equal(t, 0.93, brown.GramsPerMilliliter)
+
+	buttermilk, ok := units.DensityOf("buttermilk")
+	assert.True(t, ok)
+	assert.True(t, buttermilk.Liquid, "buttermilk must not match butter")
+
+	_, ok = units.DensityOf("saffron threads")
+	assert.False(t, ok)
+}
+
+func TestToSystem(t *testing.T) {
+	cases := []struct {
+		name       string
+		qty        float64
+		unit       string
+		ingredient string
+		system     units.System
+		wantQty    float64
+		wantUnit   string
+	}{
+		{"dry goods are weighed", 2, "cup", "flour", units.Metric, 251, "gram"},
+		{"liquids stay volumes", 1, "cup", "milk", units.Metric, 237, "milliliter"},
+		{"large volumes use liters", 6, "cup", "water", units.Metric, 1.4, "liter"},
+		{"unknown ingredients stay volumes", 1, "cup", "dashi", units.Metric, 237, "milliliter"},
+		{"pounds to kilograms", 3, "pound", "beef", units.Metric, 1.4, "kilogram"},
+		{"spoons are universal", 1, "tablespoon", "sugar", units.Metric, 1, "tablespoon"},
+		{"metric already", 200, "gram", "flour", units.Metric, 200, "gram"},
+		{"grams to ounces", 200, "gram", "chicken", units.Imperial, 7, "ounce"},
+		{"grams to pounds", 1000, "gram", "potatoes", units.Imperial, 2.25, "pound"},
+		{"small weights to spoons", 6, "gram", "salt", units.Imperial, 1, "teaspoon"},
+		{"milliliters to cups", 250, "ml", "milk", units.Imperial, 1, "cup"},
+		{"milliliters to tablespoons", 30, "ml", "olive oil", units.Imperial, 2, "tablespoon"},
+		{"thirds survive", 80, "ml", "water", units.Imperial, 1.0 / 3, "cup"},
+		{"original leaves it alone", 250, "ml", "milk", units.Original, 250, "ml"},
+		{"count units", 2, "clove", "garlic", units.Metric, 2, "clove"},
+	}
+	for _, tc := range cases {
+		qty, unit := units.ToSystem(tc.qty, tc.unit, tc.ingredient, tc.system)
+		assert.Equal(t, tc.wantUnit, unit, tc.name)
+		assert.InDelta(t, tc.wantQty, qty, 1e-9, tc.name)
+	}
+}
+
+func TestParseSystem(t *testing.T) {
+	for in, want := range map[string]units.System{"": units.Original, "Metric": units.Metric, "US": units.Imperial, "imperial": units.Imperial} {
+		got, err := units.ParseSystem(in)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := units.ParseSystem("cubits")
+	assert.ErrorIs(t, err, units.ErrUnknownSystem)
+}
@@ -9,6 +9,8 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -25,6 +27,8 @@ const (
 type GetRecipeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RecipeId      string                 `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Servings      int32                  `protobuf:"varint,2,opt,name=servings,proto3" json:"servings,omitempty"`                      // Optional: scale ingredient quantities to this many servings.
+	UnitSystem    string                 `protobuf:"bytes,3,opt,name=unit_system,json=unitSystem,proto3" json:"unit_system,omitempty"` // Optional: "metric" or "imperial" to convert ingredient units.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,37 +70,981 @@ func (x *GetRecipeRequest) GetRecipeId() string {
 	return ""
 }
 
+func (x *GetRecipeRequest) GetServings() int32 {
+	if x != nil {
+		return x.Servings
+	}
+	return 0
+}
+
+func (x *GetRecipeRequest) GetUnitSystem() string {
+	if x != nil {
+		return x.UnitSystem
+	}
+	return ""
+}
+
+// NutritionalInfo holds the nutritional values of a recipe, per serving unless
+// stated otherwise.
+type NutritionalInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calories      float64                `protobuf:"fixed64,1,opt,name=calories,proto3" json:"calories,omitempty"`
+	Protein       float64                `protobuf:"fixed64,2,opt,name=protein,proto3" json:"protein,omitempty"`
+	Carbohydrates float64                `protobuf:"fixed64,3,opt,name=carbohydrates,proto3" json:"carbohydrates,omitempty"`
+	Fat           float64                `protobuf:"fixed64,4,opt,name=fat,proto3" json:"fat,omitempty"`
+	Fiber         float64                `protobuf:"fixed64,5,opt,name=fiber,proto3" json:"fiber,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NutritionalInfo) Reset() {
+	*x = NutritionalInfo{}
+	mi := &file_recipe_recipe_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NutritionalInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NutritionalInfo) ProtoMessage() {}
+
+func (x *NutritionalInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NutritionalInfo.ProtoReflect.Descriptor instead.
+func (*NutritionalInfo) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NutritionalInfo) GetCalories() float64 {
+	if x != nil {
+		return x.Calories
+	}
+	return 0
+}
+
+func (x *NutritionalInfo) GetProtein() float64 {
+	if x != nil {
+		return x.Protein
+	}
+	return 0
+}
+
+func (x *NutritionalInfo) GetCarbohydrates() float64 {
+	if x != nil {
+		return x.Carbohydrates
+	}
+	return 0
+}
+
+func (x *NutritionalInfo) GetFat() float64 {
+	if x != nil {
+		return x.Fat
+	}
+	return 0
+}
+
+func (x *NutritionalInfo) GetFiber() float64 {
+	if x != nil {
+		return x.Fiber
+	}
+	return 0
+}
+
+// RatingSummary aggregates the star ratings of a recipe's reviews.
+type RatingSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Average       float64                `protobuf:"fixed64,1,opt,name=average,proto3" json:"average,omitempty"`           // 0 when unrated.
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`                // Number of reviews.
+	Histogram     []int32                `protobuf:"varint,3,rep,packed,name=histogram,proto3" json:"histogram,omitempty"` // Reviews giving 1 to 5 stars, in that order.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingSummary) Reset() {
+	*x = RatingSummary{}
+	mi := &file_recipe_recipe_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummary) ProtoMessage() {}
+
+func (x *RatingSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSummary.ProtoReflect.Descriptor instead.
+func (*RatingSummary) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RatingSummary) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetHistogram() []int32 {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+// ImageVariant is a resized copy of a recipe image.
+type ImageVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Size name, e.g. "small".
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageVariant) Reset() {
+	*x = ImageVariant{}
+	mi := &file_recipe_recipe_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageVariant) ProtoMessage() {}
+
+func (x *ImageVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageVariant.ProtoReflect.Descriptor instead.
+func (*ImageVariant) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ImageVariant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImageVariant) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageVariant) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ImageVariant) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// RecipeImage is a photo attached to a recipe.
+type RecipeImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"` // Full-size image.
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Width         int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Thumbnails    []*ImageVariant        `protobuf:"bytes,6,rep,name=thumbnails,proto3" json:"thumbnails,omitempty"`       // Smallest first.
+	UserId        string                 `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID of the user who uploaded it.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeImage) Reset() {
+	*x = RecipeImage{}
+	mi := &file_recipe_recipe_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeImage) ProtoMessage() {}
+
+func (x *RecipeImage) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeImage.ProtoReflect.Descriptor instead.
+func (*RecipeImage) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecipeImage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RecipeImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *RecipeImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *RecipeImage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RecipeImage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RecipeImage) GetThumbnails() []*ImageVariant {
+	if x != nil {
+		return x.Thumbnails
+	}
+	return nil
+}
+
+func (x *RecipeImage) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecipeImage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 // GetRecipeResponse returns the full details of a recipe.
 type GetRecipeResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	RecipeId          string                 `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
-	Title             string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Ingredients       string                 `protobuf:"bytes,3,opt,name=ingredients,proto3" json:"ingredients,omitempty"`
-	Steps             string                 `protobuf:"bytes,4,opt,name=steps,proto3" json:"steps,omitempty"`
-	NutritionalInfo   string                 `protobuf:"bytes,5,opt,name=nutritional_info,json=nutritionalInfo,proto3" json:"nutritional_info,omitempty"`
-	AllergyDisclaimer string                 `protobuf:"bytes,6,opt,name=allergy_disclaimer,json=allergyDisclaimer,proto3" json:"allergy_disclaimer,omitempty"`
-	Appliances        string                 `protobuf:"bytes,7,opt,name=appliances,proto3" json:"appliances,omitempty"`
-	CreatedAt         int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         int64                  `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId             string                 `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Title                string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Ingredients          []string               `protobuf:"bytes,3,rep,name=ingredients,proto3" json:"ingredients,omitempty"` // One entry per ingredient line.
+	Steps                []string               `protobuf:"bytes,4,rep,name=steps,proto3" json:"steps,omitempty"`             // Ordered preparation steps.
+	AllergyDisclaimer    string                 `protobuf:"bytes,6,opt,name=allergy_disclaimer,json=allergyDisclaimer,proto3" json:"allergy_disclaimer,omitempty"`
+	Appliances           []string               `protobuf:"bytes,7,rep,name=appliances,proto3" json:"appliances,omitempty"`
+	NutritionalInfo      *NutritionalInfo       `protobuf:"bytes,10,opt,name=nutritional_info,json=nutritionalInfo,proto3" json:"nutritional_info,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UserId               string                 `protobuf:"bytes,13,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                             // ID of the user who owns the recipe.
+	Servings             int32                  `protobuf:"varint,14,opt,name=servings,proto3" json:"servings,omitempty"`                                                      // Number of servings the recipe yields; 0 if unknown.
+	TotalNutritionalInfo *NutritionalInfo       `protobuf:"bytes,15,opt,name=total_nutritional_info,json=totalNutritionalInfo,proto3" json:"total_nutritional_info,omitempty"` // Whole-recipe totals; set on scaled responses.
+	ParentRecipeId       string                 `protobuf:"bytes,16,opt,name=parent_recipe_id,json=parentRecipeId,proto3" json:"parent_recipe_id,omitempty"`                   // Recipe this one was forked from, if any.
+	OriginalUserId       string                 `protobuf:"bytes,17,opt,name=original_user_id,json=originalUserId,proto3" json:"original_user_id,omitempty"`                   // Author of the first recipe in the fork chain.
+	Tags                 []string               `protobuf:"bytes,18,rep,name=tags,proto3" json:"tags,omitempty"`                                                               // "kind:name" references, e.g. "cuisine:Italian".
+	Allergens            []string               `protobuf:"bytes,19,rep,name=allergens,proto3" json:"allergens,omitempty"`                                                     // Allergen groups detected in the ingredients, e.g. "peanut".
+	Images               []*RecipeImage         `protobuf:"bytes,20,rep,name=images,proto3" json:"images,omitempty"`                                                           // Uploaded photos, oldest first.
+	Visibility           string                 `protobuf:"bytes,21,opt,name=visibility,proto3" json:"visibility,omitempty"`                                                   // "private", "unlisted" or "public".
+	FavoriteCount        int32                  `protobuf:"varint,22,opt,name=favorite_count,json=favoriteCount,proto3" json:"favorite_count,omitempty"`                       // Number of users who favorited the recipe.
+	IsFavorited          bool                   `protobuf:"varint,23,opt,name=is_favorited,json=isFavorited,proto3" json:"is_favorited,omitempty"`                             // Whether the requesting user favorited it.
+	Rating               *RatingSummary         `protobuf:"bytes,24,opt,name=rating,proto3" json:"rating,omitempty"`                                                           // Aggregated review ratings.
+	PrepMinutes          int32                  `protobuf:"varint,25,opt,name=prep_minutes,json=prepMinutes,proto3" json:"prep_minutes,omitempty"`                             // Time of steps that apply no heat.
+	CookMinutes          int32                  `protobuf:"varint,26,opt,name=cook_minutes,json=cookMinutes,proto3" json:"cook_minutes,omitempty"`                             // Time of steps that apply heat.
+	TotalMinutes         int32                  `protobuf:"varint,27,opt,name=total_minutes,json=totalMinutes,proto3" json:"total_minutes,omitempty"`                          // Prep plus cook time; 0 when unknown.
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetRecipeResponse) Reset() {
+	*x = GetRecipeResponse{}
+	mi := &file_recipe_recipe_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecipeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecipeResponse) ProtoMessage() {}
+
+func (x *GetRecipeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecipeResponse.ProtoReflect.Descriptor instead.
+func (*GetRecipeResponse) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetRecipeResponse) GetRecipeId() string {
+	if x != nil {
+		return x.RecipeId
+	}
+	return ""
+}
+
+func (x *GetRecipeResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GetRecipeResponse) GetIngredients() []string {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetSteps() []string {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetAllergyDisclaimer() string {
+	if x != nil {
+		return x.AllergyDisclaimer
+	}
+	return ""
+}
+
+func (x *GetRecipeResponse) GetAppliances() []string {
+	if x != nil {
+		return x.Appliances
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetNutritionalInfo() *NutritionalInfo {
+	if x != nil {
+		return x.NutritionalInfo
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRecipeResponse) GetServings() int32 {
+	if x != nil {
+		return x.Servings
+	}
+	return 0
+}
+
+func (x *GetRecipeResponse) GetTotalNutritionalInfo() *NutritionalInfo {
+	if x != nil {
+		return x.TotalNutritionalInfo
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetParentRecipeId() string {
+	if x != nil {
+		return x.ParentRecipeId
+	}
+	return ""
+}
+
+func (x *GetRecipeResponse) GetOriginalUserId() string {
+	if x != nil {
+		return x.OriginalUserId
+	}
+	return ""
+}
+
+func (x *GetRecipeResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetAllergens() []string {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetImages() []*RecipeImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *GetRecipeResponse) GetFavoriteCount() int32 {
+	if x != nil {
+		return x.FavoriteCount
+	}
+	return 0
+}
+
+func (x *GetRecipeResponse) GetIsFavorited() bool {
+	if x != nil {
+		return x.IsFavorited
+	}
+	return false
+}
+
+func (x *GetRecipeResponse) GetRating() *RatingSummary {
+	if x != nil {
+		return x.Rating
+	}
+	return nil
+}
+
+func (x *GetRecipeResponse) GetPrepMinutes() int32 {
+	if x != nil {
+		return x.PrepMinutes
+	}
+	return 0
+}
+
+func (x *GetRecipeResponse) GetCookMinutes() int32 {
+	if x != nil {
+		return x.CookMinutes
+	}
+	return 0
+}
+
+func (x *GetRecipeResponse) GetTotalMinutes() int32 {
+	if x != nil {
+		return x.TotalMinutes
+	}
+	return 0
+}
+
+// RecipeQueryRequest is used for both advanced search and list operations.
+// An empty "query" field indicates a listing operation, while a non-empty field
+// triggers advanced search logic (e.g., filtering by cuisine, diet, etc.).
+type RecipeQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                                       // Advanced search text (e.g., "vegan"); empty for simple listings.
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                       // Optional: Filter recipes by creator's user ID.
+	Filter        string                 `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`                                     // Optional: Additional filtering criteria (e.g., "Indian").
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`                                        // Optional: Requested page number for pagination.
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                                      // Optional: Number of recipes per page.
+	Filters       *RecipeFilters         `protobuf:"bytes,6,opt,name=filters,proto3" json:"filters,omitempty"`                                   // Optional: Structured filters applied on top of the query.
+	IncludeFacets bool                   `protobuf:"varint,7,opt,name=include_facets,json=includeFacets,proto3" json:"include_facets,omitempty"` // Optional: Return facet counts for all matching recipes.
+	Cursor        string                 `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`                                     // Optional: next_cursor or prev_cursor from a previous response; overrides page.
+	Sort          string                 `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"`                                         // Optional: "newest", "title", "rating" or "relevance" (the default for text queries).
+	TotalMode     string                 `protobuf:"bytes,10,opt,name=total_mode,json=totalMode,proto3" json:"total_mode,omitempty"`             // Optional: "exact" (default), "estimate" or "none".
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeQueryRequest) Reset() {
+	*x = RecipeQueryRequest{}
+	mi := &file_recipe_recipe_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeQueryRequest) ProtoMessage() {}
+
+func (x *RecipeQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeQueryRequest.ProtoReflect.Descriptor instead.
+func (*RecipeQueryRequest) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RecipeQueryRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *RecipeQueryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecipeQueryRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *RecipeQueryRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *RecipeQueryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RecipeQueryRequest) GetFilters() *RecipeFilters {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *RecipeQueryRequest) GetIncludeFacets() bool {
+	if x != nil {
+		return x.IncludeFacets
+	}
+	return false
+}
+
+func (x *RecipeQueryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *RecipeQueryRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *RecipeQueryRequest) GetTotalMode() string {
+	if x != nil {
+		return x.TotalMode
+	}
+	return ""
+}
+
+// NutrientRange bounds a per-serving nutritional value; unset ends are open.
+type NutrientRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           *float64               `protobuf:"fixed64,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *float64               `protobuf:"fixed64,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NutrientRange) Reset() {
+	*x = NutrientRange{}
+	mi := &file_recipe_recipe_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NutrientRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NutrientRange) ProtoMessage() {}
+
+func (x *NutrientRange) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NutrientRange.ProtoReflect.Descriptor instead.
+func (*NutrientRange) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NutrientRange) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *NutrientRange) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+// RecipeFilters restricts query results. Names match case-insensitively.
+type RecipeFilters struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Appliances        []string               `protobuf:"bytes,1,rep,name=appliances,proto3" json:"appliances,omitempty"`                                        // Recipes must use all of these appliances.
+	ExcludeAppliances []string               `protobuf:"bytes,2,rep,name=exclude_appliances,json=excludeAppliances,proto3" json:"exclude_appliances,omitempty"` // Recipes must use none of these appliances.
+	ExcludeAllergens  []string               `protobuf:"bytes,3,rep,name=exclude_allergens,json=excludeAllergens,proto3" json:"exclude_allergens,omitempty"`
+	Calories          *NutrientRange         `protobuf:"bytes,4,opt,name=calories,proto3" json:"calories,omitempty"`
+	Protein           *NutrientRange         `protobuf:"bytes,5,opt,name=protein,proto3" json:"protein,omitempty"`
+	Carbohydrates     *NutrientRange         `protobuf:"bytes,6,opt,name=carbohydrates,proto3" json:"carbohydrates,omitempty"`
+	Fat               *NutrientRange         `protobuf:"bytes,7,opt,name=fat,proto3" json:"fat,omitempty"`
+	Tags              []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"` // Recipes must carry all of these tags.
+	// Time ceilings in minutes; recipes whose time is unknown never match them.
+	MaxPrepMinutes  *int32 `protobuf:"varint,9,opt,name=max_prep_minutes,json=maxPrepMinutes,proto3,oneof" json:"max_prep_minutes,omitempty"`
+	MaxCookMinutes  *int32 `protobuf:"varint,10,opt,name=max_cook_minutes,json=maxCookMinutes,proto3,oneof" json:"max_cook_minutes,omitempty"`
+	MaxTotalMinutes *int32 `protobuf:"varint,11,opt,name=max_total_minutes,json=maxTotalMinutes,proto3,oneof" json:"max_total_minutes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RecipeFilters) Reset() {
+	*x = RecipeFilters{}
+	mi := &file_recipe_recipe_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeFilters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeFilters) ProtoMessage() {}
+
+func (x *RecipeFilters) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeFilters.ProtoReflect.Descriptor instead.
+func (*RecipeFilters) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RecipeFilters) GetAppliances() []string {
+	if x != nil {
+		return x.Appliances
+	}
+	return nil
+}
+
+func (x *RecipeFilters) GetExcludeAppliances() []string {
+	if x != nil {
+		return x.ExcludeAppliances
+	}
+	return nil
+}
+
+func (x *RecipeFilters) GetExcludeAllergens() []string {
+	if x != nil {
+		return x.ExcludeAllergens
+	}
+	return nil
+}
+
+func (x *RecipeFilters) GetCalories() *NutrientRange {
+	if x != nil {
+		return x.Calories
+	}
+	return nil
+}
+
+func (x *RecipeFilters) GetProtein() *NutrientRange {
+	if x != nil {
+		return x.Protein
+	}
+	return nil
+}
+
+func (x *RecipeFilters) GetCarbohydrates() *NutrientRange {
+	if x != nil {
+		return x.Carbohydrates
+	}
+	return nil
+}
+
+func (x *RecipeFilters) GetFat() *NutrientRange {
+	if x != nil {
+		return x.Fat
+	}
+	return nil
+}
+
+func (x *RecipeFilters) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RecipeFilters) GetMaxPrepMinutes() int32 {
+	if x != nil && x.MaxPrepMinutes != nil {
+		return *x.MaxPrepMinutes
+	}
+	return 0
+}
+
+func (x *RecipeFilters) GetMaxCookMinutes() int32 {
+	if x != nil && x.MaxCookMinutes != nil {
+		return *x.MaxCookMinutes
+	}
+	return 0
+}
+
+func (x *RecipeFilters) GetMaxTotalMinutes() int32 {
+	if x != nil && x.MaxTotalMinutes != nil {
+		return *x.MaxTotalMinutes
+	}
+	return 0
+}
+
+// RecipeQueryResponse returns the results for a query along with pagination details.
+type RecipeQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipes       []*GetRecipeResponse   `protobuf:"bytes,1,rep,name=recipes,proto3" json:"recipes,omitempty"`                         // List of recipes matching the query.
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`                              // Echoed page number.
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                            // Echoed limit per page.
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`                            // Total number of matching recipes.
+	Facets        *RecipeFacets          `protobuf:"bytes,5,opt,name=facets,proto3" json:"facets,omitempty"`                           // Set when include_facets was requested.
+	NextCursor    string                 `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Cursor for the following page; empty on the last page.
+	PrevCursor    string                 `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"` // Cursor for the preceding page; empty on the first page.
+	TotalMode     string                 `protobuf:"bytes,8,opt,name=total_mode,json=totalMode,proto3" json:"total_mode,omitempty"`    // How total was computed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeQueryResponse) Reset() {
+	*x = RecipeQueryResponse{}
+	mi := &file_recipe_recipe_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeQueryResponse) ProtoMessage() {}
+
+func (x *RecipeQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeQueryResponse.ProtoReflect.Descriptor instead.
+func (*RecipeQueryResponse) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecipeQueryResponse) GetRecipes() []*GetRecipeResponse {
+	if x != nil {
+		return x.Recipes
+	}
+	return nil
 }
 
-func (x *GetRecipeResponse) Reset() {
-	*x = GetRecipeResponse{}
-	mi := &file_recipe_recipe_proto_msgTypes[1]
+func (x *RecipeQueryResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *RecipeQueryResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RecipeQueryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *RecipeQueryResponse) GetFacets() *RecipeFacets {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *RecipeQueryResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *RecipeQueryResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *RecipeQueryResponse) GetTotalMode() string {
+	if x != nil {
+		return x.TotalMode
+	}
+	return ""
+}
+
+// RecipeFacets summarises all recipes matching a query.
+type RecipeFacets struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appliances    map[string]int32       `protobuf:"bytes,1,rep,name=appliances,proto3" json:"appliances,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Lower-cased appliance name to number of recipes.
+	Calories      []*RangeFacet          `protobuf:"bytes,2,rep,name=calories,proto3" json:"calories,omitempty"`                                                                                // Recipes per calorie band.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeFacets) Reset() {
+	*x = RecipeFacets{}
+	mi := &file_recipe_recipe_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRecipeResponse) String() string {
+func (x *RecipeFacets) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRecipeResponse) ProtoMessage() {}
+func (*RecipeFacets) ProtoMessage() {}
 
-func (x *GetRecipeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_recipe_recipe_proto_msgTypes[1]
+func (x *RecipeFacets) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,103 +1055,226 @@ func (x *GetRecipeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRecipeResponse.ProtoReflect.Descriptor instead.
-func (*GetRecipeResponse) Descriptor() ([]byte, []int) {
-	return file_recipe_recipe_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use RecipeFacets.ProtoReflect.Descriptor instead.
+func (*RecipeFacets) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetRecipeResponse) GetRecipeId() string {
+func (x *RecipeFacets) GetAppliances() map[string]int32 {
 	if x != nil {
-		return x.RecipeId
+		return x.Appliances
+	}
+	return nil
+}
+
+func (x *RecipeFacets) GetCalories() []*RangeFacet {
+	if x != nil {
+		return x.Calories
+	}
+	return nil
+}
+
+// RangeFacet counts the recipes whose value falls in [min, max).
+type RangeFacet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Min           *float64               `protobuf:"fixed64,2,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *float64               `protobuf:"fixed64,3,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RangeFacet) Reset() {
+	*x = RangeFacet{}
+	mi := &file_recipe_recipe_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RangeFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeFacet) ProtoMessage() {}
+
+func (x *RangeFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeFacet.ProtoReflect.Descriptor instead.
+func (*RangeFacet) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RangeFacet) GetLabel() string {
+	if x != nil {
+		return x.Label
 	}
 	return ""
 }
 
-func (x *GetRecipeResponse) GetTitle() string {
+func (x *RangeFacet) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *RangeFacet) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *RangeFacet) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// RecipeInput holds the user-editable fields of a recipe.
+type RecipeInput struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Title             string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Ingredients       []string               `protobuf:"bytes,2,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	Steps             []string               `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	NutritionalInfo   *NutritionalInfo       `protobuf:"bytes,4,opt,name=nutritional_info,json=nutritionalInfo,proto3" json:"nutritional_info,omitempty"`
+	AllergyDisclaimer string                 `protobuf:"bytes,5,opt,name=allergy_disclaimer,json=allergyDisclaimer,proto3" json:"allergy_disclaimer,omitempty"`
+	Appliances        []string               `protobuf:"bytes,6,rep,name=appliances,proto3" json:"appliances,omitempty"`
+	Servings          int32                  `protobuf:"varint,7,opt,name=servings,proto3" json:"servings,omitempty"`
+	Tags              []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`             // "kind:name" references, e.g. "cuisine:Italian".
+	Visibility        string                 `protobuf:"bytes,9,opt,name=visibility,proto3" json:"visibility,omitempty"` // "private", "unlisted" or "public"; empty keeps the current value.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RecipeInput) Reset() {
+	*x = RecipeInput{}
+	mi := &file_recipe_recipe_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeInput) ProtoMessage() {}
+
+func (x *RecipeInput) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeInput.ProtoReflect.Descriptor instead.
+func (*RecipeInput) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RecipeInput) GetTitle() string {
 	if x != nil {
 		return x.Title
 	}
 	return ""
 }
 
-func (x *GetRecipeResponse) GetIngredients() string {
+func (x *RecipeInput) GetIngredients() []string {
 	if x != nil {
 		return x.Ingredients
 	}
-	return ""
+	return nil
 }
 
-func (x *GetRecipeResponse) GetSteps() string {
+func (x *RecipeInput) GetSteps() []string {
 	if x != nil {
 		return x.Steps
 	}
-	return ""
+	return nil
 }
 
-func (x *GetRecipeResponse) GetNutritionalInfo() string {
+func (x *RecipeInput) GetNutritionalInfo() *NutritionalInfo {
 	if x != nil {
 		return x.NutritionalInfo
 	}
-	return ""
+	return nil
 }
 
-func (x *GetRecipeResponse) GetAllergyDisclaimer() string {
+func (x *RecipeInput) GetAllergyDisclaimer() string {
 	if x != nil {
 		return x.AllergyDisclaimer
 	}
 	return ""
 }
 
-func (x *GetRecipeResponse) GetAppliances() string {
+func (x *RecipeInput) GetAppliances() []string {
 	if x != nil {
 		return x.Appliances
 	}
-	return ""
+	return nil
 }
 
-func (x *GetRecipeResponse) GetCreatedAt() int64 {
+func (x *RecipeInput) GetServings() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Servings
 	}
 	return 0
 }
 
-func (x *GetRecipeResponse) GetUpdatedAt() int64 {
+func (x *RecipeInput) GetTags() []string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Tags
 	}
-	return 0
+	return nil
 }
 
-// RecipeQueryRequest is used for both advanced search and list operations.
-// An empty "query" field indicates a listing operation, while a non-empty field
-// triggers advanced search logic (e.g., filtering by cuisine, diet, etc.).
-type RecipeQueryRequest struct {
+func (x *RecipeInput) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+// CreateRecipeRequest carries the recipe to create.
+type CreateRecipeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                 // Advanced search text (e.g., "vegan"); empty for simple listings.
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Optional: Filter recipes by creator's user ID.
-	Filter        string                 `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`               // Optional: Additional filtering criteria (e.g., "Indian").
-	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`                  // Optional: Requested page number for pagination.
-	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                // Optional: Number of recipes per page.
+	Recipe        *RecipeInput           `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecipeQueryRequest) Reset() {
-	*x = RecipeQueryRequest{}
-	mi := &file_recipe_recipe_proto_msgTypes[2]
+func (x *CreateRecipeRequest) Reset() {
+	*x = CreateRecipeRequest{}
+	mi := &file_recipe_recipe_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecipeQueryRequest) String() string {
+func (x *CreateRecipeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecipeQueryRequest) ProtoMessage() {}
+func (*CreateRecipeRequest) ProtoMessage() {}
 
-func (x *RecipeQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_recipe_recipe_proto_msgTypes[2]
+func (x *CreateRecipeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -214,72 +1285,103 @@ func (x *RecipeQueryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecipeQueryRequest.ProtoReflect.Descriptor instead.
-func (*RecipeQueryRequest) Descriptor() ([]byte, []int) {
-	return file_recipe_recipe_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use CreateRecipeRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecipeRequest) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *RecipeQueryRequest) GetQuery() string {
+func (x *CreateRecipeRequest) GetRecipe() *RecipeInput {
 	if x != nil {
-		return x.Query
+		return x.Recipe
 	}
-	return ""
+	return nil
 }
 
-func (x *RecipeQueryRequest) GetUserId() string {
+// UpdateRecipeRequest carries the new field values for an existing recipe.
+type UpdateRecipeRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId string                 `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Recipe   *RecipeInput           `protobuf:"bytes,2,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	// Paths of RecipeInput fields to update (e.g., "title", "steps").
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRecipeRequest) Reset() {
+	*x = UpdateRecipeRequest{}
+	mi := &file_recipe_recipe_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRecipeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRecipeRequest) ProtoMessage() {}
+
+func (x *UpdateRecipeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[14]
 	if x != nil {
-		return x.UserId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *RecipeQueryRequest) GetFilter() string {
+// Deprecated: Use UpdateRecipeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRecipeRequest) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateRecipeRequest) GetRecipeId() string {
 	if x != nil {
-		return x.Filter
+		return x.RecipeId
 	}
 	return ""
 }
 
-func (x *RecipeQueryRequest) GetPage() int32 {
+func (x *UpdateRecipeRequest) GetRecipe() *RecipeInput {
 	if x != nil {
-		return x.Page
+		return x.Recipe
 	}
-	return 0
+	return nil
 }
 
-func (x *RecipeQueryRequest) GetLimit() int32 {
+func (x *UpdateRecipeRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	if x != nil {
-		return x.Limit
+		return x.UpdateMask
 	}
-	return 0
+	return nil
 }
 
-// RecipeQueryResponse returns the results for a query along with pagination details.
-type RecipeQueryResponse struct {
+// DeleteRecipeRequest identifies the recipe to delete.
+type DeleteRecipeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Recipes       []*GetRecipeResponse   `protobuf:"bytes,1,rep,name=recipes,proto3" json:"recipes,omitempty"` // List of recipes matching the query.
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`      // Echoed page number.
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`    // Echoed limit per page.
-	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`    // Total number of matching recipes.
+	RecipeId      string                 `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecipeQueryResponse) Reset() {
-	*x = RecipeQueryResponse{}
-	mi := &file_recipe_recipe_proto_msgTypes[3]
+func (x *DeleteRecipeRequest) Reset() {
+	*x = DeleteRecipeRequest{}
+	mi := &file_recipe_recipe_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecipeQueryResponse) String() string {
+func (x *DeleteRecipeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecipeQueryResponse) ProtoMessage() {}
+func (*DeleteRecipeRequest) ProtoMessage() {}
 
-func (x *RecipeQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_recipe_recipe_proto_msgTypes[3]
+func (x *DeleteRecipeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,84 +1392,307 @@ func (x *RecipeQueryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecipeQueryResponse.ProtoReflect.Descriptor instead.
-func (*RecipeQueryResponse) Descriptor() ([]byte, []int) {
-	return file_recipe_recipe_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DeleteRecipeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRecipeRequest) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *RecipeQueryResponse) GetRecipes() []*GetRecipeResponse {
+func (x *DeleteRecipeRequest) GetRecipeId() string {
 	if x != nil {
-		return x.Recipes
+		return x.RecipeId
 	}
-	return nil
+	return ""
 }
 
-func (x *RecipeQueryResponse) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
+// DeleteRecipeResponse is returned after a successful deletion.
+type DeleteRecipeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecipeQueryResponse) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+func (x *DeleteRecipeResponse) Reset() {
+	*x = DeleteRecipeResponse{}
+	mi := &file_recipe_recipe_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecipeQueryResponse) GetTotal() int32 {
+func (x *DeleteRecipeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRecipeResponse) ProtoMessage() {}
+
+func (x *DeleteRecipeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_recipe_recipe_proto_msgTypes[16]
 	if x != nil {
-		return x.Total
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRecipeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRecipeResponse) Descriptor() ([]byte, []int) {
+	return file_recipe_recipe_proto_rawDescGZIP(), []int{16}
 }
 
 var File_recipe_recipe_proto protoreflect.FileDescriptor
 
 var file_recipe_recipe_proto_rawDesc = string([]byte{
 	0x0a, 0x13, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x22, 0x2f, 0x0a,
-	0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x49, 0x64, 0x22, 0xb6,
-	0x02, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x69,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x1a, 0x20, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x6c, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x69,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x49,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x67, 0x72, 0x65,
-	0x64, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e,
-	0x67, 0x72, 0x65, 0x64, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x65,
-	0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12,
-	0x29, 0x0a, 0x10, 0x6e, 0x75, 0x74, 0x72, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69,
-	0x6e, 0x66, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x75, 0x74, 0x72, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x6c,
-	0x6c, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x72,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67, 0x79, 0x44,
-	0x69, 0x73, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70,
-	0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61,
-	0x70, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x85, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x63, 0x69,
-	0x70, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
-	0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71,
-	0x75, 0x65, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
-	0x8a, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x69, 0x70,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70,
-	0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x52, 0x07, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x32, 0x99, 0x01, 0x0a,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x75, 0x6e, 0x69, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x22, 0x95,
+	0x01, 0x0a, 0x0f, 0x4e, 0x75, 0x74, 0x72, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63, 0x61, 0x6c, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x69, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x61, 0x72, 0x62,
+	0x6f, 0x68, 0x79, 0x64, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x63, 0x61, 0x72, 0x62, 0x6f, 0x68, 0x79, 0x64, 0x72, 0x61, 0x74, 0x65, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x66, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x66, 0x61, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x66, 0x69, 0x62, 0x65, 0x72, 0x22, 0x5d, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f,
+	0x67, 0x72, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x22, 0x62, 0x0a, 0x0c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56, 0x61,
+	0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x8a, 0x02, 0x0a, 0x0b, 0x52, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x34, 0x0a, 0x0a,
+	0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x52, 0x0a, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69,
+	0x6c, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xd4, 0x07, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x64, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x64, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x6c, 0x6c, 0x65, 0x72,
+	0x67, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67, 0x79, 0x44, 0x69, 0x73, 0x63,
+	0x6c, 0x61, 0x69, 0x6d, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x10, 0x6e, 0x75, 0x74, 0x72, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x4e, 0x75, 0x74, 0x72, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0f, 0x6e, 0x75, 0x74, 0x72, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x4d, 0x0a, 0x16, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6e,
+	0x75, 0x74, 0x72, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x4e,
+	0x75, 0x74, 0x72, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x14,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x75, 0x74, 0x72, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x49, 0x64, 0x12, 0x28,
+	0x0a, 0x10, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x65, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x73,
+	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x76, 0x6f, 0x72,
+	0x69, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x64, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65,
+	0x64, 0x12, 0x2d, 0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x18, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x70, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x19, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x70, 0x4d, 0x69, 0x6e, 0x75,
+	0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6f, 0x6b, 0x5f, 0x6d, 0x69, 0x6e, 0x75,
+	0x74, 0x65, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6f, 0x6b, 0x4d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x4a, 0x04, 0x08, 0x05, 0x10,
+	0x06, 0x4a, 0x04, 0x08, 0x08, 0x10, 0x09, 0x4a, 0x04, 0x08, 0x09, 0x10, 0x0a, 0x22, 0xa8, 0x02,
+	0x0a, 0x12, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x07, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x5f, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x4d, 0x0a, 0x0d, 0x4e, 0x75, 0x74, 0x72,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01,
+	0x12, 0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52,
+	0x03, 0x6d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e, 0x42,
+	0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x61, 0x78, 0x22, 0xb8, 0x04, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x69,
+	0x70, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x70,
+	0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x6c, 0x6c, 0x65,
+	0x72, 0x67, 0x65, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65,
+	0x2e, 0x4e, 0x75, 0x74, 0x72, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x08,
+	0x63, 0x61, 0x6c, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x74,
+	0x65, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x65, 0x2e, 0x4e, 0x75, 0x74, 0x72, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x07, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x69, 0x6e, 0x12, 0x3b, 0x0a, 0x0d, 0x63, 0x61, 0x72,
+	0x62, 0x6f, 0x68, 0x79, 0x64, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x4e, 0x75, 0x74, 0x72, 0x69, 0x65,
+	0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0d, 0x63, 0x61, 0x72, 0x62, 0x6f, 0x68, 0x79,
+	0x64, 0x72, 0x61, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x03, 0x66, 0x61, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x4e, 0x75, 0x74,
+	0x72, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x03, 0x66, 0x61, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x61, 0x67, 0x73, 0x12, 0x2d, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x5f,
+	0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52,
+	0x0e, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x65, 0x70, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x88,
+	0x01, 0x01, 0x12, 0x2d, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6f, 0x6b, 0x5f, 0x6d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x0e,
+	0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6f, 0x6b, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x2f, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52, 0x0f,
+	0x6d, 0x61, 0x78, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x88,
+	0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x5f,
+	0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x63, 0x6f, 0x6f, 0x6b, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x42, 0x14, 0x0a, 0x12,
+	0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x73, 0x22, 0x99, 0x02, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x2c, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x46,
+	0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x76, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12,
+	0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0xc3,
+	0x01, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12,
+	0x44, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x52, 0x65, 0x63,
+	0x69, 0x70, 0x65, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65,
+	0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x61, 0x63, 0x65, 0x74, 0x52, 0x08, 0x63, 0x61, 0x6c,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x76, 0x0a, 0x0a, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x61, 0x63,
+	0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12,
+	0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x03,
+	0x6d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x06, 0x0a, 0x04,
+	0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x61, 0x78, 0x22, 0xbe, 0x02, 0x0a,
+	0x0b, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x64, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x64, 0x69,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x42, 0x0a, 0x10, 0x6e, 0x75,
+	0x74, 0x72, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x4e, 0x75,
+	0x74, 0x72, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0f, 0x6e,
+	0x75, 0x74, 0x72, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2d,
+	0x0a, 0x12, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x67, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6c, 0x61,
+	0x69, 0x6d, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x65,
+	0x72, 0x67, 0x79, 0x44, 0x69, 0x73, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x72, 0x12, 0x1e, 0x0a,
+	0x0a, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x42, 0x0a,
+	0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x52, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x65, 0x22, 0x9c, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x69,
+	0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61,
+	0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b,
+	0x22, 0x32, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x65, 0x49, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xf4, 0x02, 0x0a,
 	0x0d, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x40,
 	0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x18, 0x2e, 0x72, 0x65,
 	0x63, 0x69, 0x70, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65,
@@ -377,12 +1702,25 @@ var file_recipe_recipe_proto_rawDesc = string([]byte{
 	0x1a, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x51,
 	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x65,
 	0x63, 0x69, 0x70, 0x65, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x61, 0x67, 0x65, 0x7a, 0x61, 0x2f, 0x72, 0x65,
-	0x63, 0x69, 0x70, 0x65, 0x2d, 0x62, 0x6f, 0x6f, 0x6b, 0x2d, 0x61, 0x70, 0x69, 0x2d, 0x76, 0x32,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x63,
-	0x69, 0x70, 0x65, 0x3b, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x46, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65,
+	0x12, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x70, 0x61, 0x67, 0x65, 0x7a, 0x61, 0x2f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x2d,
+	0x62, 0x6f, 0x6f, 0x6b, 0x2d, 0x61, 0x70, 0x69, 0x2d, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x3b, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -397,24 +1735,66 @@ func file_recipe_recipe_proto_rawDescGZIP() []byte {
 	return file_recipe_recipe_proto_rawDescData
 }
 
-var file_recipe_recipe_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_recipe_recipe_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_recipe_recipe_proto_goTypes = []any{
-	(*GetRecipeRequest)(nil),    // 0: recipe.GetRecipeRequest
-	(*GetRecipeResponse)(nil),   // 1: recipe.GetRecipeResponse
-	(*RecipeQueryRequest)(nil),  // 2: recipe.RecipeQueryRequest
-	(*RecipeQueryResponse)(nil), // 3: recipe.RecipeQueryResponse
+	(*GetRecipeRequest)(nil),      // 0: recipe.GetRecipeRequest
+	(*NutritionalInfo)(nil),       // 1: recipe.NutritionalInfo
+	(*RatingSummary)(nil),         // 2: recipe.RatingSummary
+	(*ImageVariant)(nil),          // 3: recipe.ImageVariant
+	(*RecipeImage)(nil),           // 4: recipe.RecipeImage
+	(*GetRecipeResponse)(nil),     // 5: recipe.GetRecipeResponse
+	(*RecipeQueryRequest)(nil),    // 6: recipe.RecipeQueryRequest
+	(*NutrientRange)(nil),         // 7: recipe.NutrientRange
+	(*RecipeFilters)(nil),         // 8: recipe.RecipeFilters
+	(*RecipeQueryResponse)(nil),   // 9: recipe.RecipeQueryResponse
+	(*RecipeFacets)(nil),          // 10: recipe.RecipeFacets
+	(*RangeFacet)(nil),            // 11: recipe.RangeFacet
+	(*RecipeInput)(nil),           // 12: recipe.RecipeInput
+	(*CreateRecipeRequest)(nil),   // 13: recipe.CreateRecipeRequest
+	(*UpdateRecipeRequest)(nil),   // 14: recipe.UpdateRecipeRequest
+	(*DeleteRecipeRequest)(nil),   // 15: recipe.DeleteRecipeRequest
+	(*DeleteRecipeResponse)(nil),  // 16: recipe.DeleteRecipeResponse
+	nil,                           // 17: recipe.RecipeFacets.AppliancesEntry
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 19: google.protobuf.FieldMask
 }
 var file_recipe_recipe_proto_depIdxs = []int32{
-	1, // 0: recipe.RecipeQueryResponse.recipes:type_name -> recipe.GetRecipeResponse
-	0, // 1: recipe.RecipeService.GetRecipe:input_type -> recipe.GetRecipeRequest
-	2, // 2: recipe.RecipeService.QueryRecipe:input_type -> recipe.RecipeQueryRequest
-	1, // 3: recipe.RecipeService.GetRecipe:output_type -> recipe.GetRecipeResponse
-	3, // 4: recipe.RecipeService.QueryRecipe:output_type -> recipe.RecipeQueryResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3,  // 0: recipe.RecipeImage.thumbnails:type_name -> recipe.ImageVariant
+	18, // 1: recipe.RecipeImage.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: recipe.GetRecipeResponse.nutritional_info:type_name -> recipe.NutritionalInfo
+	18, // 3: recipe.GetRecipeResponse.created_at:type_name -> google.protobuf.Timestamp
+	18, // 4: recipe.GetRecipeResponse.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: recipe.GetRecipeResponse.total_nutritional_info:type_name -> recipe.NutritionalInfo
+	4,  // 6: recipe.GetRecipeResponse.images:type_name -> recipe.RecipeImage
+	2,  // 7: recipe.GetRecipeResponse.rating:type_name -> recipe.RatingSummary
+	8,  // 8: recipe.RecipeQueryRequest.filters:type_name -> recipe.RecipeFilters
+	7,  // 9: recipe.RecipeFilters.calories:type_name -> recipe.NutrientRange
+	7,  // 10: recipe.RecipeFilters.protein:type_name -> recipe.NutrientRange
+	7,  // 11: recipe.RecipeFilters.carbohydrates:type_name -> recipe.NutrientRange
+	7,  // 12: recipe.RecipeFilters.fat:type_name -> recipe.NutrientRange
+	5,  // 13: recipe.RecipeQueryResponse.recipes:type_name -> recipe.GetRecipeResponse
+	10, // 14: recipe.RecipeQueryResponse.facets:type_name -> recipe.RecipeFacets
+	17, // 15: recipe.RecipeFacets.appliances:type_name -> recipe.RecipeFacets.AppliancesEntry
+	11, // 16: recipe.RecipeFacets.calories:type_name -> recipe.RangeFacet
+	1,  // 17: recipe.RecipeInput.nutritional_info:type_name -> recipe.NutritionalInfo
+	12, // 18: recipe.CreateRecipeRequest.recipe:type_name -> recipe.RecipeInput
+	12, // 19: recipe.UpdateRecipeRequest.recipe:type_name -> recipe.RecipeInput
+	19, // 20: recipe.UpdateRecipeRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 21: recipe.RecipeService.GetRecipe:input_type -> recipe.GetRecipeRequest
+	6,  // 22: recipe.RecipeService.QueryRecipe:input_type -> recipe.RecipeQueryRequest
+	13, // 23: recipe.RecipeService.CreateRecipe:input_type -> recipe.CreateRecipeRequest
+	14, // 24: recipe.RecipeService.UpdateRecipe:input_type -> recipe.UpdateRecipeRequest
+	15, // 25: recipe.RecipeService.DeleteRecipe:input_type -> recipe.DeleteRecipeRequest
+	5,  // 26: recipe.RecipeService.GetRecipe:output_type -> recipe.GetRecipeResponse
+	9,  // 27: recipe.RecipeService.QueryRecipe:output_type -> recipe.RecipeQueryResponse
+	5,  // 28: recipe.RecipeService.CreateRecipe:output_type -> recipe.GetRecipeResponse
+	5,  // 29: recipe.RecipeService.UpdateRecipe:output_type -> recipe.GetRecipeResponse
+	16, // 30: recipe.RecipeService.DeleteRecipe:output_type -> recipe.DeleteRecipeResponse
+	26, // [26:31] is the sub-list for method output_type
+	21, // [21:26] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_recipe_recipe_proto_init() }
@@ -422,13 +1802,16 @@ func file_recipe_recipe_proto_init() {
 	if File_recipe_recipe_proto != nil {
 		return
 	}
+	file_recipe_recipe_proto_msgTypes[7].OneofWrappers = []any{}
+	file_recipe_recipe_proto_msgTypes[8].OneofWrappers = []any{}
+	file_recipe_recipe_proto_msgTypes[11].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recipe_recipe_proto_rawDesc), len(file_recipe_recipe_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RecipeService_GetRecipe_FullMethodName   = "/recipe.RecipeService/GetRecipe"
-	RecipeService_QueryRecipe_FullMethodName = "/recipe.RecipeService/QueryRecipe"
+	RecipeService_GetRecipe_FullMethodName    = "/recipe.RecipeService/GetRecipe"
+	RecipeService_QueryRecipe_FullMethodName  = "/recipe.RecipeService/QueryRecipe"
+	RecipeService_CreateRecipe_FullMethodName = "/recipe.RecipeService/CreateRecipe"
+	RecipeService_UpdateRecipe_FullMethodName = "/recipe.RecipeService/UpdateRecipe"
+	RecipeService_DeleteRecipe_FullMethodName = "/recipe.RecipeService/DeleteRecipe"
 )
 
 // RecipeServiceClient is the client API for RecipeService service.
@@ -35,6 +38,13 @@ type RecipeServiceClient interface {
 	//   - Listing recipes (if the "query" field is empty) filtered by user_id and/or filter.
 	//   - Advanced searches when the "query" field is non-empty (e.g., by cuisine, diet, ingredients).
 	QueryRecipe(ctx context.Context, in *RecipeQueryRequest, opts ...grpc.CallOption) (*RecipeQueryResponse, error)
+	// CreateRecipe stores a new recipe owned by the acting user.
+	CreateRecipe(ctx context.Context, in *CreateRecipeRequest, opts ...grpc.CallOption) (*GetRecipeResponse, error)
+	// UpdateRecipe modifies a recipe owned by the acting user. Only the fields
+	// listed in update_mask are changed; an empty mask replaces every field.
+	UpdateRecipe(ctx context.Context, in *UpdateRecipeRequest, opts ...grpc.CallOption) (*GetRecipeResponse, error)
+	// DeleteRecipe moves a recipe owned by the acting user to their trash.
+	DeleteRecipe(ctx context.Context, in *DeleteRecipeRequest, opts ...grpc.CallOption) (*DeleteRecipeResponse, error)
 }
 
 type recipeServiceClient struct {
@@ -65,6 +75,36 @@ func (c *recipeServiceClient) QueryRecipe(ctx context.Context, in *RecipeQueryRe
 	return out, nil
 }
 
+func (c *recipeServiceClient) CreateRecipe(ctx context.Context, in *CreateRecipeRequest, opts ...grpc.CallOption) (*GetRecipeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecipeResponse)
+	err := c.cc.Invoke(ctx, RecipeService_CreateRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) UpdateRecipe(ctx context.Context, in *UpdateRecipeRequest, opts ...grpc.CallOption) (*GetRecipeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecipeResponse)
+	err := c.cc.Invoke(ctx, RecipeService_UpdateRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) DeleteRecipe(ctx context.Context, in *DeleteRecipeRequest, opts ...grpc.CallOption) (*DeleteRecipeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRecipeResponse)
+	err := c.cc.Invoke(ctx, RecipeService_DeleteRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RecipeServiceServer is the server API for RecipeService service.
 // All implementations must embed UnimplementedRecipeServiceServer
 // for forward compatibility.
@@ -77,6 +117,13 @@ type RecipeServiceServer interface {
 	//   - Listing recipes (if the "query" field is empty) filtered by user_id and/or filter.
 	//   - Advanced searches when the "query" field is non-empty (e.g., by cuisine, diet, ingredients).
 	QueryRecipe(context.Context, *RecipeQueryRequest) (*RecipeQueryResponse, error)
+	// CreateRecipe stores a new recipe owned by the acting user.
+	CreateRecipe(context.Context, *CreateRecipeRequest) (*GetRecipeResponse, error)
+	// UpdateRecipe modifies a recipe owned by the acting user. Only the fields
+	// listed in update_mask are changed; an empty mask replaces every field.
+	UpdateRecipe(context.Context, *UpdateRecipeRequest) (*GetRecipeResponse, error)
+	// DeleteRecipe moves a recipe owned by the acting user to their trash.
+	DeleteRecipe(context.Context, *DeleteRecipeRequest) (*DeleteRecipeResponse, error)
 	mustEmbedUnimplementedRecipeServiceServer()
 }
 
@@ -93,6 +140,15 @@ func (UnimplementedRecipeServiceServer) GetRecipe(context.Context, *GetRecipeReq
 func (UnimplementedRecipeServiceServer) QueryRecipe(context.Context, *RecipeQueryRequest) (*RecipeQueryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryRecipe not implemented")
 }
+func (UnimplementedRecipeServiceServer) CreateRecipe(context.Context, *CreateRecipeRequest) (*GetRecipeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) UpdateRecipe(context.Context, *UpdateRecipeRequest) (*GetRecipeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) DeleteRecipe(context.Context, *DeleteRecipeRequest) (*DeleteRecipeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecipe not implemented")
+}
 func (UnimplementedRecipeServiceServer) mustEmbedUnimplementedRecipeServiceServer() {}
 func (UnimplementedRecipeServiceServer) testEmbeddedByValue()                       {}
 
@@ -150,6 +206,60 @@ func _RecipeService_QueryRecipe_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RecipeService_CreateRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecipeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).CreateRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_CreateRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).CreateRecipe(ctx, req.(*CreateRecipeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_UpdateRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRecipeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).UpdateRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_UpdateRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).UpdateRecipe(ctx, req.(*UpdateRecipeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_DeleteRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRecipeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).DeleteRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_DeleteRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).DeleteRecipe(ctx, req.(*DeleteRecipeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RecipeService_ServiceDesc is the grpc.ServiceDesc for RecipeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -165,6 +275,18 @@ var RecipeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryRecipe",
 			Handler:    _RecipeService_QueryRecipe_Handler,
 		},
+		{
+			MethodName: "CreateRecipe",
+			Handler:    _RecipeService_CreateRecipe_Handler,
+		},
+		{
+			MethodName: "UpdateRecipe",
+			Handler:    _RecipeService_UpdateRecipe_Handler,
+		},
+		{
+			MethodName: "DeleteRecipe",
+			Handler:    _RecipeService_DeleteRecipe_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "recipe/recipe.proto",